@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/go-kit/kit/auth/jwt"
 	"github.com/grilix/sworld/server"
@@ -136,6 +137,67 @@ func explorePortal(ctx context.Context, client *Client, characterID, portalID st
 	return exploreRes, nil
 }
 
+func portalReplay(ctx context.Context, client *Client, portalID string) (server.PortalReplayResponse, error) {
+	req := server.PortalReplayRequest{
+		PortalID: portalID,
+	}
+
+	res, err := client.e.PortalReplayEndpoint(ctx, req)
+	if err != nil {
+		return server.PortalReplayResponse{}, err
+	}
+	replayRes, ok := res.(server.PortalReplayResponse)
+	if !ok {
+		return server.PortalReplayResponse{}, ErrWrongResponse
+	}
+
+	return replayRes, nil
+}
+
+// stepReplay prints the events of a portal one by one, along with the
+// state of the portal after each of them
+func stepReplay(ctx context.Context, client *Client, portalID string) error {
+	replayRes, err := portalReplay(ctx, client, portalID)
+	if err != nil {
+		return err
+	}
+
+	events := make([]sworld.ReplayEvent, 0, len(replayRes.Events))
+	for _, event := range replayRes.Events {
+		events = append(events, sworld.ReplayEvent{
+			At:       time.Duration(event.At) * time.Millisecond,
+			Kind:     sworld.ReplayEventKind(event.Kind),
+			Source:   event.Source,
+			Target:   event.Target,
+			Position: event.Position,
+			Value:    event.Value,
+			Health:   event.Health,
+			Skill:    event.Skill,
+		})
+	}
+
+	replayer := sworld.NewReplayer(events)
+	fmt.Printf(" Replay of %s: %d events, enter to step, q to stop\n", portalID, replayer.Len())
+	for replayer.Step() {
+		frame := replayer.Frame()
+		event := frame.Event
+		fmt.Printf(" [%d %s] %s %s -> %s pos=%d value=%d health=%d\n",
+			frame.Step, event.At, event.Kind, event.Source, event.Target,
+			event.Position, event.Value, event.Health)
+		for _, actor := range frame.Actors {
+			fmt.Printf("    %s enemy=%v pos=%d health=%d/%d alive=%v\n",
+				actor.ID, actor.Enemy, actor.Position, actor.Health, actor.MaxHealth, actor.Alive)
+		}
+
+		var command string
+		fmt.Scanln(&command)
+		if command == "q" {
+			break
+		}
+	}
+	return nil
+}
+
 func authenticate(ctx context.Context, client *Client, c svc.Credentials) (server.AuthenticateResponse, error) {
 	req := server.AuthenticateRequest{Credentials: c}
 
@@ -248,6 +310,11 @@ func main() {
 			for _, portal := range portalsRes.Portals {
 				fmt.Printf(" -> %s\n", portal.ID)
 			}
+		case "replay":
+			err = stepReplay(ctx, client, portal.ID)
+			if err != nil {
+				fmt.Println(err.Error())
+			}
 		case "q", "quit":
 			return
 		default:
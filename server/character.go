@@ -2,7 +2,8 @@ package server
 
 import (
 	"errors"
-	// "github.com/grilix/sworld/sworld"
+
+	"github.com/grilix/sworld/sworld"
 )
 
 var (
@@ -13,7 +14,17 @@ var (
 )
 
 // ItemLocation represents an inventory location, used for referring to an item
+// The CharacterID is only set when the item is on a character inventory
 type ItemLocation struct {
-	BagID int `json:"bag_id"`
-	Slot  int `json:"slot"`
+	CharacterID string `json:"character_id,omitempty"`
+	BagID       int    `json:"bag_id"`
+	Slot        int    `json:"slot"`
+}
+
+func (l ItemLocation) itemLocation() sworld.ItemLocation {
+	return sworld.ItemLocation{
+		CharacterID: l.CharacterID,
+		BagID:       l.BagID,
+		Slot:        l.Slot,
+	}
 }
@@ -36,3 +36,28 @@ func TestInventoryDetails(t *testing.T) {
 		t.Error("Expected slot 0 on second bag to not be a stone, got ", bagTwo.Items[0].Stone)
 	}
 }
+
+func TestWeaponSlotDetails(t *testing.T) {
+	weapon := &sworld.Weapon{
+		Damage: 12,
+		Rarity: sworld.Rare,
+		Affixes: []sworld.Affix{
+			{Kind: sworld.LifeStealAffix, Value: 2},
+			{Kind: sworld.CritChanceAffix, Value: 4},
+		},
+	}
+
+	details := bagSlotDetails(0, weapon)
+	if details.Weapon == nil {
+		t.Fatal("Expected slot to be a weapon")
+	}
+	if details.Weapon.Rarity != "rare" {
+		t.Error("Expected weapon to be rare, got", details.Weapon.Rarity)
+	}
+	if len(details.Weapon.Affixes) != 2 {
+		t.Fatal("Expected weapon to have 2 affixes, got", len(details.Weapon.Affixes))
+	}
+	if details.Weapon.Affixes[0].Kind != "life_steal" {
+		t.Error("Expected first affix to be life_steal, got", details.Weapon.Affixes[0].Kind)
+	}
+}
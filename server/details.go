@@ -1,6 +1,11 @@
 package server
 
-import "github.com/grilix/sworld/sworld"
+import (
+	"time"
+
+	"github.com/grilix/sworld/sworld"
+	svc "github.com/grilix/sworld/sworldservice"
+)
 
 // UserDetails represents a user
 type UserDetails struct {
@@ -10,38 +15,123 @@ type UserDetails struct {
 
 // CharacterDetails represents a character in a response
 type CharacterDetails struct {
-	ID        string `json:"id"`
-	Level     int    `json:"level"`
-	Health    int    `json:"health"`
-	MaxHealth int    `json:"max_health"`
-	Exploring bool   `json:"exploring"`
+	ID          string `json:"id"`
+	Class       string `json:"class"`
+	Level       int    `json:"level"`
+	XP          int    `json:"xp"`
+	NextLevelXP int    `json:"next_level_xp"`
+	Health      int    `json:"health"`
+	MaxHealth   int    `json:"max_health"`
+	Exploring   bool   `json:"exploring"`
+
+	Attributes      AttributesDetails `json:"attributes"`
+	AttributePoints int               `json:"attribute_points"`
+	TalentPoints    int               `json:"talent_points"`
+	// RespecCost is the gold needed to refund the points of the character
+	RespecCost int `json:"respec_cost"`
+
+	Weapon *WeaponDetails `json:"weapon,omitempty"`
+}
+
+// AttributesDetails holds the primary attributes of a character
+type AttributesDetails struct {
+	Strength int `json:"strength"`
+	Agility  int `json:"agility"`
+	Vitality int `json:"vitality"`
+}
+
+// TalentDetails holds the information about a talent of a character
+type TalentDetails struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Rank     int    `json:"rank"`
+	MaxRank  int    `json:"max_rank"`
+	Requires string `json:"requires,omitempty"`
+	Unlocked bool   `json:"unlocked"`
+
+	Damage     int    `json:"damage,omitempty"`
+	Health     int    `json:"health,omitempty"`
+	Cooldown   int    `json:"cooldown,omitempty"`
+	Mitigation int    `json:"mitigation,omitempty"`
+	Skill      string `json:"skill,omitempty"`
+	SkillPower int    `json:"skill_power,omitempty"`
 }
 
 // StoneDetails holds the information about a stone item in a response
 type StoneDetails struct {
-	Level    int         `json:"level"`
-	Zone     ZoneDetails `json:"zone"`
-	Duration string      `json:"duration"`
+	Level     int         `json:"level"`
+	Zone      ZoneDetails `json:"zone"`
+	Duration  string      `json:"duration"`
+	Modifiers []string    `json:"modifiers,omitempty"`
+	// DropInterval is how often the portal spawns a wave
+	DropInterval string `json:"drop_interval,omitempty"`
 }
 
 // WeaponDetails holds the information about a weapon
 type WeaponDetails struct {
-	Damage int `json:"damage"`
+	Kind     string         `json:"kind"`
+	Damage   int            `json:"damage"`
+	Rarity   string         `json:"rarity"`
+	Affixes  []AffixDetails `json:"affixes,omitempty"`
+	Upgrades int            `json:"upgrades,omitempty"`
+}
+
+// ClassDetails holds the information about a character class
+type ClassDetails struct {
+	Name           string   `json:"name"`
+	Health         int      `json:"health"`
+	HealthPerLevel int      `json:"health_per_level"`
+	Damage         int      `json:"damage"`
+	DamagePerLevel int      `json:"damage_per_level"`
+	Weapons        []string `json:"weapons"`
+	Skills         []string `json:"skills"`
+}
+
+// AffixDetails holds the information about an item affix
+type AffixDetails struct {
+	Kind  string `json:"kind"`
+	Value int    `json:"value"`
+}
+
+// ConsumableDetails holds the information about a consumable item
+type ConsumableDetails struct {
+	Kind     string `json:"kind"`
+	Power    int    `json:"power"`
+	Duration string `json:"duration,omitempty"`
+	Quantity int    `json:"quantity"`
+}
+
+// MaterialDetails holds the information about a crafting material
+type MaterialDetails struct {
+	Kind     string `json:"kind"`
+	Quantity int    `json:"quantity"`
 }
 
 // BagSlotDetails represents a bag slot on a response
 type BagSlotDetails struct {
 	// TODO:
-	Slot   int            `json:"slot"`
-	Item   string         `json:"item,omitempty"`
-	Stone  *StoneDetails  `json:"stone,omitempty"`
-	Weapon *WeaponDetails `json:"weapon,omitempty"`
+	Slot       int                `json:"slot"`
+	Item       string             `json:"item,omitempty"`
+	Quantity   int                `json:"quantity,omitempty"`
+	Stone      *StoneDetails      `json:"stone,omitempty"`
+	Weapon     *WeaponDetails     `json:"weapon,omitempty"`
+	Consumable *ConsumableDetails `json:"consumable,omitempty"`
+	Bag        *BagItemDetails    `json:"bag,omitempty"`
+	Material   *MaterialDetails   `json:"material,omitempty"`
 }
 
 // BagDetails represents a bag in a response
 type BagDetails struct {
-	ID    int               `json:"id"`
-	Items []*BagSlotDetails `json:"items"`
+	ID       int               `json:"id"`
+	Kind     string            `json:"kind"`
+	Capacity int               `json:"capacity"`
+	Items    []*BagSlotDetails `json:"items"`
+}
+
+// BagItemDetails holds the information about a bag that is not attached
+type BagItemDetails struct {
+	Kind     string `json:"kind"`
+	Capacity int    `json:"capacity"`
 }
 
 // ZoneDetails represents the details of a zone
@@ -50,15 +140,266 @@ type ZoneDetails struct {
 	Name string `json:"name"`
 }
 
+// TradeOfferDetails represents what a user gives on a trade
+type TradeOfferDetails struct {
+	User      UserDetails       `json:"user"`
+	Items     []*BagSlotDetails `json:"items"`
+	Gold      int               `json:"gold"`
+	Confirmed bool              `json:"confirmed"`
+}
+
+// TradeEventDetails represents an entry of the trade log
+type TradeEventDetails struct {
+	At     string `json:"at"`
+	UserID string `json:"user_id,omitempty"`
+	Action string `json:"action"`
+}
+
+// TradeDetails represents a trade in a response
+type TradeDetails struct {
+	ID        string               `json:"id"`
+	Status    string               `json:"status"`
+	CreatedAt string               `json:"created_at"`
+	ExpiresAt string               `json:"expires_at"`
+	Offers    []*TradeOfferDetails `json:"offers"`
+	Log       []*TradeEventDetails `json:"log"`
+}
+
+// AuctionDetails represents an auction in a response
+type AuctionDetails struct {
+	ID          string          `json:"id"`
+	Seller      UserDetails     `json:"seller"`
+	Item        *BagSlotDetails `json:"item"`
+	StartingBid int             `json:"starting_bid,omitempty"`
+	Buyout      int             `json:"buyout,omitempty"`
+	Bid         int             `json:"bid,omitempty"`
+	MinimumBid  int             `json:"minimum_bid"`
+	Bidder      *UserDetails    `json:"bidder,omitempty"`
+	Status      string          `json:"status"`
+	EndsAt      string          `json:"ends_at"`
+	TimeLeft    int             `json:"time_left"`
+}
+
+// IngredientDetails represents a material needed by a recipe
+type IngredientDetails struct {
+	Material string `json:"material"`
+	Quantity int    `json:"quantity"`
+}
+
+// RecipeDetails represents a crafting recipe
+type RecipeDetails struct {
+	ID        string              `json:"id"`
+	Name      string              `json:"name"`
+	Materials []IngredientDetails `json:"materials"`
+	Gold      int                 `json:"gold,omitempty"`
+	Input     string              `json:"input,omitempty"`
+	Output    string              `json:"output"`
+	Result    *BagSlotDetails     `json:"result,omitempty"`
+	Damage    int                 `json:"damage,omitempty"`
+}
+
 // PortalDetails holds the details for a portal
 type PortalDetails struct {
-	ID       string         `json:"id"`
-	IsOpen   bool           `json:"is_open"`
-	Duration int            `json:"duration"`
-	TimeLeft int            `json:"time_left"`
-	Level    int            `json:"level"`
-	Zone     ZoneDetails    `json:"zone"`
-	Enemies  []EnemyDetails `json:"enemies,omitempty"`
+	ID        string         `json:"id"`
+	IsOpen    bool           `json:"is_open"`
+	Duration  int            `json:"duration"`
+	TimeLeft  int            `json:"time_left"`
+	Level     int            `json:"level"`
+	Zone      ZoneDetails    `json:"zone"`
+	Seed      int64          `json:"seed"`
+	Length    int            `json:"length,omitempty"`
+	Rooms     []RoomDetails  `json:"rooms,omitempty"`
+	Enemies   []EnemyDetails `json:"enemies,omitempty"`
+	Modifiers []string       `json:"modifiers,omitempty"`
+	// DropInterval, Waves and NextWave are only set for portals spawning
+	// waves, the times are in seconds
+	DropInterval int `json:"drop_interval,omitempty"`
+	Waves        int `json:"waves,omitempty"`
+	NextWave     int `json:"next_wave,omitempty"`
+}
+
+// PortalRunDetails holds the summary of a portal run
+type PortalRunDetails struct {
+	ID            string            `json:"id"`
+	PortalID      string            `json:"portal_id"`
+	CharacterID   string            `json:"character_id"`
+	Stone         *StoneDetails     `json:"stone"`
+	Seed          int64             `json:"seed"`
+	StartedAt     string            `json:"started_at"`
+	EndedAt       string            `json:"ended_at"`
+	Duration      int               `json:"duration"`
+	Depth         int               `json:"depth"`
+	EnemiesKilled int               `json:"enemies_killed"`
+	Gold          int               `json:"gold"`
+	ItemCount     int               `json:"item_count"`
+	Items         []*BagSlotDetails `json:"items,omitempty"`
+	Waves         int               `json:"waves,omitempty"`
+	Result        string            `json:"result"`
+}
+
+// ReplayEventDetails represents an entry of the portal event log
+type ReplayEventDetails struct {
+	Step int `json:"step"`
+	// At is the time since the portal was opened, in milliseconds
+	At       int64           `json:"at"`
+	Kind     string          `json:"kind"`
+	Source   string          `json:"source,omitempty"`
+	Target   string          `json:"target,omitempty"`
+	Position int             `json:"position,omitempty"`
+	Value    int             `json:"value,omitempty"`
+	Health   int             `json:"health,omitempty"`
+	Skill    string          `json:"skill,omitempty"`
+	Item     *BagSlotDetails `json:"item,omitempty"`
+}
+
+// ReplayActorDetails is the state of a character or an enemy on a replay
+type ReplayActorDetails struct {
+	ID        string `json:"id"`
+	Enemy     bool   `json:"enemy"`
+	Position  int    `json:"position"`
+	Health    int    `json:"health"`
+	MaxHealth int    `json:"max_health"`
+	Alive     bool   `json:"alive"`
+	Present   bool   `json:"present"`
+}
+
+// ReplayFrameDetails is the state of a portal at a step of its replay
+type ReplayFrameDetails struct {
+	Step   int                  `json:"step"`
+	Event  *ReplayEventDetails  `json:"event,omitempty"`
+	Actors []ReplayActorDetails `json:"actors"`
+	Gold   int                  `json:"gold"`
+	Items  int                  `json:"items"`
+	Waves  int                  `json:"waves"`
+	Closed bool                 `json:"closed"`
+}
+
+// LeaderboardEntryDetails represents a user ranked on a leaderboard
+type LeaderboardEntryDetails struct {
+	Rank        int         `json:"rank"`
+	User        UserDetails `json:"user"`
+	CharacterID string      `json:"character_id"`
+	Zone        ZoneDetails `json:"zone"`
+	StoneLevel  int         `json:"stone_level"`
+	Score       int         `json:"score"`
+	At          string      `json:"at"`
+}
+
+// AchievementRewardDetails holds the reward of an achievement
+type AchievementRewardDetails struct {
+	Gold  int    `json:"gold,omitempty"`
+	Bag   string `json:"bag,omitempty"`
+	Title string `json:"title,omitempty"`
+}
+
+// AchievementDetails holds the progress of the user on an achievement
+type AchievementDetails struct {
+	ID          string                   `json:"id"`
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	Event       string                   `json:"event"`
+	Goal        int                      `json:"goal"`
+	Progress    int                      `json:"progress"`
+	Unlocked    bool                     `json:"unlocked"`
+	UnlockedAt  string                   `json:"unlocked_at,omitempty"`
+	Claimed     bool                     `json:"claimed"`
+	Reward      AchievementRewardDetails `json:"reward"`
+}
+
+// QuestObjectiveDetails holds what has to be done to complete a quest
+type QuestObjectiveDetails struct {
+	Event    string `json:"event"`
+	Goal     int    `json:"goal"`
+	Zone     string `json:"zone,omitempty"`
+	MinLevel int    `json:"min_level,omitempty"`
+}
+
+// QuestRewardDetails holds the reward of a quest
+type QuestRewardDetails struct {
+	Gold  int               `json:"gold,omitempty"`
+	Items []*BagSlotDetails `json:"items,omitempty"`
+}
+
+// QuestDetails holds the progress of the user on a quest
+type QuestDetails struct {
+	ID          string                `json:"id"`
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Period      string                `json:"period"`
+	State       string                `json:"state"`
+	Progress    int                   `json:"progress"`
+	ExpiresAt   string                `json:"expires_at"`
+	Objective   QuestObjectiveDetails `json:"objective"`
+	Reward      QuestRewardDetails    `json:"reward"`
+}
+
+// GuildMemberDetails holds a member of a guild
+type GuildMemberDetails struct {
+	User     UserDetails `json:"user"`
+	Role     string      `json:"role"`
+	JoinedAt string      `json:"joined_at"`
+}
+
+// GuildDetails holds the information about a guild
+type GuildDetails struct {
+	ID        string                `json:"id"`
+	Name      string                `json:"name"`
+	Gold      int                   `json:"gold"`
+	CreatedAt string                `json:"created_at"`
+	Members   []*GuildMemberDetails `json:"members"`
+	Stash     []*BagDetails         `json:"stash"`
+}
+
+// PartyMemberDetails holds a member of a party
+type PartyMemberDetails struct {
+	User        UserDetails `json:"user"`
+	CharacterID string      `json:"character_id"`
+	Leader      bool        `json:"leader"`
+	JoinedAt    string      `json:"joined_at"`
+}
+
+// PartyDetails holds the information about a party
+type PartyDetails struct {
+	ID        string                `json:"id"`
+	CreatedAt string                `json:"created_at"`
+	Members   []*PartyMemberDetails `json:"members"`
+	// Matched, ZoneID and Level are set on parties formed by the matchmaking
+	Matched bool   `json:"matched"`
+	ZoneID  string `json:"zone_id,omitempty"`
+	Level   int    `json:"level,omitempty"`
+}
+
+// MatchmakingDetails holds the status of the user on the matchmaking
+type MatchmakingDetails struct {
+	ZoneID   string        `json:"zone_id"`
+	Level    int           `json:"level"`
+	Position int           `json:"position,omitempty"`
+	Queued   int           `json:"queued,omitempty"`
+	QueuedAt string        `json:"queued_at,omitempty"`
+	Party    *PartyDetails `json:"party,omitempty"`
+}
+
+// ChatMessageDetails holds a chat message
+type ChatMessageDetails struct {
+	ID      string       `json:"id"`
+	Channel string       `json:"channel"`
+	Target  string       `json:"target,omitempty"`
+	From    UserDetails  `json:"from"`
+	To      *UserDetails `json:"to,omitempty"`
+	Text    string       `json:"text"`
+	At      string       `json:"at"`
+}
+
+// ChatSettingsDetails holds the users muted and blocked by the user
+type ChatSettingsDetails struct {
+	Muted   []UserDetails `json:"muted"`
+	Blocked []UserDetails `json:"blocked"`
+}
+
+// RoomDetails represents a room from the portal layout
+type RoomDetails struct {
+	Position int    `json:"position"`
+	Type     string `json:"type"`
 }
 
 // EnemyDetails contains details about an enemy
@@ -67,57 +408,214 @@ type EnemyDetails struct {
 	Health    int    `json:"health"`
 	MaxHealth int    `json:"max_health"`
 	Level     int    `json:"level"`
+	Elite     bool   `json:"elite,omitempty"`
+}
+
+func weaponDetails(weapon *sworld.Weapon) *WeaponDetails {
+	affixes := make([]AffixDetails, 0, len(weapon.Affixes))
+	for _, affix := range weapon.Affixes {
+		affixes = append(affixes, AffixDetails{
+			Kind:  affix.Kind.String(),
+			Value: affix.Value,
+		})
+	}
+
+	return &WeaponDetails{
+		Kind:     weapon.Kind.String(),
+		Damage:   weapon.Damage,
+		Rarity:   weapon.Rarity.String(),
+		Affixes:  affixes,
+		Upgrades: weapon.Upgrades,
+	}
+}
+
+func characterDetails(character *sworld.Character) *CharacterDetails {
+	details := &CharacterDetails{
+		ID:          character.ID,
+		Class:       string(character.Class),
+		Level:       character.Level,
+		XP:          character.XP,
+		NextLevelXP: character.NextLevelXP(),
+		Health:      character.Health,
+		MaxHealth:   character.MaxHealth,
+		Exploring:   character.Exploring,
+		Attributes: AttributesDetails{
+			Strength: character.Attributes.Strength,
+			Agility:  character.Attributes.Agility,
+			Vitality: character.Attributes.Vitality,
+		},
+		AttributePoints: character.AttributePoints,
+		TalentPoints:    character.TalentPoints,
+		RespecCost:      svc.RespecCost(character),
+	}
+	if character.Weapon != nil {
+		details.Weapon = weaponDetails(character.Weapon)
+	}
+	return details
+}
+
+func classDetails(class sworld.ClassStats) *ClassDetails {
+	weapons := make([]string, 0, len(class.Weapons))
+	for _, kind := range class.Weapons {
+		weapons = append(weapons, kind.String())
+	}
+	skills := make([]string, 0, len(class.Skills))
+	for _, skill := range class.Skills {
+		skills = append(skills, skill.Name)
+	}
+
+	return &ClassDetails{
+		Name:           string(class.Class),
+		Health:         class.Health,
+		HealthPerLevel: class.HealthPerLevel,
+		Damage:         class.Damage,
+		DamagePerLevel: class.DamagePerLevel,
+		Weapons:        weapons,
+		Skills:         skills,
+	}
+}
+
+func talentDetails(status svc.TalentStatus) *TalentDetails {
+	talent := status.Talent
+	return &TalentDetails{
+		ID:         talent.ID,
+		Name:       talent.Name,
+		Rank:       status.Rank,
+		MaxRank:    talent.MaxRank,
+		Requires:   talent.Requires,
+		Unlocked:   status.Unlocked,
+		Damage:     talent.Bonus.Damage,
+		Health:     talent.Bonus.Health,
+		Cooldown:   talent.Bonus.Cooldown,
+		Mitigation: talent.Bonus.Mitigation,
+		Skill:      talent.Bonus.Skill,
+		SkillPower: talent.Bonus.SkillPower,
+	}
+}
+
+func modifierNames(modifiers []sworld.StoneModifier) []string {
+	if len(modifiers) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(modifiers))
+	for _, modifier := range modifiers {
+		names = append(names, modifier.String())
+	}
+	return names
+}
+
+func stoneDetails(stone *sworld.PortalStone) *StoneDetails {
+	details := &StoneDetails{
+		Level:     stone.Level,
+		Duration:  stone.Duration.String(),
+		Modifiers: modifierNames(stone.Modifiers),
+		Zone: ZoneDetails{
+			ID:   stone.Zone.ID,
+			Name: stone.Zone.Name,
+		},
+	}
+	if interval := stone.WaveInterval(); interval > 0 {
+		details.DropInterval = interval.String()
+	}
+	return details
 }
 
 func bagSlotDetails(slot int, item sworld.Item) *BagSlotDetails {
 	details := &BagSlotDetails{
 		Slot: slot,
 	}
+	if stack, ok := item.(sworld.Stackable); ok {
+		details.Quantity = stack.Count()
+	}
 
 	// TODO: Not sure what could be the best approach here, we'll just try every item type for now
 	stoneItem, ok := item.(*sworld.PortalStone)
 	if ok {
 		details.Item = "stone"
-		details.Stone = &StoneDetails{
-			Level:    stoneItem.Level,
-			Duration: stoneItem.Duration.String(),
-			Zone: ZoneDetails{
-				ID:   stoneItem.Zone.ID,
-				Name: stoneItem.Zone.Name,
-			},
-		}
+		details.Stone = stoneDetails(stoneItem)
 		return details
 	}
 	weaponItem, ok := item.(*sworld.Weapon)
 	if ok {
 		details.Item = "weapon"
-		details.Weapon = &WeaponDetails{
-			Damage: weaponItem.Damage,
+		details.Weapon = weaponDetails(weaponItem)
+		return details
+	}
+	consumableItem, ok := item.(*sworld.Consumable)
+	if ok {
+		details.Item = "consumable"
+		details.Consumable = &ConsumableDetails{
+			Kind:     consumableItem.Kind.String(),
+			Power:    consumableItem.Power,
+			Quantity: consumableItem.Quantity,
+		}
+		if consumableItem.Duration > 0 {
+			details.Consumable.Duration = consumableItem.Duration.String()
+		}
+		return details
+	}
+	bagItem, ok := item.(*sworld.BagItem)
+	if ok {
+		details.Item = "bag"
+		details.Bag = &BagItemDetails{
+			Kind:     bagItem.Kind,
+			Capacity: bagItem.Capacity,
+		}
+		return details
+	}
+	materialItem, ok := item.(*sworld.Material)
+	if ok {
+		details.Item = "material"
+		details.Material = &MaterialDetails{
+			Kind:     materialItem.Kind.String(),
+			Quantity: materialItem.Quantity,
 		}
 	}
 	return details
 }
 
+func bagDetails(id int, bag sworld.Bag) *BagDetails {
+	items := bag.Items()
+
+	bagItems := make([]*BagSlotDetails, 0, len(items))
+	for slot, item := range items {
+		bagItems = append(bagItems, bagSlotDetails(slot, item))
+	}
+
+	return &BagDetails{
+		ID:       id,
+		Kind:     bag.Kind(),
+		Capacity: bag.Capacity(),
+		Items:    bagItems,
+	}
+}
+
 func inventoryDetails(inventory []sworld.Bag) []*BagDetails {
 	characterBags := make([]*BagDetails, 0, len(inventory))
 	for id, bag := range inventory {
-		items := bag.Items()
+		characterBags = append(characterBags, bagDetails(id, bag))
+	}
+	return characterBags
+}
 
-		bagItems := make([]*BagSlotDetails, 0, len(items))
-		for slot, item := range items {
-			bagItems = append(bagItems, bagSlotDetails(slot, item))
+func layoutRooms(layout *sworld.Layout) []RoomDetails {
+	rooms := make([]RoomDetails, 0, len(layout.Rooms))
+	for position, room := range layout.Rooms {
+		if room == sworld.CorridorRoom {
+			continue
 		}
-
-		characterBags = append(characterBags, &BagDetails{
-			ID:    id,
-			Items: bagItems,
+		rooms = append(rooms, RoomDetails{
+			Position: position,
+			Type:     room.String(),
 		})
 	}
-	return characterBags
+	return rooms
 }
 
 func portalDetails(portal *sworld.Portal, listing bool) *PortalDetails {
 	var deadEnemies []EnemyDetails
+	var rooms []RoomDetails
+	var length int
 
 	if !listing {
 		enemies := portal.DeadEnemies()
@@ -128,24 +626,368 @@ func portalDetails(portal *sworld.Portal, listing bool) *PortalDetails {
 				Health:    enemy.Health,
 				MaxHealth: enemy.MaxHealth,
 				Level:     enemy.Level,
+				Elite:     enemy.Elite,
 			})
 		}
+
+		if portal.Layout != nil {
+			rooms = layoutRooms(portal.Layout)
+		}
+	}
+	if portal.Layout != nil {
+		length = portal.Layout.Length()
 	}
 
 	timeLeft := 0
-	if portal.IsOpen {
+	if portal.Open() {
 		timeLeft = int(portal.TimeLeft().Seconds())
 	}
 	return &PortalDetails{
-		ID:       portal.ID,
-		IsOpen:   portal.IsOpen,
-		Duration: int(portal.PortalStone.Duration.Seconds()),
-		TimeLeft: timeLeft,
-		Level:    portal.PortalStone.Level,
-		Enemies:  deadEnemies,
+		ID:        portal.ID,
+		IsOpen:    portal.Open(),
+		Duration:  int(portal.PortalStone.Duration.Seconds()),
+		TimeLeft:  timeLeft,
+		Level:     portal.PortalStone.Level,
+		Seed:      portal.Seed,
+		Length:    length,
+		Rooms:     rooms,
+		Enemies:   deadEnemies,
+		Modifiers: modifierNames(portal.PortalStone.Modifiers),
 		Zone: ZoneDetails{
 			ID:   portal.PortalStone.Zone.ID,
 			Name: portal.PortalStone.Zone.Name,
 		},
+		DropInterval: int(portal.PortalStone.WaveInterval().Seconds()),
+		Waves:        portal.Waves(),
+		NextWave:     int(portal.NextWave().Seconds()),
+	}
+}
+
+// portalRunDetails returns the summary of a run, the items are only
+// included when listing is false
+func portalRunDetails(run *sworld.RunRecord, listing bool) *PortalRunDetails {
+	details := &PortalRunDetails{
+		ID:            run.ID,
+		PortalID:      run.PortalID,
+		CharacterID:   run.CharacterID,
+		Stone:         stoneDetails(&run.Stone),
+		Seed:          run.Seed,
+		StartedAt:     run.StartedAt.Format(time.RFC3339),
+		EndedAt:       run.EndedAt.Format(time.RFC3339),
+		Duration:      int(run.Duration().Seconds()),
+		Depth:         run.Depth,
+		EnemiesKilled: run.EnemiesKilled,
+		Gold:          run.Gold,
+		ItemCount:     len(run.Items),
+		Waves:         run.Waves,
+		Result:        string(run.Result),
+	}
+	if !listing {
+		details.Items = make([]*BagSlotDetails, 0, len(run.Items))
+		for i, item := range run.Items {
+			details.Items = append(details.Items, bagSlotDetails(i, item))
+		}
+	}
+	return details
+}
+
+func replayEventDetails(step int, event *sworld.ReplayEvent) *ReplayEventDetails {
+	details := &ReplayEventDetails{
+		Step:     step,
+		At:       int64(event.At / time.Millisecond),
+		Kind:     string(event.Kind),
+		Source:   event.Source,
+		Target:   event.Target,
+		Position: event.Position,
+		Value:    event.Value,
+		Health:   event.Health,
+		Skill:    event.Skill,
+	}
+	if event.Item != nil {
+		details.Item = bagSlotDetails(0, event.Item)
+	}
+	return details
+}
+
+func replayFrameDetails(frame sworld.ReplayFrame) *ReplayFrameDetails {
+	details := &ReplayFrameDetails{
+		Step:   frame.Step,
+		Actors: make([]ReplayActorDetails, 0, len(frame.Actors)),
+		Gold:   frame.Gold,
+		Items:  frame.Items,
+		Waves:  frame.Waves,
+		Closed: frame.Closed,
+	}
+	if frame.Event != nil {
+		details.Event = replayEventDetails(frame.Step, frame.Event)
+	}
+	for _, actor := range frame.Actors {
+		details.Actors = append(details.Actors, ReplayActorDetails{
+			ID:        actor.ID,
+			Enemy:     actor.Enemy,
+			Position:  actor.Position,
+			Health:    actor.Health,
+			MaxHealth: actor.MaxHealth,
+			Alive:     actor.Alive,
+			Present:   actor.Present,
+		})
+	}
+	return details
+}
+
+func leaderboardEntryDetails(entry svc.LeaderboardEntry) *LeaderboardEntryDetails {
+	return &LeaderboardEntryDetails{
+		Rank: entry.Rank,
+		User: UserDetails{
+			ID:       entry.User.ID,
+			Username: entry.User.Username,
+		},
+		CharacterID: entry.CharacterID,
+		Zone: ZoneDetails{
+			ID:   entry.Zone.ID,
+			Name: entry.Zone.Name,
+		},
+		StoneLevel: entry.StoneLevel,
+		Score:      entry.Score,
+		At:         entry.At.Format(time.RFC3339),
+	}
+}
+
+func achievementDetails(status svc.AchievementStatus) *AchievementDetails {
+	achievement := status.Achievement
+	details := &AchievementDetails{
+		ID:          achievement.ID,
+		Name:        achievement.Name,
+		Description: achievement.Description,
+		Event:       string(achievement.Event),
+		Goal:        achievement.Goal,
+		Progress:    status.Progress,
+		Unlocked:    status.Unlocked,
+		Claimed:     status.Claimed,
+		Reward: AchievementRewardDetails{
+			Gold:  achievement.Reward.Gold,
+			Bag:   achievement.Reward.Bag,
+			Title: achievement.Reward.Title,
+		},
+	}
+	if status.Unlocked {
+		details.UnlockedAt = status.UnlockedAt.Format(time.RFC3339)
+	}
+	return details
+}
+
+func questDetails(status svc.QuestStatus) *QuestDetails {
+	quest := status.Quest
+	details := &QuestDetails{
+		ID:          quest.ID,
+		Name:        quest.Name,
+		Description: quest.Description,
+		Period:      string(quest.Period),
+		State:       string(status.State),
+		Progress:    status.Progress,
+		ExpiresAt:   status.ExpiresAt.Format(time.RFC3339),
+		Objective: QuestObjectiveDetails{
+			Event:    string(quest.Objective.Event),
+			Goal:     quest.Objective.Goal,
+			Zone:     quest.Objective.Zone,
+			MinLevel: quest.Objective.MinLevel,
+		},
+		Reward: QuestRewardDetails{
+			Gold: quest.Reward.Gold,
+		},
+	}
+	for slot, item := range status.RewardItems {
+		details.Reward.Items = append(details.Reward.Items, bagSlotDetails(slot, item))
+	}
+	return details
+}
+
+func guildDetails(guild *svc.Guild) *GuildDetails {
+	details := &GuildDetails{
+		ID:        guild.ID,
+		Name:      guild.Name,
+		Gold:      guild.Gold,
+		CreatedAt: guild.CreatedAt.Format(time.RFC3339),
+		Members:   make([]*GuildMemberDetails, 0, len(guild.Members)),
+		Stash:     inventoryDetails(guild.Stash),
+	}
+	for _, member := range guild.Members {
+		details.Members = append(details.Members, &GuildMemberDetails{
+			User: UserDetails{
+				ID:       member.User.ID,
+				Username: member.User.Username,
+			},
+			Role:     string(member.Role),
+			JoinedAt: member.JoinedAt.Format(time.RFC3339),
+		})
+	}
+	return details
+}
+
+func partyDetails(party *svc.Party) *PartyDetails {
+	details := &PartyDetails{
+		ID:        party.ID,
+		CreatedAt: party.CreatedAt.Format(time.RFC3339),
+		Members:   make([]*PartyMemberDetails, 0, len(party.Members)),
+		Matched:   party.Matched,
+		ZoneID:    party.ZoneID,
+		Level:     party.Level,
+	}
+	for _, member := range party.Members {
+		details.Members = append(details.Members, &PartyMemberDetails{
+			User: UserDetails{
+				ID:       member.User.ID,
+				Username: member.User.Username,
+			},
+			CharacterID: member.CharacterID,
+			Leader:      member.User.ID == party.Leader.ID,
+			JoinedAt:    member.JoinedAt.Format(time.RFC3339),
+		})
+	}
+	return details
+}
+
+func matchmakingDetails(status *svc.MatchmakingStatus) *MatchmakingDetails {
+	details := &MatchmakingDetails{
+		ZoneID:   status.ZoneID,
+		Level:    status.Level,
+		Position: status.Position,
+		Queued:   status.Queued,
+	}
+	if !status.QueuedAt.IsZero() {
+		details.QueuedAt = status.QueuedAt.Format(time.RFC3339)
+	}
+	if status.Party != nil {
+		details.Party = partyDetails(status.Party)
+	}
+	return details
+}
+
+func chatMessageDetails(message *svc.ChatMessage) *ChatMessageDetails {
+	details := &ChatMessageDetails{
+		ID:      message.ID,
+		Channel: string(message.Channel),
+		Target:  message.Target,
+		From: UserDetails{
+			ID:       message.From.ID,
+			Username: message.From.Username,
+		},
+		Text: message.Text,
+		At:   message.At.Format(time.RFC3339),
+	}
+	if message.To != nil {
+		details.To = &UserDetails{
+			ID:       message.To.ID,
+			Username: message.To.Username,
+		}
+	}
+	return details
+}
+
+func chatSettingsDetails(settings *svc.ChatSettings) *ChatSettingsDetails {
+	details := &ChatSettingsDetails{
+		Muted:   make([]UserDetails, 0, len(settings.Muted)),
+		Blocked: make([]UserDetails, 0, len(settings.Blocked)),
+	}
+	for _, user := range settings.Muted {
+		details.Muted = append(details.Muted, UserDetails{ID: user.ID, Username: user.Username})
+	}
+	for _, user := range settings.Blocked {
+		details.Blocked = append(details.Blocked, UserDetails{ID: user.ID, Username: user.Username})
 	}
+	return details
+}
+
+func tradeDetails(trade *svc.Trade) *TradeDetails {
+	details := &TradeDetails{
+		ID:        trade.ID,
+		Status:    string(trade.Status),
+		CreatedAt: trade.CreatedAt.Format(time.RFC3339),
+		ExpiresAt: trade.ExpiresAt.Format(time.RFC3339),
+		Offers:    make([]*TradeOfferDetails, 0, len(trade.Offers)),
+		Log:       make([]*TradeEventDetails, 0, len(trade.Log)),
+	}
+	for _, offer := range trade.Offers {
+		items := make([]*BagSlotDetails, 0, len(offer.Items))
+		for slot, item := range offer.Items {
+			items = append(items, bagSlotDetails(slot, item.Item))
+		}
+		details.Offers = append(details.Offers, &TradeOfferDetails{
+			User: UserDetails{
+				ID:       offer.User.ID,
+				Username: offer.User.Username,
+			},
+			Items:     items,
+			Gold:      offer.Gold,
+			Confirmed: offer.Confirmed,
+		})
+	}
+	for _, event := range trade.Log {
+		details.Log = append(details.Log, &TradeEventDetails{
+			At:     event.At.Format(time.RFC3339),
+			UserID: event.UserID,
+			Action: event.Action,
+		})
+	}
+	return details
+}
+
+func auctionDetails(auction *svc.Auction) *AuctionDetails {
+	details := &AuctionDetails{
+		ID: auction.ID,
+		Seller: UserDetails{
+			ID:       auction.Seller.ID,
+			Username: auction.Seller.Username,
+		},
+		Item:        bagSlotDetails(0, auction.Item),
+		StartingBid: auction.StartingBid,
+		Buyout:      auction.Buyout,
+		Bid:         auction.Bid,
+		MinimumBid:  auction.MinimumBid(),
+		Status:      string(auction.Status),
+		EndsAt:      auction.EndsAt.Format(time.RFC3339),
+	}
+	if auction.Bidder != nil {
+		details.Bidder = &UserDetails{
+			ID:       auction.Bidder.ID,
+			Username: auction.Bidder.Username,
+		}
+	}
+	if auction.Status == svc.AuctionActive {
+		details.TimeLeft = int(time.Until(auction.EndsAt).Seconds())
+	}
+	return details
+}
+
+func recipeDetails(recipe sworld.Recipe) *RecipeDetails {
+	details := &RecipeDetails{
+		ID:        recipe.ID,
+		Name:      recipe.Name,
+		Materials: make([]IngredientDetails, 0, len(recipe.Materials)),
+		Gold:      recipe.Gold,
+		Input:     recipe.Input,
+		Output:    recipe.Output.String(),
+		Damage:    recipe.Damage,
+	}
+	for _, ingredient := range recipe.Materials {
+		details.Materials = append(details.Materials, IngredientDetails{
+			Material: ingredient.Kind.String(),
+			Quantity: ingredient.Quantity,
+		})
+	}
+
+	switch recipe.Output {
+	case sworld.CraftConsumable:
+		consumable := recipe.Consumable
+		details.Result = bagSlotDetails(0, &consumable)
+	case sworld.CraftStone:
+		details.Result = &BagSlotDetails{
+			Item: "stone",
+			Stone: &StoneDetails{
+				Level:    recipe.Stone.Level,
+				Duration: recipe.Stone.Duration.String(),
+			},
+		}
+	}
+	return details
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	stdjwt "github.com/dgrijalva/jwt-go"
 	"github.com/go-kit/kit/endpoint"
@@ -28,41 +29,181 @@ type WrongRequestError struct {
 
 // Endpoints hold the endpoints
 type Endpoints struct {
-	AuthenticateEndpoint      endpoint.Endpoint
-	ViewUserInventoryEndpoint endpoint.Endpoint
-	MergeStonesEndpoint       endpoint.Endpoint
+	AuthenticateEndpoint       endpoint.Endpoint
+	ViewUserInventoryEndpoint  endpoint.Endpoint
+	SearchInventoryEndpoint    endpoint.Endpoint
+	MergeStonesEndpoint        endpoint.Endpoint
+	PreviewMergeStonesEndpoint endpoint.Endpoint
+	MergeAllStonesEndpoint     endpoint.Endpoint
+	SplitStackEndpoint         endpoint.Endpoint
+	MergeStacksEndpoint        endpoint.Endpoint
+	MoveItemEndpoint           endpoint.Endpoint
+	BuyBagEndpoint             endpoint.Endpoint
+	AttachBagEndpoint          endpoint.Endpoint
+	UpgradeBagEndpoint         endpoint.Endpoint
+	SortBagEndpoint            endpoint.Endpoint
+	ViewShopEndpoint           endpoint.Endpoint
+	BuyShopItemEndpoint        endpoint.Endpoint
+	SellItemEndpoint           endpoint.Endpoint
+	ListRecipesEndpoint        endpoint.Endpoint
+	CraftEndpoint              endpoint.Endpoint
 
 	SpawnCharacterEndpoint         endpoint.Endpoint
 	ViewCharacterEndpoint          endpoint.Endpoint
 	ListCharactersEndpoint         endpoint.Endpoint
+	ListClassesEndpoint            endpoint.Endpoint
 	ViewCharacterInventoryEndpoint endpoint.Endpoint
 	DropCharacterItemEndpoint      endpoint.Endpoint
 	TakeCharacterItemEndpoint      endpoint.Endpoint
-
-	OpenPortalEndpoint    endpoint.Endpoint
-	ExplorePortalEndpoint endpoint.Endpoint
-	ViewPortalEndpoint    endpoint.Endpoint
-	ListPortalsEndpoint   endpoint.Endpoint
+	EquipCharacterItemEndpoint     endpoint.Endpoint
+	UseItemEndpoint                endpoint.Endpoint
+	AllocateAttributesEndpoint     endpoint.Endpoint
+	ListTalentsEndpoint            endpoint.Endpoint
+	LearnTalentEndpoint            endpoint.Endpoint
+	RespecCharacterEndpoint        endpoint.Endpoint
+
+	OpenPortalEndpoint        endpoint.Endpoint
+	ExplorePortalEndpoint     endpoint.Endpoint
+	OpenTradeEndpoint         endpoint.Endpoint
+	ListTradesEndpoint        endpoint.Endpoint
+	ViewTradeEndpoint         endpoint.Endpoint
+	SetTradeOfferEndpoint     endpoint.Endpoint
+	ConfirmTradeEndpoint      endpoint.Endpoint
+	CancelTradeEndpoint       endpoint.Endpoint
+	CreateAuctionEndpoint     endpoint.Endpoint
+	SearchAuctionsEndpoint    endpoint.Endpoint
+	ClaimAuctionItemsEndpoint endpoint.Endpoint
+	ViewAuctionEndpoint       endpoint.Endpoint
+	BidAuctionEndpoint        endpoint.Endpoint
+	BuyoutAuctionEndpoint     endpoint.Endpoint
+	ViewPortalEndpoint        endpoint.Endpoint
+	ListPortalsEndpoint       endpoint.Endpoint
+	PortalHistoryEndpoint     endpoint.Endpoint
+	ViewPortalRunEndpoint     endpoint.Endpoint
+	PortalReplayEndpoint      endpoint.Endpoint
+	LeaderboardEndpoint       endpoint.Endpoint
+	ListAchievementsEndpoint  endpoint.Endpoint
+	ClaimAchievementEndpoint  endpoint.Endpoint
+	ListQuestsEndpoint        endpoint.Endpoint
+	AcceptQuestEndpoint       endpoint.Endpoint
+	ClaimQuestEndpoint        endpoint.Endpoint
+	CreateGuildEndpoint       endpoint.Endpoint
+	ViewGuildEndpoint         endpoint.Endpoint
+	InviteToGuildEndpoint     endpoint.Endpoint
+	JoinGuildEndpoint         endpoint.Endpoint
+	LeaveGuildEndpoint        endpoint.Endpoint
+	KickGuildMemberEndpoint   endpoint.Endpoint
+	SetGuildRoleEndpoint      endpoint.Endpoint
+	DepositGuildItemEndpoint  endpoint.Endpoint
+	WithdrawGuildItemEndpoint endpoint.Endpoint
+	DepositGuildGoldEndpoint  endpoint.Endpoint
+	WithdrawGuildGoldEndpoint endpoint.Endpoint
+	CreatePartyEndpoint       endpoint.Endpoint
+	ViewPartyEndpoint         endpoint.Endpoint
+	InviteToPartyEndpoint     endpoint.Endpoint
+	AcceptPartyInviteEndpoint endpoint.Endpoint
+	LeavePartyEndpoint        endpoint.Endpoint
+	SetPartyLeaderEndpoint    endpoint.Endpoint
+	OpenPartyPortalEndpoint   endpoint.Endpoint
+	JoinMatchmakingEndpoint   endpoint.Endpoint
+	LeaveMatchmakingEndpoint  endpoint.Endpoint
+	MatchmakingStatusEndpoint endpoint.Endpoint
+	SendChatMessageEndpoint   endpoint.Endpoint
+	ChatHistoryEndpoint       endpoint.Endpoint
+	ChatStreamEndpoint        endpoint.Endpoint
+	ChatSettingsEndpoint      endpoint.Endpoint
+	MuteChatUserEndpoint      endpoint.Endpoint
+	BlockChatUserEndpoint     endpoint.Endpoint
 }
 
 // MakeServerEndpoints creates an endpoints list for a server
 func MakeServerEndpoints(s svc.Service) Endpoints {
 	return Endpoints{
-		AuthenticateEndpoint:      MakeAuthenticateEndpoint(s),
-		ViewUserInventoryEndpoint: authenticatedEndpoint(s, MakeViewUserInventoryEndpoint),
-		MergeStonesEndpoint:       authenticatedEndpoint(s, MakeMergeStonesEndpoint),
+		AuthenticateEndpoint:       MakeAuthenticateEndpoint(s),
+		ViewUserInventoryEndpoint:  authenticatedEndpoint(s, MakeViewUserInventoryEndpoint),
+		SearchInventoryEndpoint:    authenticatedEndpoint(s, MakeSearchInventoryEndpoint),
+		MergeStonesEndpoint:        authenticatedEndpoint(s, MakeMergeStonesEndpoint),
+		PreviewMergeStonesEndpoint: authenticatedEndpoint(s, MakePreviewMergeStonesEndpoint),
+		MergeAllStonesEndpoint:     authenticatedEndpoint(s, MakeMergeAllStonesEndpoint),
+		SplitStackEndpoint:         authenticatedEndpoint(s, MakeSplitStackEndpoint),
+		MergeStacksEndpoint:        authenticatedEndpoint(s, MakeMergeStacksEndpoint),
+		MoveItemEndpoint:           authenticatedEndpoint(s, MakeMoveItemEndpoint),
+		BuyBagEndpoint:             authenticatedEndpoint(s, MakeBuyBagEndpoint),
+		AttachBagEndpoint:          authenticatedEndpoint(s, MakeAttachBagEndpoint),
+		UpgradeBagEndpoint:         authenticatedEndpoint(s, MakeUpgradeBagEndpoint),
+		SortBagEndpoint:            authenticatedEndpoint(s, MakeSortBagEndpoint),
+		ViewShopEndpoint:           authenticatedEndpoint(s, MakeViewShopEndpoint),
+		BuyShopItemEndpoint:        authenticatedEndpoint(s, MakeBuyShopItemEndpoint),
+		SellItemEndpoint:           authenticatedEndpoint(s, MakeSellItemEndpoint),
+		ListRecipesEndpoint:        authenticatedEndpoint(s, MakeListRecipesEndpoint),
+		CraftEndpoint:              authenticatedEndpoint(s, MakeCraftEndpoint),
 
 		SpawnCharacterEndpoint:         authenticatedEndpoint(s, MakeSpawnCharacterEndpoint),
 		ViewCharacterEndpoint:          authenticatedEndpoint(s, MakeViewCharacterEndpoint),
 		ListCharactersEndpoint:         authenticatedEndpoint(s, MakeListCharactersEndpoint),
+		ListClassesEndpoint:            authenticatedEndpoint(s, MakeListClassesEndpoint),
 		ViewCharacterInventoryEndpoint: authenticatedEndpoint(s, MakeViewCharacterInventoryEndpoint),
 		DropCharacterItemEndpoint:      authenticatedEndpoint(s, MakeDropCharacterItemEndpoint),
 		TakeCharacterItemEndpoint:      authenticatedEndpoint(s, MakeTakeCharacterItemEndpoint),
-
-		OpenPortalEndpoint:    authenticatedEndpoint(s, MakeOpenPortalEndpoint),
-		ExplorePortalEndpoint: authenticatedEndpoint(s, MakeExplorePortalEndpoint),
-		ViewPortalEndpoint:    authenticatedEndpoint(s, MakeViewPortalEndpoint),
-		ListPortalsEndpoint:   authenticatedEndpoint(s, MakeListPortalsEndpoint),
+		EquipCharacterItemEndpoint:     authenticatedEndpoint(s, MakeEquipCharacterItemEndpoint),
+		UseItemEndpoint:                authenticatedEndpoint(s, MakeUseItemEndpoint),
+		AllocateAttributesEndpoint:     authenticatedEndpoint(s, MakeAllocateAttributesEndpoint),
+		ListTalentsEndpoint:            authenticatedEndpoint(s, MakeListTalentsEndpoint),
+		LearnTalentEndpoint:            authenticatedEndpoint(s, MakeLearnTalentEndpoint),
+		RespecCharacterEndpoint:        authenticatedEndpoint(s, MakeRespecCharacterEndpoint),
+
+		OpenPortalEndpoint:        authenticatedEndpoint(s, MakeOpenPortalEndpoint),
+		ExplorePortalEndpoint:     authenticatedEndpoint(s, MakeExplorePortalEndpoint),
+		OpenTradeEndpoint:         authenticatedEndpoint(s, MakeOpenTradeEndpoint),
+		ListTradesEndpoint:        authenticatedEndpoint(s, MakeListTradesEndpoint),
+		ViewTradeEndpoint:         authenticatedEndpoint(s, MakeViewTradeEndpoint),
+		SetTradeOfferEndpoint:     authenticatedEndpoint(s, MakeSetTradeOfferEndpoint),
+		ConfirmTradeEndpoint:      authenticatedEndpoint(s, MakeConfirmTradeEndpoint),
+		CancelTradeEndpoint:       authenticatedEndpoint(s, MakeCancelTradeEndpoint),
+		CreateAuctionEndpoint:     authenticatedEndpoint(s, MakeCreateAuctionEndpoint),
+		SearchAuctionsEndpoint:    authenticatedEndpoint(s, MakeSearchAuctionsEndpoint),
+		ClaimAuctionItemsEndpoint: authenticatedEndpoint(s, MakeClaimAuctionItemsEndpoint),
+		ViewAuctionEndpoint:       authenticatedEndpoint(s, MakeViewAuctionEndpoint),
+		BidAuctionEndpoint:        authenticatedEndpoint(s, MakeBidAuctionEndpoint),
+		BuyoutAuctionEndpoint:     authenticatedEndpoint(s, MakeBuyoutAuctionEndpoint),
+		ViewPortalEndpoint:        authenticatedEndpoint(s, MakeViewPortalEndpoint),
+		ListPortalsEndpoint:       authenticatedEndpoint(s, MakeListPortalsEndpoint),
+		PortalHistoryEndpoint:     authenticatedEndpoint(s, MakePortalHistoryEndpoint),
+		ViewPortalRunEndpoint:     authenticatedEndpoint(s, MakeViewPortalRunEndpoint),
+		PortalReplayEndpoint:      authenticatedEndpoint(s, MakePortalReplayEndpoint),
+		LeaderboardEndpoint:       authenticatedEndpoint(s, MakeLeaderboardEndpoint),
+		ListAchievementsEndpoint:  authenticatedEndpoint(s, MakeListAchievementsEndpoint),
+		ClaimAchievementEndpoint:  authenticatedEndpoint(s, MakeClaimAchievementEndpoint),
+		ListQuestsEndpoint:        authenticatedEndpoint(s, MakeListQuestsEndpoint),
+		AcceptQuestEndpoint:       authenticatedEndpoint(s, MakeAcceptQuestEndpoint),
+		ClaimQuestEndpoint:        authenticatedEndpoint(s, MakeClaimQuestEndpoint),
+		CreateGuildEndpoint:       authenticatedEndpoint(s, MakeCreateGuildEndpoint),
+		ViewGuildEndpoint:         authenticatedEndpoint(s, MakeViewGuildEndpoint),
+		InviteToGuildEndpoint:     authenticatedEndpoint(s, MakeInviteToGuildEndpoint),
+		JoinGuildEndpoint:         authenticatedEndpoint(s, MakeJoinGuildEndpoint),
+		LeaveGuildEndpoint:        authenticatedEndpoint(s, MakeLeaveGuildEndpoint),
+		KickGuildMemberEndpoint:   authenticatedEndpoint(s, MakeKickGuildMemberEndpoint),
+		SetGuildRoleEndpoint:      authenticatedEndpoint(s, MakeSetGuildRoleEndpoint),
+		DepositGuildItemEndpoint:  authenticatedEndpoint(s, MakeDepositGuildItemEndpoint),
+		WithdrawGuildItemEndpoint: authenticatedEndpoint(s, MakeWithdrawGuildItemEndpoint),
+		DepositGuildGoldEndpoint:  authenticatedEndpoint(s, MakeDepositGuildGoldEndpoint),
+		WithdrawGuildGoldEndpoint: authenticatedEndpoint(s, MakeWithdrawGuildGoldEndpoint),
+		CreatePartyEndpoint:       authenticatedEndpoint(s, MakeCreatePartyEndpoint),
+		ViewPartyEndpoint:         authenticatedEndpoint(s, MakeViewPartyEndpoint),
+		InviteToPartyEndpoint:     authenticatedEndpoint(s, MakeInviteToPartyEndpoint),
+		AcceptPartyInviteEndpoint: authenticatedEndpoint(s, MakeAcceptPartyInviteEndpoint),
+		LeavePartyEndpoint:        authenticatedEndpoint(s, MakeLeavePartyEndpoint),
+		SetPartyLeaderEndpoint:    authenticatedEndpoint(s, MakeSetPartyLeaderEndpoint),
+		OpenPartyPortalEndpoint:   authenticatedEndpoint(s, MakeOpenPartyPortalEndpoint),
+		JoinMatchmakingEndpoint:   authenticatedEndpoint(s, MakeJoinMatchmakingEndpoint),
+		LeaveMatchmakingEndpoint:  authenticatedEndpoint(s, MakeLeaveMatchmakingEndpoint),
+		MatchmakingStatusEndpoint: authenticatedEndpoint(s, MakeMatchmakingStatusEndpoint),
+		SendChatMessageEndpoint:   authenticatedEndpoint(s, MakeSendChatMessageEndpoint),
+		ChatHistoryEndpoint:       authenticatedEndpoint(s, MakeChatHistoryEndpoint),
+		ChatStreamEndpoint:        authenticatedEndpoint(s, MakeChatStreamEndpoint),
+		ChatSettingsEndpoint:      authenticatedEndpoint(s, MakeChatSettingsEndpoint),
+		MuteChatUserEndpoint:      authenticatedEndpoint(s, MakeMuteChatUserEndpoint),
+		BlockChatUserEndpoint:     authenticatedEndpoint(s, MakeBlockChatUserEndpoint),
 	}
 }
 
@@ -107,19 +248,42 @@ func MakeSpawnCharacterEndpoint(s svc.Service) endpoint.Endpoint {
 		if !ok {
 			return SpawnCharacterResponse{}, ErrNoAccount
 		}
+		spawnReq, ok := request.(SpawnCharacterRequest)
+		if !ok {
+			return SpawnCharacterResponse{}, WrongRequestError{Endpoint: "SpawnCharacter"}
+		}
 
-		character, err := s.SpawnCharacter(user)
+		character, err := s.SpawnCharacter(user, spawnReq.Class)
 		if err != nil {
 			return SpawnCharacterResponse{}, err
 		}
 
 		return SpawnCharacterResponse{
-			Character: &CharacterDetails{
-				ID:        character.ID,
-				Health:    character.Health,
-				MaxHealth: character.MaxHealth,
-				Exploring: character.Exploring,
-			},
+			Character: characterDetails(character),
+		}, nil
+	}
+}
+
+// MakeListClassesEndpoint creates the endpoint for listing the character classes
+func MakeListClassesEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		_, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return ListClassesResponse{}, ErrNoAccount
+		}
+
+		classes, err := s.ListClasses()
+		if err != nil {
+			return ListClassesResponse{}, err
+		}
+
+		details := make([]*ClassDetails, 0, len(classes))
+		for _, class := range classes {
+			details = append(details, classDetails(class))
+		}
+
+		return ListClassesResponse{
+			Classes: details,
 		}, nil
 	}
 }
@@ -143,12 +307,7 @@ func MakeViewCharacterEndpoint(s svc.Service) endpoint.Endpoint {
 		}
 
 		return ViewCharacterResponse{
-			Character: &CharacterDetails{
-				ID:        character.ID,
-				Health:    character.Health,
-				MaxHealth: character.MaxHealth,
-				Exploring: character.Exploring,
-			},
+			Character: characterDetails(character),
 		}, nil
 	}
 }
@@ -168,12 +327,7 @@ func MakeListCharactersEndpoint(s svc.Service) endpoint.Endpoint {
 
 		charactersList := make([]*CharacterDetails, 0, len(characters))
 		for _, character := range characters {
-			charactersList = append(charactersList, &CharacterDetails{
-				ID:        character.ID,
-				Health:    character.Health,
-				MaxHealth: character.MaxHealth,
-				Exploring: character.Exploring,
-			})
+			charactersList = append(charactersList, characterDetails(character))
 		}
 
 		return ListCharactersResponse{
@@ -236,6 +390,147 @@ func MakeMergeStonesEndpoint(s svc.Service) endpoint.Endpoint {
 	}
 }
 
+// MakePreviewMergeStonesEndpoint creates the endpoint for previewing a merge
+func MakePreviewMergeStonesEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return PreviewMergeStonesResponse{}, ErrNoAccount
+		}
+
+		previewReq, ok := request.(PreviewMergeStonesRequest)
+		if !ok {
+			return PreviewMergeStonesResponse{}, WrongRequestError{Endpoint: "PreviewMergeStones"}
+		}
+
+		source := sworld.ItemLocation{
+			BagID: previewReq.SourceLocation.BagID,
+			Slot:  previewReq.SourceLocation.Slot,
+		}
+		target := sworld.ItemLocation{
+			BagID: previewReq.TargetLocation.BagID,
+			Slot:  previewReq.TargetLocation.Slot,
+		}
+
+		stone, err := s.PreviewMergeStones(user, source, target)
+		switch err {
+		case nil:
+			return PreviewMergeStonesResponse{Stone: stoneDetails(&stone)}, nil
+		case sworld.ErrIncompatibleZones, sworld.ErrIncompatibleStones, sworld.ErrLowLevelStones:
+			// The stones exist but can't be merged, that's a valid preview
+			return PreviewMergeStonesResponse{Error: err.Error()}, nil
+		}
+		return PreviewMergeStonesResponse{}, err
+	}
+}
+
+// MakeMergeAllStonesEndpoint creates the endpoint for merging every compatible stone
+func MakeMergeAllStonesEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return MergeAllStonesResponse{}, ErrNoAccount
+		}
+
+		mergeReq, ok := request.(MergeAllStonesRequest)
+		if !ok {
+			return MergeAllStonesResponse{}, WrongRequestError{Endpoint: "MergeAllStones"}
+		}
+
+		merges, err := s.MergeAllStones(user, mergeReq.DryRun)
+
+		details := make([]*StoneMergeDetails, 0, len(merges))
+		for _, merge := range merges {
+			stone := merge.Stone
+			details = append(details, &StoneMergeDetails{
+				Source: ItemLocation{BagID: merge.Source.BagID, Slot: merge.Source.Slot},
+				Target: ItemLocation{BagID: merge.Target.BagID, Slot: merge.Target.Slot},
+				Result: ItemLocation{BagID: merge.Result.BagID, Slot: merge.Result.Slot},
+				Stone:  stoneDetails(&stone),
+			})
+		}
+
+		return MergeAllStonesResponse{
+			Merges: details,
+			DryRun: mergeReq.DryRun,
+		}, err
+	}
+}
+
+// MakeSplitStackEndpoint creates the endpoint for splitting stacks
+func MakeSplitStackEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return SplitStackResponse{}, ErrNoAccount
+		}
+
+		splitReq, ok := request.(SplitStackRequest)
+		if !ok {
+			return SplitStackResponse{}, WrongRequestError{Endpoint: "SplitStack"}
+		}
+
+		location, err := s.SplitStack(user, sworld.ItemLocation{
+			BagID: splitReq.Location.BagID,
+			Slot:  splitReq.Location.Slot,
+		}, splitReq.Quantity)
+
+		return SplitStackResponse{
+			Location: ItemLocation{
+				BagID: location.BagID,
+				Slot:  location.Slot,
+			},
+		}, err
+	}
+}
+
+// MakeMergeStacksEndpoint creates the endpoint for merging stacks
+func MakeMergeStacksEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return MergeStacksResponse{}, ErrNoAccount
+		}
+
+		mergeReq, ok := request.(MergeStacksRequest)
+		if !ok {
+			return MergeStacksResponse{}, WrongRequestError{Endpoint: "MergeStacks"}
+		}
+
+		source := sworld.ItemLocation{
+			BagID: mergeReq.SourceLocation.BagID,
+			Slot:  mergeReq.SourceLocation.Slot,
+		}
+		target := sworld.ItemLocation{
+			BagID: mergeReq.TargetLocation.BagID,
+			Slot:  mergeReq.TargetLocation.Slot,
+		}
+
+		err := s.MergeStacks(user, source, target)
+
+		return MergeStacksResponse{}, err
+	}
+}
+
+// MakeMoveItemEndpoint creates the endpoint for moving items
+func MakeMoveItemEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return MoveItemResponse{}, ErrNoAccount
+		}
+
+		moveReq, ok := request.(MoveItemRequest)
+		if !ok {
+			return MoveItemResponse{}, WrongRequestError{Endpoint: "MoveItem"}
+		}
+
+		err := s.MoveItem(user, moveReq.From.itemLocation(), moveReq.To.itemLocation())
+
+		return MoveItemResponse{}, err
+	}
+}
+
 // MakeViewCharacterInventoryEndpoint creates the ViewCharacterInventory endpoint
 func MakeViewCharacterInventoryEndpoint(s svc.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
@@ -290,6 +585,94 @@ func MakeListPortalsEndpoint(s svc.Service) endpoint.Endpoint {
 	}
 }
 
+// MakePortalHistoryEndpoint creates the PortalHistory endpoint
+func MakePortalHistoryEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return PortalHistoryResponse{}, ErrNoAccount
+		}
+		historyReq, ok := request.(PortalHistoryRequest)
+		if !ok {
+			return PortalHistoryResponse{}, WrongRequestError{Endpoint: "PortalHistory"}
+		}
+
+		runs, total, err := s.PortalHistory(user, historyReq.Offset, historyReq.Limit)
+		if err != nil {
+			return PortalHistoryResponse{}, err
+		}
+
+		runsList := make([]*PortalRunDetails, 0, len(runs))
+		for _, run := range runs {
+			runsList = append(runsList, portalRunDetails(run, true))
+		}
+
+		return PortalHistoryResponse{
+			Runs:  runsList,
+			Total: total,
+		}, nil
+	}
+}
+
+// MakeViewPortalRunEndpoint creates the ViewPortalRun endpoint
+func MakeViewPortalRunEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return ViewPortalRunResponse{}, ErrNoAccount
+		}
+		runReq, ok := request.(ViewPortalRunRequest)
+		if !ok {
+			return ViewPortalRunResponse{}, WrongRequestError{Endpoint: "ViewPortalRun"}
+		}
+
+		run, err := s.ViewPortalRun(user, runReq.ID)
+		if err != nil {
+			return ViewPortalRunResponse{}, err
+		}
+
+		return ViewPortalRunResponse{
+			Run: portalRunDetails(run, false),
+		}, nil
+	}
+}
+
+// MakePortalReplayEndpoint creates the PortalReplay endpoint
+func MakePortalReplayEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return PortalReplayResponse{}, ErrNoAccount
+		}
+		replayReq, ok := request.(PortalReplayRequest)
+		if !ok {
+			return PortalReplayResponse{}, WrongRequestError{Endpoint: "PortalReplay"}
+		}
+
+		events, err := s.PortalReplay(user, replayReq.PortalID)
+		if err != nil {
+			return PortalReplayResponse{}, err
+		}
+
+		eventsList := make([]*ReplayEventDetails, 0, len(events))
+		for i := range events {
+			eventsList = append(eventsList, replayEventDetails(i+1, &events[i]))
+		}
+		response := PortalReplayResponse{
+			PortalID: replayReq.PortalID,
+			Events:   eventsList,
+		}
+
+		if replayReq.Step > 0 {
+			replayer := sworld.NewReplayer(events)
+			replayer.Seek(replayReq.Step)
+			response.Frame = replayFrameDetails(replayer.Frame())
+		}
+
+		return response, nil
+	}
+}
+
 // MakeViewPortalEndpoint creates the ViewPortal endpoint
 func MakeViewPortalEndpoint(s svc.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
@@ -332,6 +715,167 @@ func MakeTakeCharacterItemEndpoint(s svc.Service) endpoint.Endpoint {
 	}
 }
 
+// MakeEquipCharacterItemEndpoint creates the endpoint for equipping character items
+func MakeEquipCharacterItemEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return EquipCharacterItemResponse{}, ErrNoAccount
+		}
+
+		equipReq, ok := request.(EquipCharacterItemRequest)
+		if !ok {
+			return EquipCharacterItemResponse{}, WrongRequestError{Endpoint: "EquipCharacterItem"}
+		}
+
+		err := s.EquipCharacterItem(user, equipReq.CharacterID, equipReq.ItemLocation.BagID, equipReq.ItemLocation.Slot)
+		if err != nil {
+			return EquipCharacterItemResponse{}, err
+		}
+
+		character, err := user.FindCharacter(equipReq.CharacterID)
+		if err != nil {
+			return EquipCharacterItemResponse{}, err
+		}
+
+		return EquipCharacterItemResponse{
+			Character: characterDetails(character),
+		}, nil
+	}
+}
+
+// MakeUseItemEndpoint creates the endpoint for using character items
+func MakeUseItemEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return UseItemResponse{}, ErrNoAccount
+		}
+
+		useReq, ok := request.(UseItemRequest)
+		if !ok {
+			return UseItemResponse{}, WrongRequestError{Endpoint: "UseItem"}
+		}
+
+		err := s.UseItem(user, useReq.CharacterID, useReq.ItemLocation.BagID, useReq.ItemLocation.Slot)
+		if err != nil {
+			return UseItemResponse{}, err
+		}
+
+		character, err := user.FindCharacter(useReq.CharacterID)
+		if err != nil {
+			return UseItemResponse{}, err
+		}
+
+		return UseItemResponse{
+			Character: characterDetails(character),
+		}, nil
+	}
+}
+
+// MakeAllocateAttributesEndpoint creates the endpoint for spending attribute points
+func MakeAllocateAttributesEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return AllocateAttributesResponse{}, ErrNoAccount
+		}
+		allocateReq, ok := request.(AllocateAttributesRequest)
+		if !ok {
+			return AllocateAttributesResponse{}, WrongRequestError{Endpoint: "AllocateAttributes"}
+		}
+
+		character, err := s.AllocateAttributes(user, allocateReq.CharacterID, sworld.Attributes{
+			Strength: allocateReq.Strength,
+			Agility:  allocateReq.Agility,
+			Vitality: allocateReq.Vitality,
+		})
+		if err != nil {
+			return AllocateAttributesResponse{}, err
+		}
+
+		return AllocateAttributesResponse{
+			Character: characterDetails(character),
+		}, nil
+	}
+}
+
+// MakeListTalentsEndpoint creates the endpoint for listing the talents of a character
+func MakeListTalentsEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return ListTalentsResponse{}, ErrNoAccount
+		}
+		listReq, ok := request.(ListTalentsRequest)
+		if !ok {
+			return ListTalentsResponse{}, WrongRequestError{Endpoint: "ListTalents"}
+		}
+
+		talents, points, err := s.ListTalents(user, listReq.CharacterID)
+		if err != nil {
+			return ListTalentsResponse{}, err
+		}
+
+		details := make([]*TalentDetails, 0, len(talents))
+		for _, talent := range talents {
+			details = append(details, talentDetails(talent))
+		}
+
+		return ListTalentsResponse{
+			TalentPoints: points,
+			Talents:      details,
+		}, nil
+	}
+}
+
+// MakeLearnTalentEndpoint creates the endpoint for spending a talent point
+func MakeLearnTalentEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return LearnTalentResponse{}, ErrNoAccount
+		}
+		learnReq, ok := request.(LearnTalentRequest)
+		if !ok {
+			return LearnTalentResponse{}, WrongRequestError{Endpoint: "LearnTalent"}
+		}
+
+		character, err := s.LearnTalent(user, learnReq.CharacterID, learnReq.TalentID)
+		if err != nil {
+			return LearnTalentResponse{}, err
+		}
+
+		return LearnTalentResponse{
+			Character: characterDetails(character),
+		}, nil
+	}
+}
+
+// MakeRespecCharacterEndpoint creates the endpoint for refunding the points of a character
+func MakeRespecCharacterEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return RespecCharacterResponse{}, ErrNoAccount
+		}
+		respecReq, ok := request.(RespecCharacterRequest)
+		if !ok {
+			return RespecCharacterResponse{}, WrongRequestError{Endpoint: "RespecCharacter"}
+		}
+
+		character, gold, err := s.RespecCharacter(user, respecReq.CharacterID)
+		if err != nil {
+			return RespecCharacterResponse{}, err
+		}
+
+		return RespecCharacterResponse{
+			Character: characterDetails(character),
+			Gold:      gold,
+		}, nil
+	}
+}
+
 // MakeDropCharacterItemEndpoint creates the endpoint for dropping character items
 func MakeDropCharacterItemEndpoint(s svc.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
@@ -387,10 +931,15 @@ func MakeOpenPortalEndpoint(s svc.Service) endpoint.Endpoint {
 
 		location := portalReq.StoneLocation
 
-		if location != nil {
-			portal, err = s.OpenPortalWithStone(user, location.BagID, location.Slot)
+		if portalReq.Guild {
+			if location == nil {
+				return OpenPortalResponse{Error: svc.ErrGuildPortalStone.Error()}, svc.ErrGuildPortalStone
+			}
+			portal, err = s.OpenGuildPortal(user, location.BagID, location.Slot, portalReq.Seed)
+		} else if location != nil {
+			portal, err = s.OpenPortalWithStone(user, location.BagID, location.Slot, portalReq.Seed)
 		} else {
-			portal, err = s.OpenDefaultPortal(user)
+			portal, err = s.OpenDefaultPortal(user, portalReq.Seed)
 		}
 		if err != nil {
 			return OpenPortalResponse{Error: err.Error()}, err
@@ -401,3 +950,1433 @@ func MakeOpenPortalEndpoint(s svc.Service) endpoint.Endpoint {
 		}, nil
 	}
 }
+
+// MakeBuyBagEndpoint creates the endpoint for buying bags
+func MakeBuyBagEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return BuyBagResponse{}, ErrNoAccount
+		}
+
+		buyReq, ok := request.(BuyBagRequest)
+		if !ok {
+			return BuyBagResponse{}, WrongRequestError{Endpoint: "BuyBag"}
+		}
+
+		location, err := s.BuyBag(user, buyReq.Kind)
+
+		return BuyBagResponse{
+			Location: ItemLocation{
+				BagID: location.BagID,
+				Slot:  location.Slot,
+			},
+		}, err
+	}
+}
+
+// MakeAttachBagEndpoint creates the endpoint for attaching bags
+func MakeAttachBagEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return AttachBagResponse{}, ErrNoAccount
+		}
+
+		attachReq, ok := request.(AttachBagRequest)
+		if !ok {
+			return AttachBagResponse{}, WrongRequestError{Endpoint: "AttachBag"}
+		}
+
+		err := s.AttachBag(user, attachReq.Location.itemLocation(), attachReq.CharacterID)
+		if err != nil {
+			return AttachBagResponse{}, err
+		}
+
+		bags := user.Bags
+		if attachReq.CharacterID != "" {
+			character, err := user.FindCharacter(attachReq.CharacterID)
+			if err != nil {
+				return AttachBagResponse{}, err
+			}
+			bags = character.Bags
+		}
+
+		return AttachBagResponse{
+			Bags: inventoryDetails(bags),
+		}, nil
+	}
+}
+
+// MakeUpgradeBagEndpoint creates the endpoint for expanding bags
+func MakeUpgradeBagEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return UpgradeBagResponse{}, ErrNoAccount
+		}
+
+		upgradeReq, ok := request.(UpgradeBagRequest)
+		if !ok {
+			return UpgradeBagResponse{}, WrongRequestError{Endpoint: "UpgradeBag"}
+		}
+
+		err := s.UpgradeBag(user, upgradeReq.CharacterID, upgradeReq.BagID)
+		if err != nil {
+			return UpgradeBagResponse{}, err
+		}
+
+		bag, err := user.FindBag(upgradeReq.CharacterID, upgradeReq.BagID)
+		if err != nil {
+			return UpgradeBagResponse{}, err
+		}
+
+		return UpgradeBagResponse{
+			Bag: bagDetails(upgradeReq.BagID, bag),
+		}, nil
+	}
+}
+
+// MakeSearchInventoryEndpoint creates the endpoint for searching the user inventory
+func MakeSearchInventoryEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return SearchInventoryResponse{}, ErrNoAccount
+		}
+
+		searchReq, ok := request.(SearchInventoryRequest)
+		if !ok {
+			return SearchInventoryResponse{}, WrongRequestError{Endpoint: "SearchInventory"}
+		}
+
+		items, total, err := s.SearchInventory(user, sworld.InventoryQuery{
+			Filter: sworld.ItemFilter{
+				Kinds:     searchReq.Kinds,
+				MinLevel:  searchReq.MinLevel,
+				MaxLevel:  searchReq.MaxLevel,
+				ZoneID:    searchReq.ZoneID,
+				MinDamage: searchReq.MinDamage,
+				MaxDamage: searchReq.MaxDamage,
+			},
+			SortBy:     searchReq.SortBy,
+			Descending: searchReq.Descending,
+			Offset:     searchReq.Offset,
+			Limit:      searchReq.Limit,
+		})
+		if err != nil {
+			return SearchInventoryResponse{}, err
+		}
+
+		itemsList := make([]*InventoryItemDetails, 0, len(items))
+		for _, item := range items {
+			itemsList = append(itemsList, &InventoryItemDetails{
+				Location: ItemLocation{
+					BagID: item.Location.BagID,
+					Slot:  item.Location.Slot,
+				},
+				Item: bagSlotDetails(item.Location.Slot, item.Item),
+			})
+		}
+
+		return SearchInventoryResponse{
+			Items: itemsList,
+			Total: total,
+		}, nil
+	}
+}
+
+// MakeSortBagEndpoint creates the endpoint for sorting bags
+func MakeSortBagEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return SortBagResponse{}, ErrNoAccount
+		}
+
+		sortReq, ok := request.(SortBagRequest)
+		if !ok {
+			return SortBagResponse{}, WrongRequestError{Endpoint: "SortBag"}
+		}
+
+		err := s.SortBag(user, sortReq.CharacterID, sortReq.BagID)
+		if err != nil {
+			return SortBagResponse{}, err
+		}
+
+		bag, err := user.FindBag(sortReq.CharacterID, sortReq.BagID)
+		if err != nil {
+			return SortBagResponse{}, err
+		}
+
+		return SortBagResponse{
+			Bag: bagDetails(sortReq.BagID, bag),
+		}, nil
+	}
+}
+
+// MakeViewShopEndpoint creates the endpoint for viewing the vendor of a zone
+func MakeViewShopEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return ViewShopResponse{}, ErrNoAccount
+		}
+
+		shopReq, ok := request.(ViewShopRequest)
+		if !ok {
+			return ViewShopResponse{}, WrongRequestError{Endpoint: "ViewShop"}
+		}
+
+		shop, err := s.ViewShop(user, shopReq.ZoneID)
+		if err != nil {
+			return ViewShopResponse{}, err
+		}
+
+		items := make([]*ShopItemDetails, 0, len(shop.Items))
+		for _, item := range shop.Items {
+			items = append(items, &ShopItemDetails{
+				ID:    item.ID,
+				Price: item.Price,
+				Stock: item.Stock,
+				Item:  bagSlotDetails(0, item.Item),
+			})
+		}
+		offers := make([]*SellOfferDetails, 0, len(shop.SellOffers))
+		for _, offer := range shop.SellOffers {
+			offers = append(offers, &SellOfferDetails{
+				Location: ItemLocation{
+					BagID: offer.Location.BagID,
+					Slot:  offer.Location.Slot,
+				},
+				Price: offer.Price,
+				Item:  bagSlotDetails(offer.Location.Slot, offer.Item),
+			})
+		}
+
+		return ViewShopResponse{
+			Zone: ZoneDetails{
+				ID:   shop.Zone.ID,
+				Name: shop.Zone.Name,
+			},
+			Items:      items,
+			RotatesAt:  shop.RotatesAt.Format(time.RFC3339),
+			SellOffers: offers,
+			Gold:       user.Gold,
+		}, nil
+	}
+}
+
+// MakeBuyShopItemEndpoint creates the endpoint for buying items from a vendor
+func MakeBuyShopItemEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return BuyShopItemResponse{}, ErrNoAccount
+		}
+
+		buyReq, ok := request.(BuyShopItemRequest)
+		if !ok {
+			return BuyShopItemResponse{}, WrongRequestError{Endpoint: "BuyShopItem"}
+		}
+
+		location, err := s.BuyShopItem(user, buyReq.ZoneID, buyReq.ItemID)
+		if err != nil {
+			return BuyShopItemResponse{}, err
+		}
+
+		return BuyShopItemResponse{
+			Location: ItemLocation{
+				BagID: location.BagID,
+				Slot:  location.Slot,
+			},
+			Gold: user.Gold,
+		}, nil
+	}
+}
+
+// MakeSellItemEndpoint creates the endpoint for selling items to a vendor
+func MakeSellItemEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return SellItemResponse{}, ErrNoAccount
+		}
+
+		sellReq, ok := request.(SellItemRequest)
+		if !ok {
+			return SellItemResponse{}, WrongRequestError{Endpoint: "SellItem"}
+		}
+
+		price, err := s.SellItem(user, sellReq.Location.itemLocation())
+		if err != nil {
+			return SellItemResponse{}, err
+		}
+
+		return SellItemResponse{
+			Price: price,
+			Gold:  user.Gold,
+		}, nil
+	}
+}
+
+// MakeOpenTradeEndpoint creates the endpoint for trading with another user
+func MakeOpenTradeEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return TradeResponse{}, ErrNoAccount
+		}
+
+		openReq, ok := request.(OpenTradeRequest)
+		if !ok {
+			return TradeResponse{}, WrongRequestError{Endpoint: "OpenTrade"}
+		}
+
+		trade, err := s.OpenTrade(user, openReq.Username)
+		if err != nil {
+			return TradeResponse{}, err
+		}
+
+		return TradeResponse{
+			Trade: tradeDetails(trade),
+		}, nil
+	}
+}
+
+// MakeListTradesEndpoint creates the endpoint for listing the trades of the user
+func MakeListTradesEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return ListTradesResponse{}, ErrNoAccount
+		}
+
+		trades, err := s.ListTrades(user)
+		if err != nil {
+			return ListTradesResponse{}, err
+		}
+
+		details := make([]*TradeDetails, 0, len(trades))
+		for _, trade := range trades {
+			details = append(details, tradeDetails(trade))
+		}
+
+		return ListTradesResponse{
+			Trades: details,
+		}, nil
+	}
+}
+
+// MakeViewTradeEndpoint creates the endpoint for viewing a trade
+func MakeViewTradeEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return TradeResponse{}, ErrNoAccount
+		}
+
+		viewReq, ok := request.(ViewTradeRequest)
+		if !ok {
+			return TradeResponse{}, WrongRequestError{Endpoint: "ViewTrade"}
+		}
+
+		trade, err := s.ViewTrade(user, viewReq.ID)
+		if err != nil {
+			return TradeResponse{}, err
+		}
+
+		return TradeResponse{
+			Trade: tradeDetails(trade),
+		}, nil
+	}
+}
+
+// MakeSetTradeOfferEndpoint creates the endpoint for changing the offer of the user on a trade
+func MakeSetTradeOfferEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return TradeResponse{}, ErrNoAccount
+		}
+
+		offerReq, ok := request.(SetTradeOfferRequest)
+		if !ok {
+			return TradeResponse{}, WrongRequestError{Endpoint: "SetTradeOffer"}
+		}
+
+		locations := make([]sworld.ItemLocation, 0, len(offerReq.Items))
+		for _, location := range offerReq.Items {
+			locations = append(locations, sworld.ItemLocation{
+				BagID: location.BagID,
+				Slot:  location.Slot,
+			})
+		}
+
+		trade, err := s.SetTradeOffer(user, offerReq.ID, locations, offerReq.Gold)
+		if err != nil {
+			return TradeResponse{}, err
+		}
+
+		return TradeResponse{
+			Trade: tradeDetails(trade),
+		}, nil
+	}
+}
+
+// MakeConfirmTradeEndpoint creates the endpoint for confirming a trade
+func MakeConfirmTradeEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return TradeResponse{}, ErrNoAccount
+		}
+
+		confirmReq, ok := request.(ConfirmTradeRequest)
+		if !ok {
+			return TradeResponse{}, WrongRequestError{Endpoint: "ConfirmTrade"}
+		}
+
+		trade, err := s.ConfirmTrade(user, confirmReq.ID)
+		if err != nil {
+			return TradeResponse{}, err
+		}
+
+		return TradeResponse{
+			Trade: tradeDetails(trade),
+		}, nil
+	}
+}
+
+// MakeCancelTradeEndpoint creates the endpoint for cancelling a trade
+func MakeCancelTradeEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return TradeResponse{}, ErrNoAccount
+		}
+
+		cancelReq, ok := request.(CancelTradeRequest)
+		if !ok {
+			return TradeResponse{}, WrongRequestError{Endpoint: "CancelTrade"}
+		}
+
+		trade, err := s.CancelTrade(user, cancelReq.ID)
+		if err != nil {
+			return TradeResponse{}, err
+		}
+
+		return TradeResponse{
+			Trade: tradeDetails(trade),
+		}, nil
+	}
+}
+
+// MakeCreateAuctionEndpoint creates the endpoint for listing items on the auction house
+func MakeCreateAuctionEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return AuctionResponse{}, ErrNoAccount
+		}
+
+		createReq, ok := request.(CreateAuctionRequest)
+		if !ok {
+			return AuctionResponse{}, WrongRequestError{Endpoint: "CreateAuction"}
+		}
+
+		location := sworld.ItemLocation{
+			BagID: createReq.Location.BagID,
+			Slot:  createReq.Location.Slot,
+		}
+		duration := time.Duration(createReq.Duration) * time.Second
+
+		auction, err := s.CreateAuction(user, location, createReq.StartingBid, createReq.Buyout, duration)
+		if err != nil {
+			return AuctionResponse{}, err
+		}
+
+		return AuctionResponse{
+			Auction: auctionDetails(auction),
+		}, nil
+	}
+}
+
+// MakeSearchAuctionsEndpoint creates the endpoint for searching the auction house
+func MakeSearchAuctionsEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return SearchAuctionsResponse{}, ErrNoAccount
+		}
+
+		searchReq, ok := request.(SearchAuctionsRequest)
+		if !ok {
+			return SearchAuctionsResponse{}, WrongRequestError{Endpoint: "SearchAuctions"}
+		}
+
+		auctions, total, err := s.SearchAuctions(user, sworld.InventoryQuery{
+			Filter: sworld.ItemFilter{
+				Kinds:     searchReq.Kinds,
+				MinLevel:  searchReq.MinLevel,
+				MaxLevel:  searchReq.MaxLevel,
+				ZoneID:    searchReq.ZoneID,
+				MinDamage: searchReq.MinDamage,
+				MaxDamage: searchReq.MaxDamage,
+			},
+			SortBy:     searchReq.SortBy,
+			Descending: searchReq.Descending,
+			Offset:     searchReq.Offset,
+			Limit:      searchReq.Limit,
+		})
+		if err != nil {
+			return SearchAuctionsResponse{}, err
+		}
+
+		details := make([]*AuctionDetails, 0, len(auctions))
+		for _, auction := range auctions {
+			details = append(details, auctionDetails(auction))
+		}
+
+		return SearchAuctionsResponse{
+			Auctions: details,
+			Total:    total,
+		}, nil
+	}
+}
+
+// MakeViewAuctionEndpoint creates the endpoint for viewing an auction
+func MakeViewAuctionEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		_, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return AuctionResponse{}, ErrNoAccount
+		}
+
+		viewReq, ok := request.(ViewAuctionRequest)
+		if !ok {
+			return AuctionResponse{}, WrongRequestError{Endpoint: "ViewAuction"}
+		}
+
+		auction, err := s.ViewAuction(viewReq.ID)
+		if err != nil {
+			return AuctionResponse{}, err
+		}
+
+		return AuctionResponse{
+			Auction: auctionDetails(auction),
+		}, nil
+	}
+}
+
+// MakeBidAuctionEndpoint creates the endpoint for bidding on an auction
+func MakeBidAuctionEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return AuctionResponse{}, ErrNoAccount
+		}
+
+		bidReq, ok := request.(BidAuctionRequest)
+		if !ok {
+			return AuctionResponse{}, WrongRequestError{Endpoint: "BidAuction"}
+		}
+
+		auction, err := s.BidAuction(user, bidReq.ID, bidReq.Amount)
+		if err != nil {
+			return AuctionResponse{}, err
+		}
+
+		return AuctionResponse{
+			Auction: auctionDetails(auction),
+		}, nil
+	}
+}
+
+// MakeBuyoutAuctionEndpoint creates the endpoint for buying an auction right away
+func MakeBuyoutAuctionEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return AuctionResponse{}, ErrNoAccount
+		}
+
+		buyoutReq, ok := request.(BuyoutAuctionRequest)
+		if !ok {
+			return AuctionResponse{}, WrongRequestError{Endpoint: "BuyoutAuction"}
+		}
+
+		auction, err := s.BuyoutAuction(user, buyoutReq.ID)
+		if err != nil {
+			return AuctionResponse{}, err
+		}
+
+		return AuctionResponse{
+			Auction: auctionDetails(auction),
+		}, nil
+	}
+}
+
+// MakeClaimAuctionItemsEndpoint creates the endpoint for claiming the items waiting on the auction house
+func MakeClaimAuctionItemsEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return ClaimAuctionItemsResponse{}, ErrNoAccount
+		}
+
+		locations, pending, err := s.ClaimAuctionItems(user)
+		if err != nil {
+			return ClaimAuctionItemsResponse{}, err
+		}
+
+		claimed := make([]ItemLocation, 0, len(locations))
+		for _, location := range locations {
+			claimed = append(claimed, ItemLocation{
+				BagID: location.BagID,
+				Slot:  location.Slot,
+			})
+		}
+
+		return ClaimAuctionItemsResponse{
+			Locations: claimed,
+			Pending:   pending,
+		}, nil
+	}
+}
+
+// MakeListRecipesEndpoint creates the endpoint for listing the crafting recipes
+func MakeListRecipesEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		_, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return ListRecipesResponse{}, ErrNoAccount
+		}
+
+		recipes, err := s.ListRecipes()
+		if err != nil {
+			return ListRecipesResponse{}, err
+		}
+
+		details := make([]*RecipeDetails, 0, len(recipes))
+		for _, recipe := range recipes {
+			details = append(details, recipeDetails(recipe))
+		}
+
+		return ListRecipesResponse{
+			Recipes: details,
+		}, nil
+	}
+}
+
+// MakeCraftEndpoint creates the endpoint for crafting recipes
+func MakeCraftEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return CraftResponse{}, ErrNoAccount
+		}
+
+		craftReq, ok := request.(CraftRequest)
+		if !ok {
+			return CraftResponse{}, WrongRequestError{Endpoint: "Craft"}
+		}
+
+		var input *sworld.ItemLocation
+		if craftReq.Location != nil {
+			input = &sworld.ItemLocation{
+				BagID: craftReq.Location.BagID,
+				Slot:  craftReq.Location.Slot,
+			}
+		}
+
+		locations, err := s.Craft(user, craftReq.RecipeID, input)
+		if err != nil {
+			return CraftResponse{}, err
+		}
+
+		crafted := make([]ItemLocation, 0, len(locations))
+		for _, location := range locations {
+			crafted = append(crafted, ItemLocation{
+				BagID: location.BagID,
+				Slot:  location.Slot,
+			})
+		}
+
+		return CraftResponse{
+			Locations: crafted,
+		}, nil
+	}
+}
+
+// MakeLeaderboardEndpoint creates the Leaderboard endpoint
+func MakeLeaderboardEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		_, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return LeaderboardResponse{}, ErrNoAccount
+		}
+		boardReq, ok := request.(LeaderboardRequest)
+		if !ok {
+			return LeaderboardResponse{}, WrongRequestError{Endpoint: "Leaderboard"}
+		}
+
+		level := -1
+		if boardReq.Level != nil {
+			level = *boardReq.Level
+		}
+		board, err := s.Leaderboard(boardReq.Kind, boardReq.Period, boardReq.ZoneID, level, boardReq.Limit)
+		if err != nil {
+			return LeaderboardResponse{}, err
+		}
+
+		entries := make([]*LeaderboardEntryDetails, 0, len(board.Entries))
+		for _, entry := range board.Entries {
+			entries = append(entries, leaderboardEntryDetails(entry))
+		}
+
+		return LeaderboardResponse{
+			Kind:    string(board.Kind),
+			Period:  string(board.Period),
+			ZoneID:  board.ZoneID,
+			Level:   boardReq.Level,
+			Entries: entries,
+		}, nil
+	}
+}
+
+// MakeListAchievementsEndpoint creates the ListAchievements endpoint
+func MakeListAchievementsEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return ListAchievementsResponse{}, ErrNoAccount
+		}
+
+		statuses, err := s.ListAchievements(user)
+		if err != nil {
+			return ListAchievementsResponse{}, err
+		}
+
+		achievements := make([]*AchievementDetails, 0, len(statuses))
+		for _, status := range statuses {
+			achievements = append(achievements, achievementDetails(status))
+		}
+
+		return ListAchievementsResponse{
+			Achievements: achievements,
+			Titles:       user.Titles,
+		}, nil
+	}
+}
+
+// MakeClaimAchievementEndpoint creates the ClaimAchievement endpoint
+func MakeClaimAchievementEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return ClaimAchievementResponse{}, ErrNoAccount
+		}
+		claimReq, ok := request.(ClaimAchievementRequest)
+		if !ok {
+			return ClaimAchievementResponse{}, WrongRequestError{Endpoint: "ClaimAchievement"}
+		}
+
+		status, err := s.ClaimAchievement(user, claimReq.ID)
+		if err != nil {
+			return ClaimAchievementResponse{}, err
+		}
+
+		return ClaimAchievementResponse{
+			Achievement: achievementDetails(status),
+			Gold:        user.Gold,
+			Titles:      user.Titles,
+		}, nil
+	}
+}
+
+// MakeListQuestsEndpoint creates the ListQuests endpoint
+func MakeListQuestsEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return ListQuestsResponse{}, ErrNoAccount
+		}
+
+		statuses, err := s.ListQuests(user)
+		if err != nil {
+			return ListQuestsResponse{}, err
+		}
+
+		quests := make([]*QuestDetails, 0, len(statuses))
+		for _, status := range statuses {
+			quests = append(quests, questDetails(status))
+		}
+
+		return ListQuestsResponse{
+			Quests: quests,
+		}, nil
+	}
+}
+
+// MakeAcceptQuestEndpoint creates the AcceptQuest endpoint
+func MakeAcceptQuestEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return AcceptQuestResponse{}, ErrNoAccount
+		}
+		acceptReq, ok := request.(AcceptQuestRequest)
+		if !ok {
+			return AcceptQuestResponse{}, WrongRequestError{Endpoint: "AcceptQuest"}
+		}
+
+		status, err := s.AcceptQuest(user, acceptReq.ID)
+		if err != nil {
+			return AcceptQuestResponse{}, err
+		}
+
+		return AcceptQuestResponse{
+			Quest: questDetails(status),
+		}, nil
+	}
+}
+
+// MakeClaimQuestEndpoint creates the ClaimQuest endpoint
+func MakeClaimQuestEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return ClaimQuestResponse{}, ErrNoAccount
+		}
+		claimReq, ok := request.(ClaimQuestRequest)
+		if !ok {
+			return ClaimQuestResponse{}, WrongRequestError{Endpoint: "ClaimQuest"}
+		}
+
+		status, err := s.ClaimQuest(user, claimReq.ID)
+		if err != nil {
+			return ClaimQuestResponse{}, err
+		}
+
+		return ClaimQuestResponse{
+			Quest: questDetails(status),
+			Gold:  user.Gold,
+		}, nil
+	}
+}
+
+// MakeCreateGuildEndpoint creates the CreateGuild endpoint
+func MakeCreateGuildEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return CreateGuildResponse{}, ErrNoAccount
+		}
+		createReq, ok := request.(CreateGuildRequest)
+		if !ok {
+			return CreateGuildResponse{}, WrongRequestError{Endpoint: "CreateGuild"}
+		}
+
+		guild, err := s.CreateGuild(user, createReq.Name)
+		if err != nil {
+			return CreateGuildResponse{}, err
+		}
+
+		return CreateGuildResponse{
+			Guild: guildDetails(guild),
+			Gold:  user.Gold,
+		}, nil
+	}
+}
+
+// MakeViewGuildEndpoint creates the ViewGuild endpoint
+func MakeViewGuildEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return ViewGuildResponse{}, ErrNoAccount
+		}
+
+		guild, err := s.ViewGuild(user)
+		if err != nil {
+			return ViewGuildResponse{}, err
+		}
+
+		return ViewGuildResponse{
+			Guild: guildDetails(guild),
+		}, nil
+	}
+}
+
+// MakeInviteToGuildEndpoint creates the InviteToGuild endpoint
+func MakeInviteToGuildEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return InviteToGuildResponse{}, ErrNoAccount
+		}
+		inviteReq, ok := request.(InviteToGuildRequest)
+		if !ok {
+			return InviteToGuildResponse{}, WrongRequestError{Endpoint: "InviteToGuild"}
+		}
+
+		guild, err := s.InviteToGuild(user, inviteReq.Username)
+		if err != nil {
+			return InviteToGuildResponse{}, err
+		}
+
+		return InviteToGuildResponse{
+			Guild: guildDetails(guild),
+		}, nil
+	}
+}
+
+// MakeJoinGuildEndpoint creates the JoinGuild endpoint
+func MakeJoinGuildEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return JoinGuildResponse{}, ErrNoAccount
+		}
+		joinReq, ok := request.(JoinGuildRequest)
+		if !ok {
+			return JoinGuildResponse{}, WrongRequestError{Endpoint: "JoinGuild"}
+		}
+
+		guild, err := s.JoinGuild(user, joinReq.ID)
+		if err != nil {
+			return JoinGuildResponse{}, err
+		}
+
+		return JoinGuildResponse{
+			Guild: guildDetails(guild),
+		}, nil
+	}
+}
+
+// MakeLeaveGuildEndpoint creates the LeaveGuild endpoint
+func MakeLeaveGuildEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return LeaveGuildResponse{}, ErrNoAccount
+		}
+
+		if err := s.LeaveGuild(user); err != nil {
+			return LeaveGuildResponse{}, err
+		}
+
+		return LeaveGuildResponse{}, nil
+	}
+}
+
+// MakeKickGuildMemberEndpoint creates the KickGuildMember endpoint
+func MakeKickGuildMemberEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return KickGuildMemberResponse{}, ErrNoAccount
+		}
+		kickReq, ok := request.(KickGuildMemberRequest)
+		if !ok {
+			return KickGuildMemberResponse{}, WrongRequestError{Endpoint: "KickGuildMember"}
+		}
+
+		guild, err := s.KickGuildMember(user, kickReq.Username)
+		if err != nil {
+			return KickGuildMemberResponse{}, err
+		}
+
+		return KickGuildMemberResponse{
+			Guild: guildDetails(guild),
+		}, nil
+	}
+}
+
+// MakeSetGuildRoleEndpoint creates the SetGuildRole endpoint
+func MakeSetGuildRoleEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return SetGuildRoleResponse{}, ErrNoAccount
+		}
+		roleReq, ok := request.(SetGuildRoleRequest)
+		if !ok {
+			return SetGuildRoleResponse{}, WrongRequestError{Endpoint: "SetGuildRole"}
+		}
+
+		guild, err := s.SetGuildRole(user, roleReq.Username, roleReq.Role)
+		if err != nil {
+			return SetGuildRoleResponse{}, err
+		}
+
+		return SetGuildRoleResponse{
+			Guild: guildDetails(guild),
+		}, nil
+	}
+}
+
+// MakeDepositGuildItemEndpoint creates the DepositGuildItem endpoint
+func MakeDepositGuildItemEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return DepositGuildItemResponse{}, ErrNoAccount
+		}
+		depositReq, ok := request.(DepositGuildItemRequest)
+		if !ok {
+			return DepositGuildItemResponse{}, WrongRequestError{Endpoint: "DepositGuildItem"}
+		}
+
+		location, err := s.DepositGuildItem(user, sworld.ItemLocation{
+			BagID: depositReq.Location.BagID,
+			Slot:  depositReq.Location.Slot,
+		})
+		if err != nil {
+			return DepositGuildItemResponse{}, err
+		}
+
+		return DepositGuildItemResponse{
+			Location: ItemLocation{
+				BagID: location.BagID,
+				Slot:  location.Slot,
+			},
+		}, nil
+	}
+}
+
+// MakeWithdrawGuildItemEndpoint creates the WithdrawGuildItem endpoint
+func MakeWithdrawGuildItemEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return WithdrawGuildItemResponse{}, ErrNoAccount
+		}
+		withdrawReq, ok := request.(WithdrawGuildItemRequest)
+		if !ok {
+			return WithdrawGuildItemResponse{}, WrongRequestError{Endpoint: "WithdrawGuildItem"}
+		}
+
+		location, err := s.WithdrawGuildItem(user, sworld.ItemLocation{
+			BagID: withdrawReq.Location.BagID,
+			Slot:  withdrawReq.Location.Slot,
+		})
+		if err != nil {
+			return WithdrawGuildItemResponse{}, err
+		}
+
+		return WithdrawGuildItemResponse{
+			Location: ItemLocation{
+				BagID: location.BagID,
+				Slot:  location.Slot,
+			},
+		}, nil
+	}
+}
+
+// MakeDepositGuildGoldEndpoint creates the DepositGuildGold endpoint
+func MakeDepositGuildGoldEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return DepositGuildGoldResponse{}, ErrNoAccount
+		}
+		depositReq, ok := request.(DepositGuildGoldRequest)
+		if !ok {
+			return DepositGuildGoldResponse{}, WrongRequestError{Endpoint: "DepositGuildGold"}
+		}
+
+		guildGold, err := s.DepositGuildGold(user, depositReq.Amount)
+		if err != nil {
+			return DepositGuildGoldResponse{}, err
+		}
+
+		return DepositGuildGoldResponse{
+			Gold:      user.Gold,
+			GuildGold: guildGold,
+		}, nil
+	}
+}
+
+// MakeWithdrawGuildGoldEndpoint creates the WithdrawGuildGold endpoint
+func MakeWithdrawGuildGoldEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return WithdrawGuildGoldResponse{}, ErrNoAccount
+		}
+		withdrawReq, ok := request.(WithdrawGuildGoldRequest)
+		if !ok {
+			return WithdrawGuildGoldResponse{}, WrongRequestError{Endpoint: "WithdrawGuildGold"}
+		}
+
+		guildGold, err := s.WithdrawGuildGold(user, withdrawReq.Amount)
+		if err != nil {
+			return WithdrawGuildGoldResponse{}, err
+		}
+
+		return WithdrawGuildGoldResponse{
+			Gold:      user.Gold,
+			GuildGold: guildGold,
+		}, nil
+	}
+}
+
+// MakeCreatePartyEndpoint creates the CreateParty endpoint
+func MakeCreatePartyEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return CreatePartyResponse{}, ErrNoAccount
+		}
+		createReq, ok := request.(CreatePartyRequest)
+		if !ok {
+			return CreatePartyResponse{}, WrongRequestError{Endpoint: "CreateParty"}
+		}
+
+		party, err := s.CreateParty(user, createReq.CharacterID)
+		if err != nil {
+			return CreatePartyResponse{}, err
+		}
+
+		return CreatePartyResponse{
+			Party: partyDetails(party),
+		}, nil
+	}
+}
+
+// MakeViewPartyEndpoint creates the ViewParty endpoint
+func MakeViewPartyEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return ViewPartyResponse{}, ErrNoAccount
+		}
+
+		party, err := s.ViewParty(user)
+		if err != nil {
+			return ViewPartyResponse{}, err
+		}
+
+		return ViewPartyResponse{
+			Party: partyDetails(party),
+		}, nil
+	}
+}
+
+// MakeInviteToPartyEndpoint creates the InviteToParty endpoint
+func MakeInviteToPartyEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return InviteToPartyResponse{}, ErrNoAccount
+		}
+		inviteReq, ok := request.(InviteToPartyRequest)
+		if !ok {
+			return InviteToPartyResponse{}, WrongRequestError{Endpoint: "InviteToParty"}
+		}
+
+		party, err := s.InviteToParty(user, inviteReq.Username)
+		if err != nil {
+			return InviteToPartyResponse{}, err
+		}
+
+		return InviteToPartyResponse{
+			Party: partyDetails(party),
+		}, nil
+	}
+}
+
+// MakeAcceptPartyInviteEndpoint creates the AcceptPartyInvite endpoint
+func MakeAcceptPartyInviteEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return AcceptPartyInviteResponse{}, ErrNoAccount
+		}
+		acceptReq, ok := request.(AcceptPartyInviteRequest)
+		if !ok {
+			return AcceptPartyInviteResponse{}, WrongRequestError{Endpoint: "AcceptPartyInvite"}
+		}
+
+		party, err := s.AcceptPartyInvite(user, acceptReq.ID, acceptReq.CharacterID)
+		if err != nil {
+			return AcceptPartyInviteResponse{}, err
+		}
+
+		return AcceptPartyInviteResponse{
+			Party: partyDetails(party),
+		}, nil
+	}
+}
+
+// MakeLeavePartyEndpoint creates the LeaveParty endpoint
+func MakeLeavePartyEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return LeavePartyResponse{}, ErrNoAccount
+		}
+
+		if err := s.LeaveParty(user); err != nil {
+			return LeavePartyResponse{}, err
+		}
+
+		return LeavePartyResponse{}, nil
+	}
+}
+
+// MakeSetPartyLeaderEndpoint creates the SetPartyLeader endpoint
+func MakeSetPartyLeaderEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return SetPartyLeaderResponse{}, ErrNoAccount
+		}
+		leaderReq, ok := request.(SetPartyLeaderRequest)
+		if !ok {
+			return SetPartyLeaderResponse{}, WrongRequestError{Endpoint: "SetPartyLeader"}
+		}
+
+		party, err := s.SetPartyLeader(user, leaderReq.Username)
+		if err != nil {
+			return SetPartyLeaderResponse{}, err
+		}
+
+		return SetPartyLeaderResponse{
+			Party: partyDetails(party),
+		}, nil
+	}
+}
+
+// MakeOpenPartyPortalEndpoint creates the OpenPartyPortal endpoint
+func MakeOpenPartyPortalEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return OpenPartyPortalResponse{}, ErrNoAccount
+		}
+		portalReq, ok := request.(OpenPartyPortalRequest)
+		if !ok {
+			return OpenPartyPortalResponse{}, WrongRequestError{Endpoint: "OpenPartyPortal"}
+		}
+
+		var location *sworld.ItemLocation
+		if portalReq.StoneLocation != nil {
+			location = &sworld.ItemLocation{
+				BagID: portalReq.StoneLocation.BagID,
+				Slot:  portalReq.StoneLocation.Slot,
+			}
+		}
+
+		portal, err := s.OpenPartyPortal(user, location)
+		if err != nil {
+			return OpenPartyPortalResponse{}, err
+		}
+
+		return OpenPartyPortalResponse{
+			Portal: portalDetails(portal, false),
+		}, nil
+	}
+}
+
+// MakeJoinMatchmakingEndpoint creates the JoinMatchmaking endpoint
+func MakeJoinMatchmakingEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return JoinMatchmakingResponse{}, ErrNoAccount
+		}
+		joinReq, ok := request.(JoinMatchmakingRequest)
+		if !ok {
+			return JoinMatchmakingResponse{}, WrongRequestError{Endpoint: "JoinMatchmaking"}
+		}
+
+		status, err := s.JoinMatchmaking(user, joinReq.CharacterID, joinReq.ZoneID, joinReq.Level)
+		if err != nil {
+			return JoinMatchmakingResponse{}, err
+		}
+
+		return JoinMatchmakingResponse{
+			Status: matchmakingDetails(status),
+		}, nil
+	}
+}
+
+// MakeLeaveMatchmakingEndpoint creates the LeaveMatchmaking endpoint
+func MakeLeaveMatchmakingEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return LeaveMatchmakingResponse{}, ErrNoAccount
+		}
+
+		if err := s.LeaveMatchmaking(user); err != nil {
+			return LeaveMatchmakingResponse{}, err
+		}
+
+		return LeaveMatchmakingResponse{}, nil
+	}
+}
+
+// MakeMatchmakingStatusEndpoint creates the MatchmakingStatus endpoint
+func MakeMatchmakingStatusEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return MatchmakingStatusResponse{}, ErrNoAccount
+		}
+
+		status, err := s.MatchmakingStatus(user)
+		if err != nil {
+			return MatchmakingStatusResponse{}, err
+		}
+
+		return MatchmakingStatusResponse{
+			Status: matchmakingDetails(status),
+		}, nil
+	}
+}
+
+// MakeSendChatMessageEndpoint creates the SendChatMessage endpoint
+func MakeSendChatMessageEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return SendChatMessageResponse{}, ErrNoAccount
+		}
+		sendReq, ok := request.(SendChatMessageRequest)
+		if !ok {
+			return SendChatMessageResponse{}, WrongRequestError{Endpoint: "SendChatMessage"}
+		}
+
+		message, err := s.SendChatMessage(user, sendReq.Channel, sendReq.Target, sendReq.Text)
+		if err != nil {
+			return SendChatMessageResponse{}, err
+		}
+
+		return SendChatMessageResponse{
+			Message: chatMessageDetails(message),
+		}, nil
+	}
+}
+
+// MakeChatHistoryEndpoint creates the ChatHistory endpoint
+func MakeChatHistoryEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return ChatHistoryResponse{}, ErrNoAccount
+		}
+		historyReq, ok := request.(ChatHistoryRequest)
+		if !ok {
+			return ChatHistoryResponse{}, WrongRequestError{Endpoint: "ChatHistory"}
+		}
+
+		messages, err := s.ChatHistory(user, historyReq.Channel, historyReq.Target, historyReq.Limit)
+		if err != nil {
+			return ChatHistoryResponse{}, err
+		}
+
+		details := make([]*ChatMessageDetails, 0, len(messages))
+		for _, message := range messages {
+			details = append(details, chatMessageDetails(message))
+		}
+
+		return ChatHistoryResponse{
+			Messages: details,
+		}, nil
+	}
+}
+
+// MakeChatStreamEndpoint creates the ChatStream endpoint
+func MakeChatStreamEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return ChatStreamResponse{}, ErrNoAccount
+		}
+		streamReq, ok := request.(ChatStreamRequest)
+		if !ok {
+			return ChatStreamResponse{}, WrongRequestError{Endpoint: "ChatStream"}
+		}
+
+		subscription, err := s.SubscribeChat(user, streamReq.ZoneID)
+		if err != nil {
+			return ChatStreamResponse{}, err
+		}
+
+		return ChatStreamResponse{
+			messages: subscription.C,
+			close: func() {
+				s.UnsubscribeChat(subscription)
+			},
+		}, nil
+	}
+}
+
+// MakeChatSettingsEndpoint creates the ChatSettings endpoint
+func MakeChatSettingsEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return ChatSettingsResponse{}, ErrNoAccount
+		}
+
+		settings, err := s.ChatSettings(user)
+		if err != nil {
+			return ChatSettingsResponse{}, err
+		}
+
+		return ChatSettingsResponse{
+			Settings: chatSettingsDetails(settings),
+		}, nil
+	}
+}
+
+// MakeMuteChatUserEndpoint creates the MuteChatUser endpoint
+func MakeMuteChatUserEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return MuteChatUserResponse{}, ErrNoAccount
+		}
+		muteReq, ok := request.(MuteChatUserRequest)
+		if !ok {
+			return MuteChatUserResponse{}, WrongRequestError{Endpoint: "MuteChatUser"}
+		}
+
+		settings, err := s.MuteChatUser(user, muteReq.Username, muteReq.Muted)
+		if err != nil {
+			return MuteChatUserResponse{}, err
+		}
+
+		return MuteChatUserResponse{
+			Settings: chatSettingsDetails(settings),
+		}, nil
+	}
+}
+
+// MakeBlockChatUserEndpoint creates the BlockChatUser endpoint
+func MakeBlockChatUserEndpoint(s svc.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		user, ok := ctx.Value(ctxUserKey).(*sworld.User)
+		if !ok {
+			return BlockChatUserResponse{}, ErrNoAccount
+		}
+		blockReq, ok := request.(BlockChatUserRequest)
+		if !ok {
+			return BlockChatUserResponse{}, WrongRequestError{Endpoint: "BlockChatUser"}
+		}
+
+		settings, err := s.BlockChatUser(user, blockReq.Username, blockReq.Blocked)
+		if err != nil {
+			return BlockChatUserResponse{}, err
+		}
+
+		return BlockChatUserResponse{
+			Settings: chatSettingsDetails(settings),
+		}, nil
+	}
+}
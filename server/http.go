@@ -6,11 +6,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/go-kit/kit/auth/jwt"
 	"github.com/go-kit/kit/endpoint"
@@ -23,8 +25,15 @@ import (
 var (
 	// ErrBadRouting is when a handler receives the wrong information from a route
 	ErrBadRouting = errors.New("inconsistent mapping between route and handler")
+	// ErrStreamingUnsupported is when the connection can't be flushed
+	ErrStreamingUnsupported = errors.New("Streaming is not supported")
 )
 
+// TODO: this should be on settings
+// chatKeepAlive is how often an idle chat stream sends a comment, so
+// proxies don't close it
+const chatKeepAlive = 15 * time.Second
+
 type errorer interface {
 	error() error
 }
@@ -42,19 +51,99 @@ func MakeHTTPServer(s sworldservice.Service, logger klog.Logger) http.Handler {
 
 	r.Methods("POST").Path("/api/v1/auth").Handler(AuthenticateHTTPServer(e, options))
 	r.Methods("GET").Path("/api/v1/inventory").Handler(ViewUserInventoryHTTPServer(e, options))
+	r.Methods("GET").Path("/api/v1/inventory/search").Handler(SearchInventoryHTTPServer(e, options))
 	r.Methods("POST").Path("/api/v1/inventory/merge-stones").Handler(MergeStonesHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/inventory/merge-stones/preview").Handler(PreviewMergeStonesHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/inventory/merge-stones/all").Handler(MergeAllStonesHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/inventory/split").Handler(SplitStackHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/inventory/merge-stacks").Handler(MergeStacksHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/inventory/move").Handler(MoveItemHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/inventory/bags").Handler(BuyBagHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/inventory/bags/attach").Handler(AttachBagHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/inventory/bags/upgrade").Handler(UpgradeBagHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/inventory/sort").Handler(SortBagHTTPServer(e, options))
+	r.Methods("GET").Path("/api/v1/shop").Handler(ViewShopHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/shop/buy").Handler(BuyShopItemHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/shop/sell").Handler(SellItemHTTPServer(e, options))
+
+	r.Methods("GET").Path("/api/v1/crafting/recipes").Handler(ListRecipesHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/crafting/craft").Handler(CraftHTTPServer(e, options))
 
+	r.Methods("GET").Path("/api/v1/classes").Handler(ListClassesHTTPServer(e, options))
 	r.Methods("GET").Path("/api/v1/characters").Handler(ListCharactersHTTPServer(e, options))
 	r.Methods("POST").Path("/api/v1/characters").Handler(SpawnCharacterHTTPServer(e, options))
 	r.Methods("GET").Path("/api/v1/characters/{id}").Handler(ViewCharacterHTTPServer(e, options))
 	r.Methods("GET").Path("/api/v1/characters/{id}/inventory").Handler(ViewCharacterInventoryHTTPServer(e, options))
 	r.Methods("POST").Path("/api/v1/characters/{id}/drop").Handler(DropCharacterItemHTTPServer(e, options))
 	r.Methods("POST").Path("/api/v1/characters/{id}/take").Handler(TakeCharacterItemHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/characters/{id}/equip").Handler(EquipCharacterItemHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/characters/{id}/use").Handler(UseItemHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/characters/{id}/attributes").Handler(AllocateAttributesHTTPServer(e, options))
+	r.Methods("GET").Path("/api/v1/characters/{id}/talents").Handler(ListTalentsHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/characters/{id}/talents").Handler(LearnTalentHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/characters/{id}/respec").Handler(RespecCharacterHTTPServer(e, options))
 
 	r.Methods("POST").Path("/api/v1/portals").Handler(OpenPortalHTTPServer(e, options))
 	r.Methods("GET").Path("/api/v1/portals").Handler(ListPortalsHTTPServer(e, options))
+	r.Methods("GET").Path("/api/v1/portals/history").Handler(PortalHistoryHTTPServer(e, options))
+	r.Methods("GET").Path("/api/v1/portals/history/{id}").Handler(ViewPortalRunHTTPServer(e, options))
 	r.Methods("GET").Path("/api/v1/portals/{id}").Handler(ViewPortalHTTPServer(e, options))
 	r.Methods("POST").Path("/api/v1/portals/{id}/explore").Handler(ExplorePortalHTTPServer(e, options))
+	r.Methods("GET").Path("/api/v1/portals/{id}/replay").Handler(PortalReplayHTTPServer(e, options))
+
+	r.Methods("GET").Path("/api/v1/leaderboards/{kind}").Handler(LeaderboardHTTPServer(e, options))
+
+	r.Methods("GET").Path("/api/v1/achievements").Handler(ListAchievementsHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/achievements/{id}/claim").Handler(ClaimAchievementHTTPServer(e, options))
+
+	r.Methods("GET").Path("/api/v1/quests").Handler(ListQuestsHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/quests/{id}/accept").Handler(AcceptQuestHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/quests/{id}/claim").Handler(ClaimQuestHTTPServer(e, options))
+
+	r.Methods("POST").Path("/api/v1/guilds").Handler(CreateGuildHTTPServer(e, options))
+	r.Methods("GET").Path("/api/v1/guild").Handler(ViewGuildHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/guild/invite").Handler(InviteToGuildHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/guilds/{id}/join").Handler(JoinGuildHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/guild/leave").Handler(LeaveGuildHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/guild/kick").Handler(KickGuildMemberHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/guild/role").Handler(SetGuildRoleHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/guild/stash/deposit").Handler(DepositGuildItemHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/guild/stash/withdraw").Handler(WithdrawGuildItemHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/guild/gold/deposit").Handler(DepositGuildGoldHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/guild/gold/withdraw").Handler(WithdrawGuildGoldHTTPServer(e, options))
+
+	r.Methods("POST").Path("/api/v1/parties").Handler(CreatePartyHTTPServer(e, options))
+	r.Methods("GET").Path("/api/v1/party").Handler(ViewPartyHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/party/invite").Handler(InviteToPartyHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/parties/{id}/accept").Handler(AcceptPartyInviteHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/party/leave").Handler(LeavePartyHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/party/leader").Handler(SetPartyLeaderHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/party/portal").Handler(OpenPartyPortalHTTPServer(e, options))
+
+	r.Methods("POST").Path("/api/v1/matchmaking").Handler(JoinMatchmakingHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/matchmaking/leave").Handler(LeaveMatchmakingHTTPServer(e, options))
+	r.Methods("GET").Path("/api/v1/matchmaking").Handler(MatchmakingStatusHTTPServer(e, options))
+
+	r.Methods("POST").Path("/api/v1/chat/messages").Handler(SendChatMessageHTTPServer(e, options))
+	r.Methods("GET").Path("/api/v1/chat/messages").Handler(ChatHistoryHTTPServer(e, options))
+	r.Methods("GET").Path("/api/v1/chat/stream").Handler(ChatStreamHTTPServer(e, options))
+	r.Methods("GET").Path("/api/v1/chat/settings").Handler(ChatSettingsHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/chat/mute").Handler(MuteChatUserHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/chat/block").Handler(BlockChatUserHTTPServer(e, options))
+
+	r.Methods("POST").Path("/api/v1/trades").Handler(OpenTradeHTTPServer(e, options))
+	r.Methods("GET").Path("/api/v1/trades").Handler(ListTradesHTTPServer(e, options))
+	r.Methods("GET").Path("/api/v1/trades/{id}").Handler(ViewTradeHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/trades/{id}/offer").Handler(SetTradeOfferHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/trades/{id}/confirm").Handler(ConfirmTradeHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/trades/{id}/cancel").Handler(CancelTradeHTTPServer(e, options))
+
+	r.Methods("POST").Path("/api/v1/auctions").Handler(CreateAuctionHTTPServer(e, options))
+	r.Methods("GET").Path("/api/v1/auctions").Handler(SearchAuctionsHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/auctions/claim").Handler(ClaimAuctionItemsHTTPServer(e, options))
+	r.Methods("GET").Path("/api/v1/auctions/{id}").Handler(ViewAuctionHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/auctions/{id}/bid").Handler(BidAuctionHTTPServer(e, options))
+	r.Methods("POST").Path("/api/v1/auctions/{id}/buyout").Handler(BuyoutAuctionHTTPServer(e, options))
 
 	return r
 }
@@ -101,21 +190,90 @@ func MakeHTTPClientEndpoints(instance string) (Endpoints, error) {
 	}
 
 	return Endpoints{
-		AuthenticateEndpoint:      AuthenticateHTTPClient(tgt, options),
-		ViewUserInventoryEndpoint: ViewUserInventoryHTTPClient(tgt, options),
-		MergeStonesEndpoint:       MergeStonesHTTPClient(tgt, options),
+		AuthenticateEndpoint:       AuthenticateHTTPClient(tgt, options),
+		ViewUserInventoryEndpoint:  ViewUserInventoryHTTPClient(tgt, options),
+		SearchInventoryEndpoint:    SearchInventoryHTTPClient(tgt, options),
+		MergeStonesEndpoint:        MergeStonesHTTPClient(tgt, options),
+		PreviewMergeStonesEndpoint: PreviewMergeStonesHTTPClient(tgt, options),
+		MergeAllStonesEndpoint:     MergeAllStonesHTTPClient(tgt, options),
+		SplitStackEndpoint:         SplitStackHTTPClient(tgt, options),
+		MergeStacksEndpoint:        MergeStacksHTTPClient(tgt, options),
+		MoveItemEndpoint:           MoveItemHTTPClient(tgt, options),
+		BuyBagEndpoint:             BuyBagHTTPClient(tgt, options),
+		AttachBagEndpoint:          AttachBagHTTPClient(tgt, options),
+		UpgradeBagEndpoint:         UpgradeBagHTTPClient(tgt, options),
+		SortBagEndpoint:            SortBagHTTPClient(tgt, options),
+		ViewShopEndpoint:           ViewShopHTTPClient(tgt, options),
+		BuyShopItemEndpoint:        BuyShopItemHTTPClient(tgt, options),
+		SellItemEndpoint:           SellItemHTTPClient(tgt, options),
+		ListRecipesEndpoint:        ListRecipesHTTPClient(tgt, options),
+		CraftEndpoint:              CraftHTTPClient(tgt, options),
 
 		ViewCharacterEndpoint:          ViewCharacterHTTPClient(tgt, options),
 		ListCharactersEndpoint:         ListCharactersHTTPClient(tgt, options),
+		ListClassesEndpoint:            ListClassesHTTPClient(tgt, options),
 		SpawnCharacterEndpoint:         SpawnCharacterHTTPClient(tgt, options),
 		ViewCharacterInventoryEndpoint: ViewCharacterInventoryHTTPClient(tgt, options),
 		DropCharacterItemEndpoint:      DropCharacterItemHTTPClient(tgt, options),
 		TakeCharacterItemEndpoint:      TakeCharacterItemHTTPClient(tgt, options),
+		EquipCharacterItemEndpoint:     EquipCharacterItemHTTPClient(tgt, options),
+		UseItemEndpoint:                UseItemHTTPClient(tgt, options),
+		AllocateAttributesEndpoint:     AllocateAttributesHTTPClient(tgt, options),
+		ListTalentsEndpoint:            ListTalentsHTTPClient(tgt, options),
+		LearnTalentEndpoint:            LearnTalentHTTPClient(tgt, options),
+		RespecCharacterEndpoint:        RespecCharacterHTTPClient(tgt, options),
 
-		OpenPortalEndpoint:    OpenPortalHTTPClient(tgt, options),
-		ExplorePortalEndpoint: ExplorePortalHTTPClient(tgt, options),
-		ListPortalsEndpoint:   ListPortalsHTTPClient(tgt, options),
-		ViewPortalEndpoint:    ViewPortalHTTPClient(tgt, options),
+		OpenPortalEndpoint:        OpenPortalHTTPClient(tgt, options),
+		ExplorePortalEndpoint:     ExplorePortalHTTPClient(tgt, options),
+		OpenTradeEndpoint:         OpenTradeHTTPClient(tgt, options),
+		ListTradesEndpoint:        ListTradesHTTPClient(tgt, options),
+		ViewTradeEndpoint:         ViewTradeHTTPClient(tgt, options),
+		SetTradeOfferEndpoint:     SetTradeOfferHTTPClient(tgt, options),
+		ConfirmTradeEndpoint:      ConfirmTradeHTTPClient(tgt, options),
+		CancelTradeEndpoint:       CancelTradeHTTPClient(tgt, options),
+		CreateAuctionEndpoint:     CreateAuctionHTTPClient(tgt, options),
+		SearchAuctionsEndpoint:    SearchAuctionsHTTPClient(tgt, options),
+		ClaimAuctionItemsEndpoint: ClaimAuctionItemsHTTPClient(tgt, options),
+		ViewAuctionEndpoint:       ViewAuctionHTTPClient(tgt, options),
+		BidAuctionEndpoint:        BidAuctionHTTPClient(tgt, options),
+		BuyoutAuctionEndpoint:     BuyoutAuctionHTTPClient(tgt, options),
+		ListPortalsEndpoint:       ListPortalsHTTPClient(tgt, options),
+		PortalHistoryEndpoint:     PortalHistoryHTTPClient(tgt, options),
+		ViewPortalRunEndpoint:     ViewPortalRunHTTPClient(tgt, options),
+		PortalReplayEndpoint:      PortalReplayHTTPClient(tgt, options),
+		LeaderboardEndpoint:       LeaderboardHTTPClient(tgt, options),
+		ListAchievementsEndpoint:  ListAchievementsHTTPClient(tgt, options),
+		ClaimAchievementEndpoint:  ClaimAchievementHTTPClient(tgt, options),
+		ListQuestsEndpoint:        ListQuestsHTTPClient(tgt, options),
+		AcceptQuestEndpoint:       AcceptQuestHTTPClient(tgt, options),
+		ClaimQuestEndpoint:        ClaimQuestHTTPClient(tgt, options),
+		CreateGuildEndpoint:       CreateGuildHTTPClient(tgt, options),
+		ViewGuildEndpoint:         ViewGuildHTTPClient(tgt, options),
+		InviteToGuildEndpoint:     InviteToGuildHTTPClient(tgt, options),
+		JoinGuildEndpoint:         JoinGuildHTTPClient(tgt, options),
+		LeaveGuildEndpoint:        LeaveGuildHTTPClient(tgt, options),
+		KickGuildMemberEndpoint:   KickGuildMemberHTTPClient(tgt, options),
+		SetGuildRoleEndpoint:      SetGuildRoleHTTPClient(tgt, options),
+		DepositGuildItemEndpoint:  DepositGuildItemHTTPClient(tgt, options),
+		WithdrawGuildItemEndpoint: WithdrawGuildItemHTTPClient(tgt, options),
+		DepositGuildGoldEndpoint:  DepositGuildGoldHTTPClient(tgt, options),
+		WithdrawGuildGoldEndpoint: WithdrawGuildGoldHTTPClient(tgt, options),
+		CreatePartyEndpoint:       CreatePartyHTTPClient(tgt, options),
+		ViewPartyEndpoint:         ViewPartyHTTPClient(tgt, options),
+		InviteToPartyEndpoint:     InviteToPartyHTTPClient(tgt, options),
+		AcceptPartyInviteEndpoint: AcceptPartyInviteHTTPClient(tgt, options),
+		LeavePartyEndpoint:        LeavePartyHTTPClient(tgt, options),
+		SetPartyLeaderEndpoint:    SetPartyLeaderHTTPClient(tgt, options),
+		OpenPartyPortalEndpoint:   OpenPartyPortalHTTPClient(tgt, options),
+		JoinMatchmakingEndpoint:   JoinMatchmakingHTTPClient(tgt, options),
+		LeaveMatchmakingEndpoint:  LeaveMatchmakingHTTPClient(tgt, options),
+		MatchmakingStatusEndpoint: MatchmakingStatusHTTPClient(tgt, options),
+		SendChatMessageEndpoint:   SendChatMessageHTTPClient(tgt, options),
+		ChatHistoryEndpoint:       ChatHistoryHTTPClient(tgt, options),
+		ChatSettingsEndpoint:      ChatSettingsHTTPClient(tgt, options),
+		MuteChatUserEndpoint:      MuteChatUserHTTPClient(tgt, options),
+		BlockChatUserEndpoint:     BlockChatUserHTTPClient(tgt, options),
+		ViewPortalEndpoint:        ViewPortalHTTPClient(tgt, options),
 	}, nil
 }
 
@@ -449,6 +607,92 @@ func TakeCharacterItemHTTPClient(tgt *url.URL, options []httptransport.ClientOpt
 	).Endpoint()
 }
 
+// EquipCharacterItemHTTPServer serves the EquipCharacterItemEndpoint
+func EquipCharacterItemHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.EquipCharacterItemEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			vars := mux.Vars(r)
+			id, ok := vars["id"]
+			if !ok {
+				return nil, ErrBadRouting
+			}
+
+			var req EquipCharacterItemRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+			req.CharacterID = id
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// EquipCharacterItemHTTPClient calls the EquipCharacterItemEndpoint
+func EquipCharacterItemHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			equipReq, ok := request.(EquipCharacterItemRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = fmt.Sprintf("/api/v1/characters/%s/equip", equipReq.CharacterID)
+			return encodeRequest(ctx, req, equipReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response EquipCharacterItemResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// UseItemHTTPServer serves the UseItemEndpoint
+func UseItemHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.UseItemEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			vars := mux.Vars(r)
+			id, ok := vars["id"]
+			if !ok {
+				return nil, ErrBadRouting
+			}
+
+			var req UseItemRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+			req.CharacterID = id
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// UseItemHTTPClient calls the UseItemEndpoint
+func UseItemHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			useReq, ok := request.(UseItemRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = fmt.Sprintf("/api/v1/characters/%s/use", useReq.CharacterID)
+			return encodeRequest(ctx, req, useReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response UseItemResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
 // MergeStonesHTTPServer serves the MergeStonesEndpoint
 func MergeStonesHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
 	return httptransport.NewServer(endpoints.MergeStonesEndpoint,
@@ -485,11 +729,123 @@ func MergeStonesHTTPClient(tgt *url.URL, options []httptransport.ClientOption) e
 	).Endpoint()
 }
 
+// SplitStackHTTPServer serves the SplitStackEndpoint
+func SplitStackHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.SplitStackEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req SplitStackRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// SplitStackHTTPClient calls the SplitStackEndpoint
+func SplitStackHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			splitReq, ok := request.(SplitStackRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/inventory/split"
+			return encodeRequest(ctx, req, splitReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response SplitStackResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// MergeStacksHTTPServer serves the MergeStacksEndpoint
+func MergeStacksHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.MergeStacksEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req MergeStacksRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// MergeStacksHTTPClient calls the MergeStacksEndpoint
+func MergeStacksHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			mergeReq, ok := request.(MergeStacksRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/inventory/merge-stacks"
+			return encodeRequest(ctx, req, mergeReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response MergeStacksResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// MoveItemHTTPServer serves the MoveItemEndpoint
+func MoveItemHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.MoveItemEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req MoveItemRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// MoveItemHTTPClient calls the MoveItemEndpoint
+func MoveItemHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			moveReq, ok := request.(MoveItemRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/inventory/move"
+			return encodeRequest(ctx, req, moveReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response MoveItemResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
 // SpawnCharacterHTTPServer serves the SpawnCharacterEndpoint
 func SpawnCharacterHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
 	return httptransport.NewServer(endpoints.SpawnCharacterEndpoint,
 		func(_ context.Context, r *http.Request) (request interface{}, err error) {
 			var req SpawnCharacterRequest
+			// The class is optional, so an empty body is a valid request
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil && e != io.EOF {
+				return nil, e
+			}
 
 			return req, nil
 		},
@@ -604,6 +960,2372 @@ func ExplorePortalHTTPClient(tgt *url.URL, options []httptransport.ClientOption)
 	).Endpoint()
 }
 
+// BuyBagHTTPServer serves the BuyBagEndpoint
+func BuyBagHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.BuyBagEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req BuyBagRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// BuyBagHTTPClient calls the BuyBagEndpoint
+func BuyBagHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			buyBagReq, ok := request.(BuyBagRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/inventory/bags"
+			return encodeRequest(ctx, req, buyBagReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response BuyBagResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// AttachBagHTTPServer serves the AttachBagEndpoint
+func AttachBagHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.AttachBagEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req AttachBagRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// AttachBagHTTPClient calls the AttachBagEndpoint
+func AttachBagHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			attachBagReq, ok := request.(AttachBagRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/inventory/bags/attach"
+			return encodeRequest(ctx, req, attachBagReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response AttachBagResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// UpgradeBagHTTPServer serves the UpgradeBagEndpoint
+func UpgradeBagHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.UpgradeBagEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req UpgradeBagRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// UpgradeBagHTTPClient calls the UpgradeBagEndpoint
+func UpgradeBagHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			upgradeBagReq, ok := request.(UpgradeBagRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/inventory/bags/upgrade"
+			return encodeRequest(ctx, req, upgradeBagReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response UpgradeBagResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// SearchInventoryHTTPServer serves the SearchInventoryEndpoint
+func SearchInventoryHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.SearchInventoryEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			return decodeSearchInventoryRequest(r.URL.Query())
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// SearchInventoryHTTPClient calls the SearchInventoryEndpoint
+func SearchInventoryHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("GET", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			searchInventoryReq, ok := request.(SearchInventoryRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/inventory/search"
+			req.URL.RawQuery = searchInventoryReq.values().Encode()
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response SearchInventoryResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// SortBagHTTPServer serves the SortBagEndpoint
+func SortBagHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.SortBagEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req SortBagRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// SortBagHTTPClient calls the SortBagEndpoint
+func SortBagHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			sortBagReq, ok := request.(SortBagRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/inventory/sort"
+			return encodeRequest(ctx, req, sortBagReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response SortBagResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// PreviewMergeStonesHTTPServer serves the PreviewMergeStonesEndpoint
+func PreviewMergeStonesHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.PreviewMergeStonesEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req PreviewMergeStonesRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// PreviewMergeStonesHTTPClient calls the PreviewMergeStonesEndpoint
+func PreviewMergeStonesHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			previewMergeStonesReq, ok := request.(PreviewMergeStonesRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/inventory/merge-stones/preview"
+			return encodeRequest(ctx, req, previewMergeStonesReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response PreviewMergeStonesResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// MergeAllStonesHTTPServer serves the MergeAllStonesEndpoint
+func MergeAllStonesHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.MergeAllStonesEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req MergeAllStonesRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// MergeAllStonesHTTPClient calls the MergeAllStonesEndpoint
+func MergeAllStonesHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			mergeAllStonesReq, ok := request.(MergeAllStonesRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/inventory/merge-stones/all"
+			return encodeRequest(ctx, req, mergeAllStonesReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response MergeAllStonesResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// ViewShopHTTPServer serves the ViewShopEndpoint
+func ViewShopHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.ViewShopEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			return ViewShopRequest{
+				ZoneID: r.URL.Query().Get("zone"),
+			}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// ViewShopHTTPClient calls the ViewShopEndpoint
+func ViewShopHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("GET", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			viewShopReq, ok := request.(ViewShopRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/shop"
+			if viewShopReq.ZoneID != "" {
+				req.URL.RawQuery = url.Values{"zone": {viewShopReq.ZoneID}}.Encode()
+			}
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response ViewShopResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// BuyShopItemHTTPServer serves the BuyShopItemEndpoint
+func BuyShopItemHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.BuyShopItemEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req BuyShopItemRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// BuyShopItemHTTPClient calls the BuyShopItemEndpoint
+func BuyShopItemHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			buyShopItemReq, ok := request.(BuyShopItemRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/shop/buy"
+			return encodeRequest(ctx, req, buyShopItemReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response BuyShopItemResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// SellItemHTTPServer serves the SellItemEndpoint
+func SellItemHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.SellItemEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req SellItemRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// SellItemHTTPClient calls the SellItemEndpoint
+func SellItemHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			sellItemReq, ok := request.(SellItemRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/shop/sell"
+			return encodeRequest(ctx, req, sellItemReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response SellItemResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// OpenTradeHTTPServer serves the OpenTradeEndpoint
+func OpenTradeHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.OpenTradeEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req OpenTradeRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// OpenTradeHTTPClient calls the OpenTradeEndpoint
+func OpenTradeHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			openTradeReq, ok := request.(OpenTradeRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/trades"
+			return encodeRequest(ctx, req, openTradeReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response TradeResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// ListTradesHTTPServer serves the ListTradesEndpoint
+func ListTradesHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.ListTradesEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			return ListTradesRequest{}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// ListTradesHTTPClient calls the ListTradesEndpoint
+func ListTradesHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("GET", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			_, ok := request.(ListTradesRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/trades"
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response ListTradesResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// ViewTradeHTTPServer serves the ViewTradeEndpoint
+func ViewTradeHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.ViewTradeEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			vars := mux.Vars(r)
+			id, ok := vars["id"]
+			if !ok {
+				return nil, ErrBadRouting
+			}
+
+			return ViewTradeRequest{
+				ID: id,
+			}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// ViewTradeHTTPClient calls the ViewTradeEndpoint
+func ViewTradeHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("GET", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			viewTradeReq, ok := request.(ViewTradeRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = fmt.Sprintf("/api/v1/trades/%s", viewTradeReq.ID)
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response TradeResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// SetTradeOfferHTTPServer serves the SetTradeOfferEndpoint
+func SetTradeOfferHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.SetTradeOfferEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			vars := mux.Vars(r)
+			id, ok := vars["id"]
+			if !ok {
+				return nil, ErrBadRouting
+			}
+
+			var req SetTradeOfferRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+			req.ID = id
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// SetTradeOfferHTTPClient calls the SetTradeOfferEndpoint
+func SetTradeOfferHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			setTradeOfferReq, ok := request.(SetTradeOfferRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = fmt.Sprintf("/api/v1/trades/%s/offer", setTradeOfferReq.ID)
+			return encodeRequest(ctx, req, setTradeOfferReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response TradeResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// ConfirmTradeHTTPServer serves the ConfirmTradeEndpoint
+func ConfirmTradeHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.ConfirmTradeEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			vars := mux.Vars(r)
+			id, ok := vars["id"]
+			if !ok {
+				return nil, ErrBadRouting
+			}
+
+			return ConfirmTradeRequest{
+				ID: id,
+			}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// ConfirmTradeHTTPClient calls the ConfirmTradeEndpoint
+func ConfirmTradeHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			confirmTradeReq, ok := request.(ConfirmTradeRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = fmt.Sprintf("/api/v1/trades/%s/confirm", confirmTradeReq.ID)
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response TradeResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// CancelTradeHTTPServer serves the CancelTradeEndpoint
+func CancelTradeHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.CancelTradeEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			vars := mux.Vars(r)
+			id, ok := vars["id"]
+			if !ok {
+				return nil, ErrBadRouting
+			}
+
+			return CancelTradeRequest{
+				ID: id,
+			}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// CancelTradeHTTPClient calls the CancelTradeEndpoint
+func CancelTradeHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			cancelTradeReq, ok := request.(CancelTradeRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = fmt.Sprintf("/api/v1/trades/%s/cancel", cancelTradeReq.ID)
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response TradeResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// CreateAuctionHTTPServer serves the CreateAuctionEndpoint
+func CreateAuctionHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.CreateAuctionEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req CreateAuctionRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// CreateAuctionHTTPClient calls the CreateAuctionEndpoint
+func CreateAuctionHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			createAuctionReq, ok := request.(CreateAuctionRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/auctions"
+			return encodeRequest(ctx, req, createAuctionReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response AuctionResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// SearchAuctionsHTTPServer serves the SearchAuctionsEndpoint
+func SearchAuctionsHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.SearchAuctionsEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			req, err := decodeSearchInventoryRequest(r.URL.Query())
+			if err != nil {
+				return nil, WrongRequestError{Endpoint: "SearchAuctions"}
+			}
+			return SearchAuctionsRequest{req}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// SearchAuctionsHTTPClient calls the SearchAuctionsEndpoint
+func SearchAuctionsHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("GET", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			searchAuctionsReq, ok := request.(SearchAuctionsRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/auctions"
+			req.URL.RawQuery = searchAuctionsReq.values().Encode()
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response SearchAuctionsResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// ClaimAuctionItemsHTTPServer serves the ClaimAuctionItemsEndpoint
+func ClaimAuctionItemsHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.ClaimAuctionItemsEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			return ClaimAuctionItemsRequest{}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// ClaimAuctionItemsHTTPClient calls the ClaimAuctionItemsEndpoint
+func ClaimAuctionItemsHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			_, ok := request.(ClaimAuctionItemsRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/auctions/claim"
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response ClaimAuctionItemsResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// ViewAuctionHTTPServer serves the ViewAuctionEndpoint
+func ViewAuctionHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.ViewAuctionEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			vars := mux.Vars(r)
+			id, ok := vars["id"]
+			if !ok {
+				return nil, ErrBadRouting
+			}
+
+			return ViewAuctionRequest{
+				ID: id,
+			}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// ViewAuctionHTTPClient calls the ViewAuctionEndpoint
+func ViewAuctionHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("GET", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			viewAuctionReq, ok := request.(ViewAuctionRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = fmt.Sprintf("/api/v1/auctions/%s", viewAuctionReq.ID)
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response AuctionResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// BidAuctionHTTPServer serves the BidAuctionEndpoint
+func BidAuctionHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.BidAuctionEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			vars := mux.Vars(r)
+			id, ok := vars["id"]
+			if !ok {
+				return nil, ErrBadRouting
+			}
+
+			var req BidAuctionRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+			req.ID = id
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// BidAuctionHTTPClient calls the BidAuctionEndpoint
+func BidAuctionHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			bidAuctionReq, ok := request.(BidAuctionRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = fmt.Sprintf("/api/v1/auctions/%s/bid", bidAuctionReq.ID)
+			return encodeRequest(ctx, req, bidAuctionReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response AuctionResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// BuyoutAuctionHTTPServer serves the BuyoutAuctionEndpoint
+func BuyoutAuctionHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.BuyoutAuctionEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			vars := mux.Vars(r)
+			id, ok := vars["id"]
+			if !ok {
+				return nil, ErrBadRouting
+			}
+
+			return BuyoutAuctionRequest{
+				ID: id,
+			}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// BuyoutAuctionHTTPClient calls the BuyoutAuctionEndpoint
+func BuyoutAuctionHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			buyoutAuctionReq, ok := request.(BuyoutAuctionRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = fmt.Sprintf("/api/v1/auctions/%s/buyout", buyoutAuctionReq.ID)
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response AuctionResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// ListRecipesHTTPServer serves the ListRecipesEndpoint
+func ListRecipesHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.ListRecipesEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			return ListRecipesRequest{}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// ListRecipesHTTPClient calls the ListRecipesEndpoint
+func ListRecipesHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("GET", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			_, ok := request.(ListRecipesRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/crafting/recipes"
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response ListRecipesResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// CraftHTTPServer serves the CraftEndpoint
+func CraftHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.CraftEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req CraftRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// CraftHTTPClient calls the CraftEndpoint
+func CraftHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			craftReq, ok := request.(CraftRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/crafting/craft"
+			return encodeRequest(ctx, req, craftReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response CraftResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// PortalHistoryHTTPServer serves the PortalHistoryEndpoint
+func PortalHistoryHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.PortalHistoryEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			return decodePortalHistoryRequest(r.URL.Query())
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// PortalHistoryHTTPClient calls the PortalHistoryEndpoint
+func PortalHistoryHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("GET", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			portalHistoryReq, ok := request.(PortalHistoryRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/portals/history"
+			req.URL.RawQuery = portalHistoryReq.values().Encode()
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response PortalHistoryResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// ViewPortalRunHTTPServer serves the ViewPortalRunEndpoint
+func ViewPortalRunHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.ViewPortalRunEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			vars := mux.Vars(r)
+			id, ok := vars["id"]
+			if !ok {
+				return nil, ErrBadRouting
+			}
+
+			return ViewPortalRunRequest{
+				ID: id,
+			}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// ViewPortalRunHTTPClient calls the ViewPortalRunEndpoint
+func ViewPortalRunHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("GET", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			viewPortalRunReq, ok := request.(ViewPortalRunRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = fmt.Sprintf("/api/v1/portals/history/%s", viewPortalRunReq.ID)
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response ViewPortalRunResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// PortalReplayHTTPServer serves the PortalReplayEndpoint
+func PortalReplayHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.PortalReplayEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			vars := mux.Vars(r)
+			id, ok := vars["id"]
+			if !ok {
+				return nil, ErrBadRouting
+			}
+
+			step, err := queryInt(r.URL.Query(), "step")
+			if err != nil {
+				return nil, WrongRequestError{Endpoint: "PortalReplay"}
+			}
+			return PortalReplayRequest{PortalID: id, Step: step}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// PortalReplayHTTPClient calls the PortalReplayEndpoint
+func PortalReplayHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("GET", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			portalReplayReq, ok := request.(PortalReplayRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = fmt.Sprintf("/api/v1/portals/%s/replay", portalReplayReq.PortalID)
+			if portalReplayReq.Step > 0 {
+				req.URL.RawQuery = url.Values{"step": {fmt.Sprint(portalReplayReq.Step)}}.Encode()
+			}
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response PortalReplayResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// LeaderboardHTTPServer serves the LeaderboardEndpoint
+func LeaderboardHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.LeaderboardEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			vars := mux.Vars(r)
+			kind, ok := vars["kind"]
+			if !ok {
+				return nil, ErrBadRouting
+			}
+
+			return decodeLeaderboardRequest(kind, r.URL.Query())
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// LeaderboardHTTPClient calls the LeaderboardEndpoint
+func LeaderboardHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("GET", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			leaderboardReq, ok := request.(LeaderboardRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = fmt.Sprintf("/api/v1/leaderboards/%s", leaderboardReq.Kind)
+			req.URL.RawQuery = leaderboardReq.values().Encode()
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response LeaderboardResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// ListAchievementsHTTPServer serves the ListAchievementsEndpoint
+func ListAchievementsHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.ListAchievementsEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			return ListAchievementsRequest{}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// ListAchievementsHTTPClient calls the ListAchievementsEndpoint
+func ListAchievementsHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("GET", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			_, ok := request.(ListAchievementsRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/achievements"
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response ListAchievementsResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// ClaimAchievementHTTPServer serves the ClaimAchievementEndpoint
+func ClaimAchievementHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.ClaimAchievementEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			vars := mux.Vars(r)
+			id, ok := vars["id"]
+			if !ok {
+				return nil, ErrBadRouting
+			}
+
+			return ClaimAchievementRequest{
+				ID: id,
+			}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// ClaimAchievementHTTPClient calls the ClaimAchievementEndpoint
+func ClaimAchievementHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			claimAchievementReq, ok := request.(ClaimAchievementRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = fmt.Sprintf("/api/v1/achievements/%s/claim", claimAchievementReq.ID)
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response ClaimAchievementResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// ListQuestsHTTPServer serves the ListQuestsEndpoint
+func ListQuestsHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.ListQuestsEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			return ListQuestsRequest{}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// ListQuestsHTTPClient calls the ListQuestsEndpoint
+func ListQuestsHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("GET", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			_, ok := request.(ListQuestsRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/quests"
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response ListQuestsResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// AcceptQuestHTTPServer serves the AcceptQuestEndpoint
+func AcceptQuestHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.AcceptQuestEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			vars := mux.Vars(r)
+			id, ok := vars["id"]
+			if !ok {
+				return nil, ErrBadRouting
+			}
+
+			return AcceptQuestRequest{
+				ID: id,
+			}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// AcceptQuestHTTPClient calls the AcceptQuestEndpoint
+func AcceptQuestHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			acceptQuestReq, ok := request.(AcceptQuestRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = fmt.Sprintf("/api/v1/quests/%s/accept", acceptQuestReq.ID)
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response AcceptQuestResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// ClaimQuestHTTPServer serves the ClaimQuestEndpoint
+func ClaimQuestHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.ClaimQuestEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			vars := mux.Vars(r)
+			id, ok := vars["id"]
+			if !ok {
+				return nil, ErrBadRouting
+			}
+
+			return ClaimQuestRequest{
+				ID: id,
+			}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// ClaimQuestHTTPClient calls the ClaimQuestEndpoint
+func ClaimQuestHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			claimQuestReq, ok := request.(ClaimQuestRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = fmt.Sprintf("/api/v1/quests/%s/claim", claimQuestReq.ID)
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response ClaimQuestResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// CreateGuildHTTPServer serves the CreateGuildEndpoint
+func CreateGuildHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.CreateGuildEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req CreateGuildRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// CreateGuildHTTPClient calls the CreateGuildEndpoint
+func CreateGuildHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			createGuildReq, ok := request.(CreateGuildRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/guilds"
+			return encodeRequest(ctx, req, createGuildReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response CreateGuildResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// ViewGuildHTTPServer serves the ViewGuildEndpoint
+func ViewGuildHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.ViewGuildEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			return ViewGuildRequest{}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// ViewGuildHTTPClient calls the ViewGuildEndpoint
+func ViewGuildHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("GET", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			_, ok := request.(ViewGuildRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/guild"
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response ViewGuildResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// InviteToGuildHTTPServer serves the InviteToGuildEndpoint
+func InviteToGuildHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.InviteToGuildEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req InviteToGuildRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// InviteToGuildHTTPClient calls the InviteToGuildEndpoint
+func InviteToGuildHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			inviteToGuildReq, ok := request.(InviteToGuildRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/guild/invite"
+			return encodeRequest(ctx, req, inviteToGuildReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response InviteToGuildResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// JoinGuildHTTPServer serves the JoinGuildEndpoint
+func JoinGuildHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.JoinGuildEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			vars := mux.Vars(r)
+			id, ok := vars["id"]
+			if !ok {
+				return nil, ErrBadRouting
+			}
+
+			return JoinGuildRequest{
+				ID: id,
+			}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// JoinGuildHTTPClient calls the JoinGuildEndpoint
+func JoinGuildHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			joinGuildReq, ok := request.(JoinGuildRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = fmt.Sprintf("/api/v1/guilds/%s/join", joinGuildReq.ID)
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response JoinGuildResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// LeaveGuildHTTPServer serves the LeaveGuildEndpoint
+func LeaveGuildHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.LeaveGuildEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			return LeaveGuildRequest{}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// LeaveGuildHTTPClient calls the LeaveGuildEndpoint
+func LeaveGuildHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			_, ok := request.(LeaveGuildRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/guild/leave"
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response LeaveGuildResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// KickGuildMemberHTTPServer serves the KickGuildMemberEndpoint
+func KickGuildMemberHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.KickGuildMemberEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req KickGuildMemberRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// KickGuildMemberHTTPClient calls the KickGuildMemberEndpoint
+func KickGuildMemberHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			kickGuildMemberReq, ok := request.(KickGuildMemberRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/guild/kick"
+			return encodeRequest(ctx, req, kickGuildMemberReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response KickGuildMemberResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// SetGuildRoleHTTPServer serves the SetGuildRoleEndpoint
+func SetGuildRoleHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.SetGuildRoleEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req SetGuildRoleRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// SetGuildRoleHTTPClient calls the SetGuildRoleEndpoint
+func SetGuildRoleHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			setGuildRoleReq, ok := request.(SetGuildRoleRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/guild/role"
+			return encodeRequest(ctx, req, setGuildRoleReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response SetGuildRoleResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// DepositGuildItemHTTPServer serves the DepositGuildItemEndpoint
+func DepositGuildItemHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.DepositGuildItemEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req DepositGuildItemRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// DepositGuildItemHTTPClient calls the DepositGuildItemEndpoint
+func DepositGuildItemHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			depositGuildItemReq, ok := request.(DepositGuildItemRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/guild/stash/deposit"
+			return encodeRequest(ctx, req, depositGuildItemReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response DepositGuildItemResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// WithdrawGuildItemHTTPServer serves the WithdrawGuildItemEndpoint
+func WithdrawGuildItemHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.WithdrawGuildItemEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req WithdrawGuildItemRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// WithdrawGuildItemHTTPClient calls the WithdrawGuildItemEndpoint
+func WithdrawGuildItemHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			withdrawGuildItemReq, ok := request.(WithdrawGuildItemRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/guild/stash/withdraw"
+			return encodeRequest(ctx, req, withdrawGuildItemReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response WithdrawGuildItemResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// DepositGuildGoldHTTPServer serves the DepositGuildGoldEndpoint
+func DepositGuildGoldHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.DepositGuildGoldEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req DepositGuildGoldRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// DepositGuildGoldHTTPClient calls the DepositGuildGoldEndpoint
+func DepositGuildGoldHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			depositGuildGoldReq, ok := request.(DepositGuildGoldRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/guild/gold/deposit"
+			return encodeRequest(ctx, req, depositGuildGoldReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response DepositGuildGoldResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// WithdrawGuildGoldHTTPServer serves the WithdrawGuildGoldEndpoint
+func WithdrawGuildGoldHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.WithdrawGuildGoldEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req WithdrawGuildGoldRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// WithdrawGuildGoldHTTPClient calls the WithdrawGuildGoldEndpoint
+func WithdrawGuildGoldHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			withdrawGuildGoldReq, ok := request.(WithdrawGuildGoldRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/guild/gold/withdraw"
+			return encodeRequest(ctx, req, withdrawGuildGoldReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response WithdrawGuildGoldResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// CreatePartyHTTPServer serves the CreatePartyEndpoint
+func CreatePartyHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.CreatePartyEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req CreatePartyRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// CreatePartyHTTPClient calls the CreatePartyEndpoint
+func CreatePartyHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			createPartyReq, ok := request.(CreatePartyRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/parties"
+			return encodeRequest(ctx, req, createPartyReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response CreatePartyResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// ViewPartyHTTPServer serves the ViewPartyEndpoint
+func ViewPartyHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.ViewPartyEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			return ViewPartyRequest{}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// ViewPartyHTTPClient calls the ViewPartyEndpoint
+func ViewPartyHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("GET", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			_, ok := request.(ViewPartyRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/party"
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response ViewPartyResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// InviteToPartyHTTPServer serves the InviteToPartyEndpoint
+func InviteToPartyHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.InviteToPartyEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req InviteToPartyRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// InviteToPartyHTTPClient calls the InviteToPartyEndpoint
+func InviteToPartyHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			inviteToPartyReq, ok := request.(InviteToPartyRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/party/invite"
+			return encodeRequest(ctx, req, inviteToPartyReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response InviteToPartyResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// AcceptPartyInviteHTTPServer serves the AcceptPartyInviteEndpoint
+func AcceptPartyInviteHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.AcceptPartyInviteEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			vars := mux.Vars(r)
+			id, ok := vars["id"]
+			if !ok {
+				return nil, ErrBadRouting
+			}
+
+			var req AcceptPartyInviteRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+			req.ID = id
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// AcceptPartyInviteHTTPClient calls the AcceptPartyInviteEndpoint
+func AcceptPartyInviteHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			acceptPartyInviteReq, ok := request.(AcceptPartyInviteRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = fmt.Sprintf("/api/v1/parties/%s/accept", acceptPartyInviteReq.ID)
+			return encodeRequest(ctx, req, acceptPartyInviteReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response AcceptPartyInviteResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// LeavePartyHTTPServer serves the LeavePartyEndpoint
+func LeavePartyHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.LeavePartyEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			return LeavePartyRequest{}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// LeavePartyHTTPClient calls the LeavePartyEndpoint
+func LeavePartyHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			_, ok := request.(LeavePartyRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/party/leave"
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response LeavePartyResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// SetPartyLeaderHTTPServer serves the SetPartyLeaderEndpoint
+func SetPartyLeaderHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.SetPartyLeaderEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req SetPartyLeaderRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// SetPartyLeaderHTTPClient calls the SetPartyLeaderEndpoint
+func SetPartyLeaderHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			setPartyLeaderReq, ok := request.(SetPartyLeaderRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/party/leader"
+			return encodeRequest(ctx, req, setPartyLeaderReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response SetPartyLeaderResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// OpenPartyPortalHTTPServer serves the OpenPartyPortalEndpoint
+func OpenPartyPortalHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.OpenPartyPortalEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req OpenPartyPortalRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// OpenPartyPortalHTTPClient calls the OpenPartyPortalEndpoint
+func OpenPartyPortalHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			openPartyPortalReq, ok := request.(OpenPartyPortalRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/party/portal"
+			return encodeRequest(ctx, req, openPartyPortalReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response OpenPartyPortalResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// JoinMatchmakingHTTPServer serves the JoinMatchmakingEndpoint
+func JoinMatchmakingHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.JoinMatchmakingEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req JoinMatchmakingRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// JoinMatchmakingHTTPClient calls the JoinMatchmakingEndpoint
+func JoinMatchmakingHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			joinMatchmakingReq, ok := request.(JoinMatchmakingRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/matchmaking"
+			return encodeRequest(ctx, req, joinMatchmakingReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response JoinMatchmakingResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// LeaveMatchmakingHTTPServer serves the LeaveMatchmakingEndpoint
+func LeaveMatchmakingHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.LeaveMatchmakingEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			return LeaveMatchmakingRequest{}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// LeaveMatchmakingHTTPClient calls the LeaveMatchmakingEndpoint
+func LeaveMatchmakingHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			_, ok := request.(LeaveMatchmakingRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/matchmaking/leave"
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response LeaveMatchmakingResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// MatchmakingStatusHTTPServer serves the MatchmakingStatusEndpoint
+func MatchmakingStatusHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.MatchmakingStatusEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			return MatchmakingStatusRequest{}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// MatchmakingStatusHTTPClient calls the MatchmakingStatusEndpoint
+func MatchmakingStatusHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("GET", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			_, ok := request.(MatchmakingStatusRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/matchmaking"
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response MatchmakingStatusResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// SendChatMessageHTTPServer serves the SendChatMessageEndpoint
+func SendChatMessageHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.SendChatMessageEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req SendChatMessageRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// SendChatMessageHTTPClient calls the SendChatMessageEndpoint
+func SendChatMessageHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			sendChatMessageReq, ok := request.(SendChatMessageRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/chat/messages"
+			return encodeRequest(ctx, req, sendChatMessageReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response SendChatMessageResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// ChatHistoryHTTPServer serves the ChatHistoryEndpoint
+func ChatHistoryHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.ChatHistoryEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			return decodeChatHistoryRequest(r.URL.Query())
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// ChatHistoryHTTPClient calls the ChatHistoryEndpoint
+func ChatHistoryHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("GET", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			chatHistoryReq, ok := request.(ChatHistoryRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/chat/messages"
+			req.URL.RawQuery = chatHistoryReq.values().Encode()
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response ChatHistoryResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// ChatStreamHTTPServer serves the ChatStreamEndpoint, sending the chat
+// messages as server-sent events
+// There is no client for it, the stream can be read by any SSE client
+func ChatStreamHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.ChatStreamEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			return ChatStreamRequest{
+				ZoneID: r.URL.Query().Get("zone"),
+			}, nil
+		},
+		encodeChatStream,
+		options...,
+	)
+}
+
+// encodeChatStream writes the messages of the stream until the client
+// disconnects
+func encodeChatStream(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	stream, ok := response.(ChatStreamResponse)
+	if !ok {
+		return encodeResponse(ctx, w, response)
+	}
+	defer stream.close()
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return ErrStreamingUnsupported
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(chatKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		case message, ok := <-stream.messages:
+			if !ok {
+				return nil
+			}
+			data, err := json.Marshal(chatMessageDetails(message))
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "id: %s\nevent: message\ndata: %s\n\n", message.ID, data)
+			flusher.Flush()
+		}
+	}
+}
+
+// ChatSettingsHTTPServer serves the ChatSettingsEndpoint
+func ChatSettingsHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.ChatSettingsEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			return ChatSettingsRequest{}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// ChatSettingsHTTPClient calls the ChatSettingsEndpoint
+func ChatSettingsHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("GET", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			_, ok := request.(ChatSettingsRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/chat/settings"
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response ChatSettingsResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// MuteChatUserHTTPServer serves the MuteChatUserEndpoint
+func MuteChatUserHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.MuteChatUserEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req MuteChatUserRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// MuteChatUserHTTPClient calls the MuteChatUserEndpoint
+func MuteChatUserHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			muteChatUserReq, ok := request.(MuteChatUserRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/chat/mute"
+			return encodeRequest(ctx, req, muteChatUserReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response MuteChatUserResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// BlockChatUserHTTPServer serves the BlockChatUserEndpoint
+func BlockChatUserHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.BlockChatUserEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			var req BlockChatUserRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// BlockChatUserHTTPClient calls the BlockChatUserEndpoint
+func BlockChatUserHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			blockChatUserReq, ok := request.(BlockChatUserRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/chat/block"
+			return encodeRequest(ctx, req, blockChatUserReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response BlockChatUserResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// ListClassesHTTPServer serves the ListClassesEndpoint
+func ListClassesHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.ListClassesEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			return ListClassesRequest{}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// ListClassesHTTPClient calls the ListClassesEndpoint
+func ListClassesHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("GET", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			_, ok := request.(ListClassesRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = "/api/v1/classes"
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response ListClassesResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// AllocateAttributesHTTPServer serves the AllocateAttributesEndpoint
+func AllocateAttributesHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.AllocateAttributesEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			vars := mux.Vars(r)
+			id, ok := vars["id"]
+			if !ok {
+				return nil, ErrBadRouting
+			}
+
+			var req AllocateAttributesRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+			req.CharacterID = id
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// AllocateAttributesHTTPClient calls the AllocateAttributesEndpoint
+func AllocateAttributesHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			allocateAttributesReq, ok := request.(AllocateAttributesRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = fmt.Sprintf("/api/v1/characters/%s/attributes", allocateAttributesReq.CharacterID)
+			return encodeRequest(ctx, req, allocateAttributesReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response AllocateAttributesResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// ListTalentsHTTPServer serves the ListTalentsEndpoint
+func ListTalentsHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.ListTalentsEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			vars := mux.Vars(r)
+			id, ok := vars["id"]
+			if !ok {
+				return nil, ErrBadRouting
+			}
+
+			return ListTalentsRequest{
+				CharacterID: id,
+			}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// ListTalentsHTTPClient calls the ListTalentsEndpoint
+func ListTalentsHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("GET", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			listTalentsReq, ok := request.(ListTalentsRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = fmt.Sprintf("/api/v1/characters/%s/talents", listTalentsReq.CharacterID)
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response ListTalentsResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// LearnTalentHTTPServer serves the LearnTalentEndpoint
+func LearnTalentHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.LearnTalentEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			vars := mux.Vars(r)
+			id, ok := vars["id"]
+			if !ok {
+				return nil, ErrBadRouting
+			}
+
+			var req LearnTalentRequest
+			if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+				return nil, e
+			}
+			req.CharacterID = id
+
+			return req, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// LearnTalentHTTPClient calls the LearnTalentEndpoint
+func LearnTalentHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			learnTalentReq, ok := request.(LearnTalentRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = fmt.Sprintf("/api/v1/characters/%s/talents", learnTalentReq.CharacterID)
+			return encodeRequest(ctx, req, learnTalentReq)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response LearnTalentResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
+// RespecCharacterHTTPServer serves the RespecCharacterEndpoint
+func RespecCharacterHTTPServer(endpoints Endpoints, options []httptransport.ServerOption) *httptransport.Server {
+	return httptransport.NewServer(endpoints.RespecCharacterEndpoint,
+		func(_ context.Context, r *http.Request) (request interface{}, err error) {
+			vars := mux.Vars(r)
+			id, ok := vars["id"]
+			if !ok {
+				return nil, ErrBadRouting
+			}
+
+			return RespecCharacterRequest{
+				CharacterID: id,
+			}, nil
+		},
+		encodeResponse,
+		options...,
+	)
+}
+
+// RespecCharacterHTTPClient calls the RespecCharacterEndpoint
+func RespecCharacterHTTPClient(tgt *url.URL, options []httptransport.ClientOption) endpoint.Endpoint {
+	return httptransport.NewClient("POST", tgt,
+		func(ctx context.Context, req *http.Request, request interface{}) error {
+			respecCharacterReq, ok := request.(RespecCharacterRequest)
+			if !ok {
+				panic("Wrong request type")
+			}
+			req.URL.Path = fmt.Sprintf("/api/v1/characters/%s/respec", respecCharacterReq.CharacterID)
+			return encodeRequest(ctx, req, nil)
+		},
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var response RespecCharacterResponse
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			return response, err
+		},
+		options...,
+	).Endpoint()
+}
+
 // encodeRequest likewise JSON-encodes the request to the HTTP request body.
 // Don't use it directly as a transport/http.Client EncodeRequestFunc:
 // profilesvc endpoints require mutating the HTTP method and request path.
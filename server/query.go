@@ -0,0 +1,168 @@
+package server
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// queryInt reads an integer from the query string, missing values are 0
+func queryInt(values url.Values, key string) (int, error) {
+	value := values.Get(key)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+func decodeSearchInventoryRequest(values url.Values) (SearchInventoryRequest, error) {
+	var err error
+	req := SearchInventoryRequest{
+		Kinds:      values["kind"],
+		ZoneID:     values.Get("zone"),
+		SortBy:     values.Get("sort"),
+		Descending: values.Get("order") == "desc",
+	}
+
+	ints := map[string]*int{
+		"min_level":  &req.MinLevel,
+		"max_level":  &req.MaxLevel,
+		"min_damage": &req.MinDamage,
+		"max_damage": &req.MaxDamage,
+		"offset":     &req.Offset,
+		"limit":      &req.Limit,
+	}
+	for key, value := range ints {
+		*value, err = queryInt(values, key)
+		if err != nil {
+			return req, WrongRequestError{Endpoint: "SearchInventory"}
+		}
+	}
+
+	return req, nil
+}
+
+func (r SearchInventoryRequest) values() url.Values {
+	values := url.Values{}
+	for _, kind := range r.Kinds {
+		values.Add("kind", kind)
+	}
+	if r.ZoneID != "" {
+		values.Set("zone", r.ZoneID)
+	}
+	if r.SortBy != "" {
+		values.Set("sort", r.SortBy)
+	}
+	if r.Descending {
+		values.Set("order", "desc")
+	}
+
+	ints := map[string]int{
+		"min_level":  r.MinLevel,
+		"max_level":  r.MaxLevel,
+		"min_damage": r.MinDamage,
+		"max_damage": r.MaxDamage,
+		"offset":     r.Offset,
+		"limit":      r.Limit,
+	}
+	for key, value := range ints {
+		if value != 0 {
+			values.Set(key, strconv.Itoa(value))
+		}
+	}
+
+	return values
+}
+
+func decodePortalHistoryRequest(values url.Values) (PortalHistoryRequest, error) {
+	var err error
+	req := PortalHistoryRequest{}
+
+	req.Offset, err = queryInt(values, "offset")
+	if err != nil {
+		return req, WrongRequestError{Endpoint: "PortalHistory"}
+	}
+	req.Limit, err = queryInt(values, "limit")
+	if err != nil {
+		return req, WrongRequestError{Endpoint: "PortalHistory"}
+	}
+
+	return req, nil
+}
+
+func (r PortalHistoryRequest) values() url.Values {
+	values := url.Values{}
+	if r.Offset != 0 {
+		values.Set("offset", strconv.Itoa(r.Offset))
+	}
+	if r.Limit != 0 {
+		values.Set("limit", strconv.Itoa(r.Limit))
+	}
+	return values
+}
+
+func decodeLeaderboardRequest(kind string, values url.Values) (LeaderboardRequest, error) {
+	var err error
+	req := LeaderboardRequest{
+		Kind:   kind,
+		Period: values.Get("period"),
+		ZoneID: values.Get("zone"),
+	}
+
+	if values.Get("level") != "" {
+		level, err := queryInt(values, "level")
+		if err != nil {
+			return req, WrongRequestError{Endpoint: "Leaderboard"}
+		}
+		req.Level = &level
+	}
+	req.Limit, err = queryInt(values, "limit")
+	if err != nil {
+		return req, WrongRequestError{Endpoint: "Leaderboard"}
+	}
+
+	return req, nil
+}
+
+func (r LeaderboardRequest) values() url.Values {
+	values := url.Values{}
+	if r.Period != "" {
+		values.Set("period", r.Period)
+	}
+	if r.ZoneID != "" {
+		values.Set("zone", r.ZoneID)
+	}
+	if r.Level != nil {
+		values.Set("level", strconv.Itoa(*r.Level))
+	}
+	if r.Limit != 0 {
+		values.Set("limit", strconv.Itoa(r.Limit))
+	}
+	return values
+}
+
+func decodeChatHistoryRequest(values url.Values) (ChatHistoryRequest, error) {
+	var err error
+	req := ChatHistoryRequest{
+		Channel: values.Get("channel"),
+		Target:  values.Get("target"),
+	}
+
+	req.Limit, err = queryInt(values, "limit")
+	if err != nil {
+		return req, WrongRequestError{Endpoint: "ChatHistory"}
+	}
+
+	return req, nil
+}
+
+func (r ChatHistoryRequest) values() url.Values {
+	values := url.Values{}
+	values.Set("channel", r.Channel)
+	if r.Target != "" {
+		values.Set("target", r.Target)
+	}
+	if r.Limit != 0 {
+		values.Set("limit", strconv.Itoa(r.Limit))
+	}
+	return values
+}
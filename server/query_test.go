@@ -0,0 +1,34 @@
+package server
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestDecodeSearchInventoryRequest(t *testing.T) {
+	values, _ := url.ParseQuery("kind=stone&kind=weapon&min_level=3&zone=forest&sort=level&order=desc&limit=10")
+
+	req, err := decodeSearchInventoryRequest(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(req.Kinds) != 2 {
+		t.Error("Expected two kinds, got", req.Kinds)
+	}
+	if req.MinLevel != 3 || req.Limit != 10 {
+		t.Error("Expected min_level 3 and limit 10, got", req.MinLevel, req.Limit)
+	}
+	if !req.Descending || req.SortBy != "level" || req.ZoneID != "forest" {
+		t.Error("Unexpected request", req)
+	}
+
+	encoded := req.values()
+	if encoded.Get("min_level") != "3" || encoded.Get("order") != "desc" {
+		t.Error("Expected values to round trip, got", encoded.Encode())
+	}
+
+	values, _ = url.ParseQuery("min_level=three")
+	if _, err = decodeSearchInventoryRequest(values); err == nil {
+		t.Error("Expected invalid numbers to fail")
+	}
+}
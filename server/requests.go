@@ -8,9 +8,43 @@ type MergeStonesRequest struct {
 	TargetLocation ItemLocation `json:"target"`
 }
 
+// PreviewMergeStonesRequest represents a request for previewing a merge
+type PreviewMergeStonesRequest struct {
+	SourceLocation ItemLocation `json:"source"`
+	TargetLocation ItemLocation `json:"target"`
+}
+
+// MergeAllStonesRequest represents a request for merging every compatible stone
+type MergeAllStonesRequest struct {
+	DryRun bool `json:"dry_run"`
+}
+
+// SplitStackRequest represents a request for splitting a stack of items
+type SplitStackRequest struct {
+	Location ItemLocation `json:"location"`
+	Quantity int          `json:"quantity"`
+}
+
+// MergeStacksRequest represents a request for merging two stacks of items
+type MergeStacksRequest struct {
+	SourceLocation ItemLocation `json:"source"`
+	TargetLocation ItemLocation `json:"target"`
+}
+
+// MoveItemRequest represents a request for moving an item
+type MoveItemRequest struct {
+	From ItemLocation `json:"from"`
+	To   ItemLocation `json:"to"`
+}
+
 // OpenPortalRequest holds the parameters for the new portal
 type OpenPortalRequest struct {
 	StoneLocation *ItemLocation `json:"stone_location"`
+	// Seed replays the layout of another portal, a random one is used when
+	// it's not given
+	Seed int64 `json:"seed,omitempty"`
+	// Guild opens a portal every member of the guild can enter
+	Guild bool `json:"guild,omitempty"`
 }
 
 // ViewPortalRequest represents a request for viewing a portal
@@ -22,6 +56,25 @@ type ViewPortalRequest struct {
 type ListPortalsRequest struct {
 }
 
+// PortalHistoryRequest represents a request for listing the portal runs
+type PortalHistoryRequest struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+// PortalReplayRequest represents a request for the event log of a portal
+// When Step is set, the state of the portal after that many events is
+// included
+type PortalReplayRequest struct {
+	PortalID string `json:"portal_id"`
+	Step     int    `json:"step"`
+}
+
+// ViewPortalRunRequest represents a request for viewing a portal run
+type ViewPortalRunRequest struct {
+	ID string `json:"id"`
+}
+
 // ListZonesRequest holds the parameters for listing zones
 type ListZonesRequest struct{}
 
@@ -32,7 +85,9 @@ type ExplorePortalRequest struct {
 }
 
 // SpawnCharacterRequest represents a request for spawning a character
+// The default class is used when Class is empty
 type SpawnCharacterRequest struct {
+	Class string `json:"class,omitempty"`
 }
 
 // AuthenticateRequest holds the credentials for authentication
@@ -51,12 +106,49 @@ type TakeCharacterItemRequest struct {
 	ItemLocation ItemLocation `json:"location"`
 }
 
+// EquipCharacterItemRequest represents a request for equipping an item from the character inventory
+type EquipCharacterItemRequest struct {
+	CharacterID  string       `json:"id"`
+	ItemLocation ItemLocation `json:"location"`
+}
+
+// UseItemRequest represents a request for using an item from the character inventory
+type UseItemRequest struct {
+	CharacterID  string       `json:"id"`
+	ItemLocation ItemLocation `json:"location"`
+}
+
 // DropCharacterItemRequest represents a request for dropping an item from the character inventory
 type DropCharacterItemRequest struct {
 	CharacterID  string       `json:"id"`
 	ItemLocation ItemLocation `json:"location"`
 }
 
+// AllocateAttributesRequest represents a request for spending attribute points
+type AllocateAttributesRequest struct {
+	CharacterID string `json:"character_id"`
+	Strength    int    `json:"strength"`
+	Agility     int    `json:"agility"`
+	Vitality    int    `json:"vitality"`
+}
+
+// ListTalentsRequest represents a request for listing the talents of a character
+type ListTalentsRequest struct {
+	CharacterID string `json:"character_id"`
+}
+
+// LearnTalentRequest represents a request for spending a talent point
+type LearnTalentRequest struct {
+	CharacterID string `json:"character_id"`
+	TalentID    string `json:"talent_id"`
+}
+
+// RespecCharacterRequest represents a request for refunding the attribute
+// and talent points of a character
+type RespecCharacterRequest struct {
+	CharacterID string `json:"character_id"`
+}
+
 // ViewCharacterInventoryRequest represents a request for viewing the character inventory
 type ViewCharacterInventoryRequest struct {
 	CharacterID string `json:"character_id"`
@@ -70,3 +162,309 @@ type ListCharactersRequest struct {
 type ViewCharacterRequest struct {
 	ID string `json:"id"`
 }
+
+// BuyBagRequest represents a request for buying a bag
+type BuyBagRequest struct {
+	Kind string `json:"kind"`
+}
+
+// AttachBagRequest represents a request for attaching a bag item to the user
+// or to a character
+type AttachBagRequest struct {
+	Location    ItemLocation `json:"location"`
+	CharacterID string       `json:"character_id,omitempty"`
+}
+
+// UpgradeBagRequest represents a request for expanding a bag
+type UpgradeBagRequest struct {
+	CharacterID string `json:"character_id,omitempty"`
+	BagID       int    `json:"bag_id"`
+}
+
+// SearchInventoryRequest represents a request for searching the user inventory
+type SearchInventoryRequest struct {
+	Kinds      []string `json:"kinds,omitempty"`
+	MinLevel   int      `json:"min_level,omitempty"`
+	MaxLevel   int      `json:"max_level,omitempty"`
+	ZoneID     string   `json:"zone_id,omitempty"`
+	MinDamage  int      `json:"min_damage,omitempty"`
+	MaxDamage  int      `json:"max_damage,omitempty"`
+	SortBy     string   `json:"sort,omitempty"`
+	Descending bool     `json:"desc,omitempty"`
+	Offset     int      `json:"offset,omitempty"`
+	Limit      int      `json:"limit,omitempty"`
+}
+
+// SortBagRequest represents a request for sorting a bag
+type SortBagRequest struct {
+	CharacterID string `json:"character_id,omitempty"`
+	BagID       int    `json:"bag_id"`
+}
+
+// ViewShopRequest represents a request for viewing the vendor of a zone
+type ViewShopRequest struct {
+	ZoneID string `json:"zone,omitempty"`
+}
+
+// BuyShopItemRequest represents a request for buying an item from a vendor
+type BuyShopItemRequest struct {
+	ZoneID string `json:"zone,omitempty"`
+	ItemID string `json:"item"`
+}
+
+// SellItemRequest represents a request for selling an item to a vendor
+type SellItemRequest struct {
+	Location ItemLocation `json:"location"`
+}
+
+// OpenTradeRequest represents a request for trading with another user
+type OpenTradeRequest struct {
+	Username string `json:"username"`
+}
+
+// ListTradesRequest represents a request for listing the trades of the user
+type ListTradesRequest struct{}
+
+// ViewTradeRequest represents a request for viewing a trade
+type ViewTradeRequest struct {
+	ID string `json:"id"`
+}
+
+// SetTradeOfferRequest represents a request for changing the offer of the user
+type SetTradeOfferRequest struct {
+	ID    string         `json:"id"`
+	Items []ItemLocation `json:"items"`
+	Gold  int            `json:"gold"`
+}
+
+// ConfirmTradeRequest represents a request for confirming a trade
+type ConfirmTradeRequest struct {
+	ID string `json:"id"`
+}
+
+// CancelTradeRequest represents a request for cancelling a trade
+type CancelTradeRequest struct {
+	ID string `json:"id"`
+}
+
+// CreateAuctionRequest represents a request for listing an item on the auction house
+type CreateAuctionRequest struct {
+	Location    ItemLocation `json:"location"`
+	StartingBid int          `json:"starting_bid,omitempty"`
+	Buyout      int          `json:"buyout,omitempty"`
+	// Duration is in seconds
+	Duration int `json:"duration"`
+}
+
+// SearchAuctionsRequest represents a request for searching auctions
+// It uses the same filters as the inventory search
+type SearchAuctionsRequest struct {
+	SearchInventoryRequest
+}
+
+// ViewAuctionRequest represents a request for viewing an auction
+type ViewAuctionRequest struct {
+	ID string `json:"id"`
+}
+
+// BidAuctionRequest represents a request for bidding on an auction
+type BidAuctionRequest struct {
+	ID     string `json:"id"`
+	Amount int    `json:"amount"`
+}
+
+// BuyoutAuctionRequest represents a request for buying an auction right away
+type BuyoutAuctionRequest struct {
+	ID string `json:"id"`
+}
+
+// ClaimAuctionItemsRequest represents a request for taking the items won
+// or returned that didn't fit on the user bags
+type ClaimAuctionItemsRequest struct{}
+
+// ListClassesRequest represents a request for listing the character classes
+type ListClassesRequest struct{}
+
+// ListRecipesRequest represents a request for listing the crafting recipes
+type ListRecipesRequest struct{}
+
+// CraftRequest represents a request for crafting a recipe
+type CraftRequest struct {
+	RecipeID string `json:"recipe"`
+	// Location is the item used by the recipe, if it needs one
+	Location *ItemLocation `json:"location,omitempty"`
+}
+
+// LeaderboardRequest represents a request for viewing a leaderboard
+// Level is the stone level of the runs, every level is included when nil
+type LeaderboardRequest struct {
+	Kind   string `json:"kind"`
+	Period string `json:"period"`
+	ZoneID string `json:"zone"`
+	Level  *int   `json:"level"`
+	Limit  int    `json:"limit"`
+}
+
+// ListAchievementsRequest represents a request for listing the achievements
+type ListAchievementsRequest struct{}
+
+// ClaimAchievementRequest represents a request for claiming the reward of
+// an achievement
+type ClaimAchievementRequest struct {
+	ID string `json:"id"`
+}
+
+// ListQuestsRequest represents a request for listing the quests of the
+// current rotations
+type ListQuestsRequest struct{}
+
+// AcceptQuestRequest represents a request for accepting a quest
+type AcceptQuestRequest struct {
+	ID string `json:"id"`
+}
+
+// ClaimQuestRequest represents a request for claiming the reward of a quest
+type ClaimQuestRequest struct {
+	ID string `json:"id"`
+}
+
+// CreateGuildRequest represents a request for creating a guild
+type CreateGuildRequest struct {
+	Name string `json:"name"`
+}
+
+// ViewGuildRequest represents a request for viewing the guild of the user
+type ViewGuildRequest struct{}
+
+// InviteToGuildRequest represents a request for inviting a user to the guild
+type InviteToGuildRequest struct {
+	Username string `json:"username"`
+}
+
+// JoinGuildRequest represents a request for joining a guild
+type JoinGuildRequest struct {
+	ID string `json:"id"`
+}
+
+// LeaveGuildRequest represents a request for leaving the guild
+type LeaveGuildRequest struct{}
+
+// KickGuildMemberRequest represents a request for removing a member from the
+// guild
+type KickGuildMemberRequest struct {
+	Username string `json:"username"`
+}
+
+// SetGuildRoleRequest represents a request for changing the role of a member
+type SetGuildRoleRequest struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+// DepositGuildItemRequest represents a request for moving an item to the
+// guild stash
+type DepositGuildItemRequest struct {
+	Location ItemLocation `json:"location"`
+}
+
+// WithdrawGuildItemRequest represents a request for taking an item from the
+// guild stash
+type WithdrawGuildItemRequest struct {
+	Location ItemLocation `json:"location"`
+}
+
+// DepositGuildGoldRequest represents a request for moving gold to the guild
+// bank
+type DepositGuildGoldRequest struct {
+	Amount int `json:"amount"`
+}
+
+// WithdrawGuildGoldRequest represents a request for taking gold from the
+// guild bank
+type WithdrawGuildGoldRequest struct {
+	Amount int `json:"amount"`
+}
+
+// CreatePartyRequest represents a request for creating a party
+type CreatePartyRequest struct {
+	CharacterID string `json:"character_id"`
+}
+
+// ViewPartyRequest represents a request for viewing the party of the user
+type ViewPartyRequest struct{}
+
+// InviteToPartyRequest represents a request for inviting a user to the party
+type InviteToPartyRequest struct {
+	Username string `json:"username"`
+}
+
+// AcceptPartyInviteRequest represents a request for joining a party
+type AcceptPartyInviteRequest struct {
+	ID          string `json:"id"`
+	CharacterID string `json:"character_id"`
+}
+
+// LeavePartyRequest represents a request for leaving the party
+type LeavePartyRequest struct{}
+
+// SetPartyLeaderRequest represents a request for passing the lead of the
+// party
+type SetPartyLeaderRequest struct {
+	Username string `json:"username"`
+}
+
+// OpenPartyPortalRequest represents a request for opening a portal for the
+// whole party
+type OpenPartyPortalRequest struct {
+	StoneLocation *ItemLocation `json:"stone_location"`
+}
+
+// JoinMatchmakingRequest represents a request for joining a matchmaking queue
+type JoinMatchmakingRequest struct {
+	CharacterID string `json:"character_id"`
+	ZoneID      string `json:"zone_id"`
+	Level       int    `json:"level"`
+}
+
+// LeaveMatchmakingRequest represents a request for leaving the matchmaking
+type LeaveMatchmakingRequest struct{}
+
+// MatchmakingStatusRequest represents a request for viewing the matchmaking
+// status of the user
+type MatchmakingStatusRequest struct{}
+
+// SendChatMessageRequest represents a request for sending a chat message
+// Target is the zone ID for zone messages and the username for whispers
+type SendChatMessageRequest struct {
+	Channel string `json:"channel"`
+	Target  string `json:"target,omitempty"`
+	Text    string `json:"text"`
+}
+
+// ChatHistoryRequest represents a request for the last messages of a channel
+type ChatHistoryRequest struct {
+	Channel string `json:"channel"`
+	Target  string `json:"target,omitempty"`
+	Limit   int    `json:"limit,omitempty"`
+}
+
+// ChatStreamRequest represents a request for receiving the chat messages,
+// along with the zone messages of a zone
+type ChatStreamRequest struct {
+	ZoneID string `json:"zone_id,omitempty"`
+}
+
+// ChatSettingsRequest represents a request for the chat settings of the user
+type ChatSettingsRequest struct{}
+
+// MuteChatUserRequest represents a request for muting or unmuting a user
+type MuteChatUserRequest struct {
+	Username string `json:"username"`
+	Muted    bool   `json:"muted"`
+}
+
+// BlockChatUserRequest represents a request for blocking or unblocking a user
+type BlockChatUserRequest struct {
+	Username string `json:"username"`
+	Blocked  bool   `json:"blocked"`
+}
@@ -1,10 +1,22 @@
 package server
 
+import svc "github.com/grilix/sworld/sworldservice"
+
 // TakeCharacterItemResponse represents a response after dropping an item from the character inventory
 type TakeCharacterItemResponse struct {
 	// TODO: what to respond here?
 }
 
+// EquipCharacterItemResponse represents a response after equipping an item
+type EquipCharacterItemResponse struct {
+	Character *CharacterDetails `json:"character,omitempty"`
+}
+
+// UseItemResponse represents a response after using an item
+type UseItemResponse struct {
+	Character *CharacterDetails `json:"character,omitempty"`
+}
+
 // DropCharacterItemResponse represents a response after dropping an item from the character inventory
 type DropCharacterItemResponse struct {
 	// TODO: what to respond here?
@@ -44,6 +56,30 @@ type SpawnCharacterResponse struct {
 	Character *CharacterDetails `json:"character"`
 }
 
+// AllocateAttributesResponse represents a response for spending attribute points
+type AllocateAttributesResponse struct {
+	Character *CharacterDetails `json:"character"`
+}
+
+// ListTalentsResponse represents a response with the talents of a character
+type ListTalentsResponse struct {
+	TalentPoints int              `json:"talent_points"`
+	Talents      []*TalentDetails `json:"talents"`
+}
+
+// LearnTalentResponse represents a response for spending a talent point
+type LearnTalentResponse struct {
+	Character *CharacterDetails `json:"character"`
+}
+
+// RespecCharacterResponse represents a response for refunding the points of
+// a character
+type RespecCharacterResponse struct {
+	Character *CharacterDetails `json:"character"`
+	// Gold is the gold the user has left
+	Gold int `json:"gold"`
+}
+
 // EmptyResponse represents a response that has no information to show
 // FIXME: This actually sounds weird, can't we just use nil instead?
 type EmptyResponse struct{}
@@ -53,6 +89,42 @@ type MergeStonesResponse struct {
 	ResultLocation ItemLocation `json:"location"`
 }
 
+// PreviewMergeStonesResponse represents the stone a merge would create
+// Error is set when the stones can't be merged
+type PreviewMergeStonesResponse struct {
+	Stone *StoneDetails `json:"stone,omitempty"`
+	Error string        `json:"error,omitempty"`
+}
+
+// StoneMergeDetails represents a single merge of a batch
+type StoneMergeDetails struct {
+	Source ItemLocation  `json:"source"`
+	Target ItemLocation  `json:"target"`
+	Result ItemLocation  `json:"result"`
+	Stone  *StoneDetails `json:"stone"`
+}
+
+// MergeAllStonesResponse represents the merges done by a batch
+type MergeAllStonesResponse struct {
+	Merges []*StoneMergeDetails `json:"merges"`
+	DryRun bool                 `json:"dry_run,omitempty"`
+}
+
+// SplitStackResponse represents the response of splitting a stack
+type SplitStackResponse struct {
+	Location ItemLocation `json:"location"`
+}
+
+// MergeStacksResponse represents the response of merging two stacks
+type MergeStacksResponse struct {
+	// TODO: what to respond here?
+}
+
+// MoveItemResponse represents the response of moving an item
+type MoveItemResponse struct {
+	// TODO: what to respond here?
+}
+
 // OpenPortalResponse holds the result of creating a portal
 type OpenPortalResponse struct {
 	Portal *PortalDetails `json:"portal,omitempty"`
@@ -69,6 +141,24 @@ type ListPortalsResponse struct {
 	Portals []*PortalDetails `json:"portals"`
 }
 
+// PortalReplayResponse represents a response with the event log of a portal
+type PortalReplayResponse struct {
+	PortalID string                `json:"portal_id"`
+	Events   []*ReplayEventDetails `json:"events"`
+	Frame    *ReplayFrameDetails   `json:"frame,omitempty"`
+}
+
+// PortalHistoryResponse represents a response with the portal runs
+type PortalHistoryResponse struct {
+	Runs  []*PortalRunDetails `json:"runs"`
+	Total int                 `json:"total"`
+}
+
+// ViewPortalRunResponse represents a response with the summary of a run
+type ViewPortalRunResponse struct {
+	Run *PortalRunDetails `json:"run"`
+}
+
 // ZoneInformationResponse holds information about a zone
 type ZoneInformationResponse struct {
 	ID   string `json:"id"`
@@ -88,3 +178,292 @@ type ListZonesResponse struct {
 type ExplorePortalResponse struct {
 	//Error string `json:"error,omitempty"`
 }
+
+// BuyBagResponse represents the response of buying a bag
+type BuyBagResponse struct {
+	Location ItemLocation `json:"location"`
+}
+
+// AttachBagResponse represents the response of attaching a bag
+type AttachBagResponse struct {
+	Bags []*BagDetails `json:"bags"`
+}
+
+// UpgradeBagResponse represents the response of expanding a bag
+type UpgradeBagResponse struct {
+	Bag *BagDetails `json:"bag,omitempty"`
+}
+
+// InventoryItemDetails represents an item found on the inventory
+type InventoryItemDetails struct {
+	Location ItemLocation    `json:"location"`
+	Item     *BagSlotDetails `json:"item"`
+}
+
+// SearchInventoryResponse represents a response with the items found
+type SearchInventoryResponse struct {
+	Items []*InventoryItemDetails `json:"items"`
+	Total int                     `json:"total"`
+}
+
+// SortBagResponse represents the response of sorting a bag
+type SortBagResponse struct {
+	Bag *BagDetails `json:"bag,omitempty"`
+}
+
+// ShopItemDetails represents an item on sale
+type ShopItemDetails struct {
+	ID    string          `json:"id"`
+	Price int             `json:"price"`
+	Stock int             `json:"stock"`
+	Item  *BagSlotDetails `json:"item"`
+}
+
+// SellOfferDetails represents what the vendor pays for an item of the user
+type SellOfferDetails struct {
+	Location ItemLocation    `json:"location"`
+	Price    int             `json:"price"`
+	Item     *BagSlotDetails `json:"item"`
+}
+
+// ViewShopResponse represents a response with the vendor stock
+type ViewShopResponse struct {
+	Zone       ZoneDetails         `json:"zone"`
+	Items      []*ShopItemDetails  `json:"items"`
+	RotatesAt  string              `json:"rotates_at"`
+	SellOffers []*SellOfferDetails `json:"sell_offers"`
+	Gold       int                 `json:"gold"`
+}
+
+// BuyShopItemResponse represents the response of buying an item
+type BuyShopItemResponse struct {
+	Location ItemLocation `json:"location"`
+	Gold     int          `json:"gold"`
+}
+
+// SellItemResponse represents the response of selling an item
+type SellItemResponse struct {
+	Price int `json:"price"`
+	Gold  int `json:"gold"`
+}
+
+// TradeResponse represents a response with a trade
+type TradeResponse struct {
+	Trade *TradeDetails `json:"trade,omitempty"`
+}
+
+// ListTradesResponse represents a response with the trades of the user
+type ListTradesResponse struct {
+	Trades []*TradeDetails `json:"trades"`
+}
+
+// AuctionResponse represents a response with an auction
+type AuctionResponse struct {
+	Auction *AuctionDetails `json:"auction,omitempty"`
+}
+
+// SearchAuctionsResponse represents a response with the auctions found
+type SearchAuctionsResponse struct {
+	Auctions []*AuctionDetails `json:"auctions"`
+	Total    int               `json:"total"`
+}
+
+// ClaimAuctionItemsResponse represents the response of claiming auction items
+type ClaimAuctionItemsResponse struct {
+	Locations []ItemLocation `json:"locations"`
+	// Pending is the amount of items that still don't fit
+	Pending int `json:"pending"`
+}
+
+// ListClassesResponse represents a response with the character classes
+type ListClassesResponse struct {
+	Classes []*ClassDetails `json:"classes"`
+}
+
+// ListRecipesResponse represents a response with the crafting recipes
+type ListRecipesResponse struct {
+	Recipes []*RecipeDetails `json:"recipes"`
+}
+
+// CraftResponse represents the response of crafting a recipe
+type CraftResponse struct {
+	Locations []ItemLocation `json:"locations"`
+}
+
+// LeaderboardResponse represents a response with a leaderboard
+type LeaderboardResponse struct {
+	Kind    string                     `json:"kind"`
+	Period  string                     `json:"period"`
+	ZoneID  string                     `json:"zone,omitempty"`
+	Level   *int                       `json:"level,omitempty"`
+	Entries []*LeaderboardEntryDetails `json:"entries"`
+}
+
+// ListAchievementsResponse represents a response with the achievements of
+// the user
+type ListAchievementsResponse struct {
+	Achievements []*AchievementDetails `json:"achievements"`
+	Titles       []string              `json:"titles"`
+}
+
+// ClaimAchievementResponse represents the response of claiming a reward
+type ClaimAchievementResponse struct {
+	Achievement *AchievementDetails `json:"achievement"`
+	Gold        int                 `json:"gold"`
+	Titles      []string            `json:"titles"`
+}
+
+// ListQuestsResponse represents a response with the quests of the user
+type ListQuestsResponse struct {
+	Quests []*QuestDetails `json:"quests"`
+}
+
+// AcceptQuestResponse represents the response of accepting a quest
+type AcceptQuestResponse struct {
+	Quest *QuestDetails `json:"quest"`
+}
+
+// ClaimQuestResponse represents the response of claiming a quest
+type ClaimQuestResponse struct {
+	Quest *QuestDetails `json:"quest"`
+	Gold  int           `json:"gold"`
+}
+
+// CreateGuildResponse represents the response of creating a guild
+type CreateGuildResponse struct {
+	Guild *GuildDetails `json:"guild"`
+	Gold  int           `json:"gold"`
+}
+
+// ViewGuildResponse represents a response with the guild of the user
+type ViewGuildResponse struct {
+	Guild *GuildDetails `json:"guild"`
+}
+
+// InviteToGuildResponse represents the response of inviting a user
+type InviteToGuildResponse struct {
+	Guild *GuildDetails `json:"guild"`
+}
+
+// JoinGuildResponse represents the response of joining a guild
+type JoinGuildResponse struct {
+	Guild *GuildDetails `json:"guild"`
+}
+
+// LeaveGuildResponse represents the response of leaving the guild
+type LeaveGuildResponse struct{}
+
+// KickGuildMemberResponse represents the response of removing a member
+type KickGuildMemberResponse struct {
+	Guild *GuildDetails `json:"guild"`
+}
+
+// SetGuildRoleResponse represents the response of changing a role
+type SetGuildRoleResponse struct {
+	Guild *GuildDetails `json:"guild"`
+}
+
+// DepositGuildItemResponse represents the response of moving an item to the
+// guild stash
+type DepositGuildItemResponse struct {
+	Location ItemLocation `json:"location"`
+}
+
+// WithdrawGuildItemResponse represents the response of taking an item from
+// the guild stash
+type WithdrawGuildItemResponse struct {
+	Location ItemLocation `json:"location"`
+}
+
+// DepositGuildGoldResponse represents the response of moving gold to the
+// guild bank
+type DepositGuildGoldResponse struct {
+	Gold      int `json:"gold"`
+	GuildGold int `json:"guild_gold"`
+}
+
+// WithdrawGuildGoldResponse represents the response of taking gold from the
+// guild bank
+type WithdrawGuildGoldResponse struct {
+	Gold      int `json:"gold"`
+	GuildGold int `json:"guild_gold"`
+}
+
+// CreatePartyResponse represents the response of creating a party
+type CreatePartyResponse struct {
+	Party *PartyDetails `json:"party"`
+}
+
+// ViewPartyResponse represents a response with the party of the user
+type ViewPartyResponse struct {
+	Party *PartyDetails `json:"party"`
+}
+
+// InviteToPartyResponse represents the response of inviting a user
+type InviteToPartyResponse struct {
+	Party *PartyDetails `json:"party"`
+}
+
+// AcceptPartyInviteResponse represents the response of joining a party
+type AcceptPartyInviteResponse struct {
+	Party *PartyDetails `json:"party"`
+}
+
+// LeavePartyResponse represents the response of leaving the party
+type LeavePartyResponse struct{}
+
+// SetPartyLeaderResponse represents the response of passing the lead
+type SetPartyLeaderResponse struct {
+	Party *PartyDetails `json:"party"`
+}
+
+// OpenPartyPortalResponse represents the response of opening a portal for
+// the party
+type OpenPartyPortalResponse struct {
+	Portal *PortalDetails `json:"portal"`
+}
+
+// JoinMatchmakingResponse represents the response of joining a queue
+type JoinMatchmakingResponse struct {
+	Status *MatchmakingDetails `json:"status"`
+}
+
+// LeaveMatchmakingResponse represents the response of leaving the queue
+type LeaveMatchmakingResponse struct{}
+
+// MatchmakingStatusResponse represents a response with the matchmaking
+// status of the user
+type MatchmakingStatusResponse struct {
+	Status *MatchmakingDetails `json:"status"`
+}
+
+// SendChatMessageResponse represents the response of sending a message
+type SendChatMessageResponse struct {
+	Message *ChatMessageDetails `json:"message"`
+}
+
+// ChatHistoryResponse represents a response with the messages of a channel
+type ChatHistoryResponse struct {
+	Messages []*ChatMessageDetails `json:"messages"`
+}
+
+// ChatStreamResponse holds the subscription streamed to the client
+type ChatStreamResponse struct {
+	messages <-chan *svc.ChatMessage
+	close    func()
+}
+
+// ChatSettingsResponse represents a response with the chat settings
+type ChatSettingsResponse struct {
+	Settings *ChatSettingsDetails `json:"settings"`
+}
+
+// MuteChatUserResponse represents the response of muting a user
+type MuteChatUserResponse struct {
+	Settings *ChatSettingsDetails `json:"settings"`
+}
+
+// BlockChatUserResponse represents the response of blocking a user
+type BlockChatUserResponse struct {
+	Settings *ChatSettingsDetails `json:"settings"`
+}
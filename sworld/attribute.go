@@ -0,0 +1,108 @@
+package sworld
+
+import (
+	"errors"
+)
+
+var (
+	// ErrNotEnoughPoints is when the character doesn't have enough points to spend
+	ErrNotEnoughPoints = errors.New("Not enough points")
+	// ErrInvalidPoints is when trying to allocate a negative amount of points
+	ErrInvalidPoints = errors.New("The amount of points is not valid")
+)
+
+// TODO: this should be on settings
+const (
+	// attributePointsPerLevel are the points gained on each level up
+	attributePointsPerLevel = 3
+	// strengthDamage is the damage given by each point of strength
+	strengthDamage = 2
+	// agilityCooldown is the cooldown reduction (in percent) given by each
+	// point of agility
+	agilityCooldown = 1
+	// vitalityHealth is the max health given by each point of vitality
+	vitalityHealth = 10
+	// maxCooldownReduction and maxMitigation are the caps (in percent)
+	maxCooldownReduction = 50
+	maxMitigation        = 75
+)
+
+// Attributes are the primary attributes of a character
+type Attributes struct {
+	Strength int
+	Agility  int
+	Vitality int
+}
+
+// total returns the sum of the attributes
+func (a Attributes) total() int {
+	return a.Strength + a.Agility + a.Vitality
+}
+
+// AllocateAttributes spends attribute points
+func (c *Character) AllocateAttributes(points Attributes) error {
+	if c.Exploring {
+		return ErrCharacterBusy
+	}
+	if points.Strength < 0 || points.Agility < 0 || points.Vitality < 0 || points.total() == 0 {
+		return ErrInvalidPoints
+	}
+	if points.total() > c.AttributePoints {
+		return ErrNotEnoughPoints
+	}
+
+	c.AttributePoints -= points.total()
+	c.Attributes.Strength += points.Strength
+	c.Attributes.Agility += points.Agility
+	c.Attributes.Vitality += points.Vitality
+	c.updateMaxHealth()
+
+	return nil
+}
+
+// updateMaxHealth recalculates the max health from the class, attributes
+// and talents
+// Health is only lowered when it goes over the new max health
+func (c *Character) updateMaxHealth() {
+	health := classStats(c.Class).MaxHealth(c.Level) + (c.Attributes.Vitality * vitalityHealth)
+	c.MaxHealth = health + ((health * c.talentBonus().Health) / 100)
+	if c.Health > c.MaxHealth {
+		c.Health = c.MaxHealth
+	}
+}
+
+// CooldownReduction returns the cooldown reduction (in percent) of the
+// character skills
+func (c Character) CooldownReduction() int {
+	reduction := (c.Attributes.Agility * agilityCooldown) + c.talentBonus().Cooldown
+	if reduction > maxCooldownReduction {
+		return maxCooldownReduction
+	}
+	return reduction
+}
+
+// Mitigation returns the percentage of the damage received that is prevented
+func (c Character) Mitigation() int {
+	mitigation := c.talentBonus().Mitigation
+	if mitigation > maxMitigation {
+		return maxMitigation
+	}
+	return mitigation
+}
+
+// Respec refunds every attribute and talent point
+func (c *Character) Respec() error {
+	if c.Exploring {
+		return ErrCharacterBusy
+	}
+
+	c.AttributePoints += c.Attributes.total()
+	c.Attributes = Attributes{}
+	for _, rank := range c.Talents {
+		c.TalentPoints += rank
+	}
+	c.Talents = make(map[string]int)
+	c.updateMaxHealth()
+
+	return nil
+}
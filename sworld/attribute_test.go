@@ -0,0 +1,79 @@
+package sworld
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAllocateAttributes(t *testing.T) {
+	char := NewCharacter(WarriorClass)
+	stats, _ := FindClass("warrior")
+	char.AttributePoints = 3
+
+	if err := char.AllocateAttributes(Attributes{Strength: 2, Vitality: 2}); err != ErrNotEnoughPoints {
+		t.Error("Expected not enough points, got", err)
+	}
+	if err := char.AllocateAttributes(Attributes{Strength: -1, Vitality: 2}); err != ErrInvalidPoints {
+		t.Error("Expected invalid points, got", err)
+	}
+	if err := char.AllocateAttributes(Attributes{Strength: 1, Agility: 1, Vitality: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if char.AttributePoints != 0 {
+		t.Error("Expected every point to be spent, got", char.AttributePoints)
+	}
+	if char.Damage() != stats.Damage+strengthDamage {
+		t.Error("Expected strength to add damage, got", char.Damage())
+	}
+	if char.MaxHealth != stats.Health+vitalityHealth {
+		t.Error("Expected vitality to add max health, got", char.MaxHealth)
+	}
+	if char.CooldownReduction() != agilityCooldown {
+		t.Error("Expected agility to reduce cooldowns, got", char.CooldownReduction())
+	}
+}
+
+func TestCooldownReduction(t *testing.T) {
+	char := NewCharacter(WarriorClass)
+	char.Attributes.Agility = 80
+
+	if char.CooldownReduction() != maxCooldownReduction {
+		t.Fatal("Expected the cooldown reduction to be capped, got", char.CooldownReduction())
+	}
+
+	skill := char.Skills[0].(*HitSkill)
+	skill.lastUse = time.Now()
+	if skill.WaitTime() > skill.cooldown/2 {
+		t.Error("Expected the cooldown to be halved, got", skill.WaitTime())
+	}
+}
+
+func TestRespec(t *testing.T) {
+	char := NewCharacter(WarriorClass)
+	stats, _ := FindClass("warrior")
+	char.AttributePoints = 3
+	char.TalentPoints = 2
+	char.AllocateAttributes(Attributes{Vitality: 3})
+	char.LearnTalent("might")
+	char.LearnTalent("might")
+	char.Health = char.MaxHealth
+
+	if err := char.Respec(); err != nil {
+		t.Fatal(err)
+	}
+	if char.AttributePoints != 3 || char.TalentPoints != 2 {
+		t.Error("Expected every point to be refunded, got", char.AttributePoints, char.TalentPoints)
+	}
+	if char.Attributes.total() != 0 || len(char.Talents) != 0 {
+		t.Error("Expected attributes and talents to be reset, got", char.Attributes, char.Talents)
+	}
+	if char.MaxHealth != stats.Health || char.Health != stats.Health {
+		t.Error("Expected health to go back to", stats.Health, "got", char.Health, char.MaxHealth)
+	}
+
+	char.Exploring = true
+	if err := char.Respec(); err != ErrCharacterBusy {
+		t.Error("Expected a busy character to not respec, got", err)
+	}
+}
@@ -26,18 +26,25 @@ type Item interface {
 // Bag is the interface for a bag
 // A bag is used to hold items either by a character or a user
 type Bag interface {
+	Kind() string
+	Capacity() int
+	Accepts(item Item) bool
 	StoreItem(item Item, slot int) error
 	GetItem(slot int) (Item, error)
 	FindEmptySlot(item Item) (int, error)
 	DropItem(slot int) (Item, error)
+	SplitStack(slot int, count int) (Item, error)
 	Items() []Item
+	Sort()
 	Empty()
 }
 
 // ItemLocation represents the location of an item
+// An empty CharacterID means the item is on the user bags
 type ItemLocation struct {
-	BagID int
-	Slot  int
+	CharacterID string
+	BagID       int
+	Slot        int
 }
 
 // StandardBag is the main bag type
@@ -45,11 +52,6 @@ type StandardBag struct {
 	items []Item
 }
 
-// Weapon represents a weapon item
-type Weapon struct {
-	Damage int
-}
-
 // NewStandardBag creates a standard bag of a given capacity
 func NewStandardBag(capacity int) *StandardBag {
 	return &StandardBag{
@@ -59,15 +61,30 @@ func NewStandardBag(capacity int) *StandardBag {
 
 // SameAs compares the ItemLocation to another
 func (i ItemLocation) SameAs(location ItemLocation) bool {
-	if i.BagID == location.BagID {
+	if i.CharacterID == location.CharacterID && i.BagID == location.BagID {
 		return i.Slot == location.Slot
 	}
 	return false
 }
 
+// Kind returns the kind of the bag
+func (b StandardBag) Kind() string {
+	return StandardBagKind
+}
+
+// Capacity returns the amount of slots of the bag
+func (b StandardBag) Capacity() int {
+	return len(b.items)
+}
+
+// Accepts returns true if the bag can hold the item
+func (b StandardBag) Accepts(item Item) bool {
+	return true
+}
+
 // GetItem returns the item that is at a given slot
 func (b StandardBag) GetItem(slot int) (Item, error) {
-	if slot >= len(b.items) {
+	if slot < 0 || slot >= len(b.items) {
 		return nil, ErrInvalidBagSlot
 	}
 	item := b.items[slot]
@@ -105,7 +122,13 @@ func (b StandardBag) Items() []Item {
 }
 
 // FindEmptySlot returns a slot available for a given item
+// Stacks that can hold the item are preferred over empty slots
 func (b StandardBag) FindEmptySlot(item Item) (int, error) {
+	for slot := range b.items {
+		if b.items[slot] != nil && canStack(b.items[slot], item) {
+			return slot, nil
+		}
+	}
 	for slot := range b.items {
 		if b.items[slot] == nil {
 			return slot, nil
@@ -115,12 +138,33 @@ func (b StandardBag) FindEmptySlot(item Item) (int, error) {
 }
 
 // StoreItem puts an item on an empty slot, if available
+// If the slot holds a stack of the same kind the item is merged into it
 func (b *StandardBag) StoreItem(item Item, slot int) error {
+	if slot < 0 || slot >= len(b.items) {
+		return ErrInvalidBagSlot
+	}
 	if b.items[slot] != nil {
-		return ErrNotEmptyBagSlot
+		target, source, ok := stackWith(b.items[slot], item)
+		if !ok {
+			return ErrNotEmptyBagSlot
+		}
+		if !canStack(target, source) {
+			return ErrStackFull
+		}
+		transferStack(source, target)
+		return nil
 	}
 
 	b.items[slot] = item
 
 	return nil
 }
+
+// SplitStack takes a given quantity out of the stack at a given slot
+func (b *StandardBag) SplitStack(slot int, count int) (Item, error) {
+	item, err := b.GetItem(slot)
+	if err != nil {
+		return nil, err
+	}
+	return splitStack(item, count)
+}
@@ -60,3 +60,73 @@ func TestDropItem(t *testing.T) {
 		t.Error("Expected drop to remove the item, got:", stored)
 	}
 }
+
+func TestRestrictedBag(t *testing.T) {
+	pouch := NewStonePouch(2)
+
+	_, err := pouch.FindEmptySlot(&Weapon{})
+	if err != ErrItemNotAccepted {
+		t.Error("Expected pouch to not accept weapons, got", err)
+	}
+	if err = pouch.StoreItem(&Weapon{}, 0); err != ErrItemNotAccepted {
+		t.Error("Expected pouch to not store weapons, got", err)
+	}
+
+	slot, err := pouch.FindEmptySlot(&PortalStone{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = pouch.StoreItem(&PortalStone{}, slot); err != nil {
+		t.Fatal(err)
+	}
+	if pouch.Kind() != StonePouchKind {
+		t.Error("Expected bag to be a stone pouch, got", pouch.Kind())
+	}
+}
+
+func TestExpandableBag(t *testing.T) {
+	bag := NewExpandableBag(5, 10)
+	bag.StoreItem(&PortalStone{}, 4)
+
+	if err := bag.Expand(); err != nil {
+		t.Fatal(err)
+	}
+	if bag.Capacity() != 10 {
+		t.Error("Expected bag to have 10 slots, got", bag.Capacity())
+	}
+	if _, err := bag.GetItem(4); err != nil {
+		t.Error("Expected items to be kept after expanding, got", err)
+	}
+	if err := bag.Expand(); err != ErrBagNotExpandable {
+		t.Error("Expected bag to not expand past its maximum, got", err)
+	}
+}
+
+func TestAttachBag(t *testing.T) {
+	character := &Character{ID: "character"}
+	user := User{
+		Bags:       []Bag{NewStandardBag(2)},
+		Characters: []*Character{character},
+	}
+	user.Bags[0].StoreItem(&BagItem{Kind: WeaponRackKind, Capacity: 4}, 0)
+	user.Bags[0].StoreItem(&BagItem{Kind: StonePouchKind, Capacity: 3}, 1)
+
+	err := user.AttachBag(ItemLocation{BagID: 0, Slot: 0}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(user.Bags) != 2 || user.Bags[1].Kind() != WeaponRackKind {
+		t.Fatal("Expected a weapon rack to be attached to the user")
+	}
+	if _, err = user.Bags[0].GetItem(0); err != ErrEmptyBagSlot {
+		t.Error("Expected the bag item to be taken from the inventory, got", err)
+	}
+
+	err = user.AttachBag(ItemLocation{BagID: 0, Slot: 1}, "character")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(character.Bags) != 1 || character.Bags[0].Capacity() != 3 {
+		t.Error("Expected a stone pouch to be attached to the character")
+	}
+}
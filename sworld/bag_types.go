@@ -0,0 +1,151 @@
+package sworld
+
+import (
+	"errors"
+)
+
+var (
+	// ErrItemNotAccepted is when a bag can't hold that kind of item
+	ErrItemNotAccepted = errors.New("That bag can't hold that item")
+	// ErrUnknownBagKind is when there is no bag of a given kind
+	ErrUnknownBagKind = errors.New("Unknown bag kind")
+	// ErrTooManyBags is when no more bags can be attached
+	ErrTooManyBags = errors.New("Can't carry more bags")
+	// ErrBagNotExpandable is when the bag can't be expanded
+	ErrBagNotExpandable = errors.New("That bag can't be expanded")
+)
+
+const (
+	// StandardBagKind holds any item
+	StandardBagKind = "standard"
+	// StonePouchKind only holds portal stones
+	StonePouchKind = "stone_pouch"
+	// WeaponRackKind only holds weapons
+	WeaponRackKind = "weapon_rack"
+	// ExpandableBagKind holds any item and can be expanded
+	ExpandableBagKind = "expandable"
+
+	// MaxBags is the maximum amount of bags a user or a character can have
+	MaxBags = 5
+	// ExpandSlots is the amount of slots added when expanding a bag
+	ExpandSlots = 5
+)
+
+// RestrictedBag is a bag that only holds some kinds of items
+type RestrictedBag struct {
+	StandardBag
+
+	kind    string
+	allowed []string
+}
+
+// ExpandableBag is a bag that can grow up to a maximum capacity
+type ExpandableBag struct {
+	StandardBag
+
+	MaxCapacity int
+}
+
+// BagItem is a bag found or bought that is not attached yet
+type BagItem struct {
+	Kind     string
+	Capacity int
+}
+
+// NewRestrictedBag creates a bag that only holds the given item kinds
+func NewRestrictedBag(kind string, capacity int, allowed ...string) *RestrictedBag {
+	return &RestrictedBag{
+		StandardBag: StandardBag{
+			items: make([]Item, capacity),
+		},
+		kind:    kind,
+		allowed: allowed,
+	}
+}
+
+// NewStonePouch creates a bag that only holds portal stones
+func NewStonePouch(capacity int) *RestrictedBag {
+	return NewRestrictedBag(StonePouchKind, capacity, StoneItem)
+}
+
+// NewWeaponRack creates a bag that only holds weapons
+func NewWeaponRack(capacity int) *RestrictedBag {
+	return NewRestrictedBag(WeaponRackKind, capacity, WeaponItem)
+}
+
+// NewExpandableBag creates a bag that can be expanded up to maxCapacity
+func NewExpandableBag(capacity, maxCapacity int) *ExpandableBag {
+	return &ExpandableBag{
+		StandardBag: StandardBag{
+			items: make([]Item, capacity),
+		},
+		MaxCapacity: maxCapacity,
+	}
+}
+
+// NewBag creates a bag for a given kind
+func NewBag(kind string, capacity int) (Bag, error) {
+	switch kind {
+	case StandardBagKind:
+		return NewStandardBag(capacity), nil
+	case StonePouchKind:
+		return NewStonePouch(capacity), nil
+	case WeaponRackKind:
+		return NewWeaponRack(capacity), nil
+	case ExpandableBagKind:
+		return NewExpandableBag(capacity, capacity*3), nil
+	default:
+		return nil, ErrUnknownBagKind
+	}
+}
+
+// Kind returns the kind of the bag
+func (b RestrictedBag) Kind() string {
+	return b.kind
+}
+
+// Accepts returns true if the bag can hold the item
+func (b RestrictedBag) Accepts(item Item) bool {
+	kind := ItemKind(item)
+	for _, allowed := range b.allowed {
+		if kind == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// FindEmptySlot returns a slot available for a given item
+func (b RestrictedBag) FindEmptySlot(item Item) (int, error) {
+	if !b.Accepts(item) {
+		return 0, ErrItemNotAccepted
+	}
+	return b.StandardBag.FindEmptySlot(item)
+}
+
+// StoreItem puts an item on an empty slot, if the bag accepts it
+func (b *RestrictedBag) StoreItem(item Item, slot int) error {
+	if !b.Accepts(item) {
+		return ErrItemNotAccepted
+	}
+	return b.StandardBag.StoreItem(item, slot)
+}
+
+// Kind returns the kind of the bag
+func (b ExpandableBag) Kind() string {
+	return ExpandableBagKind
+}
+
+// Expand adds ExpandSlots slots to the bag
+func (b *ExpandableBag) Expand() error {
+	if len(b.items)+ExpandSlots > b.MaxCapacity {
+		return ErrBagNotExpandable
+	}
+	b.items = append(b.items, make([]Item, ExpandSlots)...)
+	return nil
+}
+
+// NewBag creates the bag this item represents
+func (b BagItem) NewBag() (Bag, error) {
+	return NewBag(b.Kind, b.Capacity)
+}
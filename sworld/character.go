@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sync/atomic"
+	"time"
 )
 
 var (
@@ -13,26 +15,19 @@ var (
 	ErrPortalIsClosed = errors.New("The portal is already closed")
 	// ErrCharacterBusy means the character is busy and cannot perform the requested task
 	ErrCharacterBusy = errors.New("The character is busy")
+	// ErrCharacterDead means the character is dead and cannot perform the requested task
+	ErrCharacterDead = errors.New("The character is dead")
 )
 
 // Delete item:
 //     a[i] = a[len(a)-1]
 //     a[len(a)-1] = nil
 //     a = a[:len(a)-1]
-//
-// Killing enemy xp:
-// xp := int64(
-//     math.Round(
-//         (float64(enemy.Level) / float64(c.Level)) * float64(enemy.Level),
-//     ),
-// )
-
-// Next lvl xp:
-// return int64(math.Round((4 * math.Pow(float64(c.Level), 3)) / 5))
 
 // Character represents a user character
 type Character struct {
 	ID        string
+	Class     Class
 	Level     int
 	Health    int
 	MaxHealth int
@@ -41,44 +36,72 @@ type Character struct {
 	User      *User
 	Bags      []Bag
 	Skills    []Skill
+	Weapon    *Weapon
 	D         chan bool
 
+	// XP is the experience gained towards the next level
+	XP int
+	// AttributePoints and TalentPoints are the points gained on level up
+	// that were not spent yet
+	AttributePoints int
+	TalentPoints    int
+	Attributes      Attributes
+	// Talents are the ranks of the learned talents, by talent ID
+	Talents map[string]int
+
+	// AutoPotionThreshold is the health percentage under which a health
+	// potion is used automatically
+	AutoPotionThreshold int
+	// AutoExtendThreshold is the portal time left under which a portal
+	// extender is used automatically
+	AutoExtendThreshold time.Duration
+
+	buffs  []Buff
+	portal *Portal
+	run    runStats
+	// died is set once the character died, so D is only closed once
+	died int32
+
 	// TODO: this is so we can debug things
 	enemies int
 }
 
-// NewCharacter creates a character
-func NewCharacter() *Character {
-	health := 100
+// NewCharacter creates a character of a given class
+func NewCharacter(class Class) *Character {
+	stats := classStats(class)
+	health := stats.MaxHealth(1)
 
 	character := &Character{
 		ID:        RandomID(16),
+		Class:     stats.Class,
 		Level:     1,
+		Talents:   make(map[string]int),
 		Health:    health,
 		MaxHealth: health,
 		Gold:      0,
 		Exploring: false,
-		Skills:    make([]Skill, 1),
 		D:         make(chan bool),
 		Bags: []Bag{
 			NewStandardBag(10),
 		},
+		AutoPotionThreshold: 30,
+		AutoExtendThreshold: 3 * time.Second,
 	}
 
 	// FIXME: I don't really like this cross-dependency
-	character.Skills[0] = NewHitSkill(character)
+	character.Skills = stats.newSkills(character)
 
 	return character
 }
 
 // Die kills the character
+// The character stays on the portal until ReturnDead is called, so its bags
+// are only changed by whoever holds the character
 func (c *Character) Die() {
 	c.Health = 0
-	for _, bag := range c.Bags {
-		bag.Empty()
+	if !atomic.CompareAndSwapInt32(&c.died, 0, 1) {
+		return
 	}
-
-	c.Exploring = false
 	if c.User == nil {
 		log.Println(" --->  Character doe not have a user!")
 	}
@@ -87,9 +110,113 @@ func (c *Character) Die() {
 	close(c.D)
 }
 
+// ReturnDead takes a dead character out of its portal, everything on its
+// bags is lost
+func (c *Character) ReturnDead() {
+	for _, bag := range c.Bags {
+		bag.Empty()
+	}
+	c.Exploring = false
+	c.portal = nil
+}
+
+// Heal restores some health, up to MaxHealth
+// Characters on a portal with NoHealingModifier can't heal
+func (c *Character) Heal(amount int) {
+	if c.Health <= 0 || !c.canHeal() {
+		return
+	}
+	c.Health += amount
+	if c.Health > c.MaxHealth {
+		c.Health = c.MaxHealth
+	}
+	c.portal.record(ReplayEvent{
+		Kind:   ReplayHeal,
+		Target: c.ID,
+		Value:  amount,
+		Health: c.Health,
+	})
+}
+
 // Damage returns the base damage dealt by the character
 func (c Character) Damage() int {
-	return c.Level * 20
+	damage := classStats(c.Class).BaseDamage(c.Level) + (c.Attributes.Strength * strengthDamage)
+	if c.Weapon != nil {
+		damage += c.Weapon.Damage + c.Weapon.AffixValue(BonusDamageAffix)
+	}
+	damage += (damage * c.talentBonus().Damage) / 100
+	for _, buff := range c.buffs {
+		if buff.Active() {
+			damage += buff.Damage
+		}
+	}
+	return damage
+}
+
+// CriticalChance returns the chance (in percent) of dealing a critical hit
+func (c Character) CriticalChance() int {
+	if c.Weapon == nil {
+		return 0
+	}
+	return c.Weapon.AffixValue(CritChanceAffix)
+}
+
+// LifeSteal heals the character for a part of the damage dealt
+func (c *Character) LifeSteal(damage int) {
+	if c.Weapon == nil {
+		return
+	}
+	amount := (damage * c.Weapon.AffixValue(LifeStealAffix)) / 100
+	if amount > 0 {
+		c.Heal(amount)
+	}
+}
+
+// goldFound applies the portal modifiers and the gold find bonus to an
+// amount of gold
+func (c Character) goldFound(gold int) int {
+	if c.portal != nil {
+		gold = c.portal.PortalStone.goldFound(gold)
+	}
+	if c.Weapon == nil {
+		return gold
+	}
+	return gold + ((gold * c.Weapon.AffixValue(GoldFindAffix)) / 100)
+}
+
+// EquipWeapon equips the weapon that is at a given location
+// The weapon that was equipped before, if any, takes its place on the bag
+func (c *Character) EquipWeapon(bagID, slot int) error {
+	if c.Exploring {
+		return ErrCharacterBusy
+	}
+	if bagID < 0 || bagID >= len(c.Bags) {
+		return ErrInvalidBag
+	}
+	bag := c.Bags[bagID]
+
+	item, err := bag.GetItem(slot)
+	if err != nil {
+		return err
+	}
+	weapon, ok := item.(*Weapon)
+	if !ok {
+		return ErrWrongItem
+	}
+	if !classStats(c.Class).CanWield(weapon.Kind) {
+		return ErrWeaponNotAllowed
+	}
+
+	_, err = bag.DropItem(slot)
+	if err != nil {
+		return err
+	}
+	if c.Weapon != nil {
+		bag.StoreItem(c.Weapon, slot)
+	}
+	c.Weapon = weapon
+
+	return nil
 }
 
 func (c Character) findEmptyBagSlot(item Item) (int, int, error) {
@@ -141,7 +268,9 @@ func (c *Character) DropItem(bagID, slot int) (Item, error) {
 
 // ReturnToTown makes the character leave the "exploring" state
 func (c *Character) ReturnToTown(portal *Portal) {
+	portal.record(ReplayEvent{Kind: ReplayLeave, Target: c.ID})
 	c.Exploring = false
+	c.portal = nil
 
 	// FIXME: This is just for debugging
 	for bagID, bag := range c.Bags {
@@ -160,6 +289,7 @@ func (c *Character) ReturnToTown(portal *Portal) {
 
 	u := c.User
 	u.Gold += c.Gold
+	c.Gold = 0
 
 	log.Printf(" -> Current stats for user:")
 	log.Printf("   -> Gold: %d", u.Gold)
@@ -169,7 +299,15 @@ func (c *Character) ReturnToTown(portal *Portal) {
 // TODO: remove?
 func (c *Character) EncounterEvent(event *PortalEvent) error {
 	if event.Item != nil {
-		c.pickupItem(event.Item)
+		_, _, err := c.pickupItem(event.Item)
+		if err == nil {
+			c.run.items = append(c.run.items, event.Item)
+			c.portal.record(ReplayEvent{
+				Kind:   ReplayDrop,
+				Target: c.ID,
+				Item:   event.Item,
+			})
+		}
 	}
 
 	if event.Enemy != nil {
@@ -178,16 +316,24 @@ func (c *Character) EncounterEvent(event *PortalEvent) error {
 	}
 
 	if event.Gold > 0 {
-		c.Gold += event.Gold
+		gold := c.goldFound(event.Gold)
+		c.Gold += gold
+		c.run.gold += gold
+		c.portal.record(ReplayEvent{Kind: ReplayGold, Target: c.ID, Value: gold})
 		log.Printf("   -> Gold spawn\n")
 	}
 
+	if event.Heal > 0 {
+		c.Heal(event.Heal)
+		log.Printf("   -> Healed, health is now %d\n", c.Health)
+	}
+
 	return nil
 }
 
 // EnterPortal makes a character enter a portal
 func (c *Character) EnterPortal(portal *Portal) (*Explorer, error) {
-	if !portal.IsOpen {
+	if !portal.Open() {
 		return nil, ErrPortalIsClosed
 	}
 
@@ -196,12 +342,20 @@ func (c *Character) EnterPortal(portal *Portal) (*Explorer, error) {
 	}
 
 	c.Exploring = true
+	c.portal = portal
+	c.run = runStats{startedAt: time.Now()}
 
 	exploration := &Explorer{
 		Portal:    portal,
 		Character: c,
 	}
 	portal.explorers = append(portal.explorers, exploration)
+	portal.record(ReplayEvent{
+		Kind:   ReplayEnter,
+		Target: c.ID,
+		Value:  c.MaxHealth,
+		Health: c.Health,
+	})
 
 	return exploration, nil
 }
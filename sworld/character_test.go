@@ -115,3 +115,84 @@ func TestPickupItem(t *testing.T) {
 	}
 
 }
+
+func TestEquipWeapon(t *testing.T) {
+	bag := NewStandardBag(2)
+	char := &Character{Level: 1, Bags: []Bag{bag}}
+
+	weapon := &Weapon{
+		Damage:  10,
+		Affixes: []Affix{{Kind: BonusDamageAffix, Value: 5}},
+	}
+	bag.StoreItem(weapon, 0)
+	bag.StoreItem(&PortalStone{}, 1)
+
+	if err := char.EquipWeapon(0, 1); err == nil {
+		t.Error("Expected equipping a stone to fail")
+	}
+
+	if err := char.EquipWeapon(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if char.Weapon != weapon {
+		t.Fatal("Expected weapon to be equipped")
+	}
+	if char.Damage() != 35 {
+		t.Error("Expected damage to be 35, got", char.Damage())
+	}
+
+	bag.DropItem(1)
+	other := &Weapon{Damage: 1}
+	bag.StoreItem(other, 1)
+	if err := char.EquipWeapon(0, 1); err != nil {
+		t.Fatal(err)
+	}
+	item, err := bag.GetItem(1)
+	if err != nil || item != weapon {
+		t.Error("Expected previous weapon to be stored in the bag, got", item)
+	}
+}
+
+func TestGoldFind(t *testing.T) {
+	char := &Character{
+		Bags: []Bag{NewStandardBag(1)},
+		Weapon: &Weapon{
+			Affixes: []Affix{{Kind: GoldFindAffix, Value: 50}},
+		},
+	}
+	char.EncounterEvent(&PortalEvent{Gold: 10})
+
+	if char.Gold != 15 {
+		t.Error("Expected gold find to increase gold to 15, got", char.Gold)
+	}
+}
+
+func TestDieAndReturnDead(t *testing.T) {
+	char := &Character{
+		Health:    10,
+		Exploring: true,
+		Bags:      []Bag{NewStandardBag(2)},
+		D:         make(chan bool),
+	}
+	if _, _, err := char.pickupItem(&PortalStone{Level: 3}); err != nil {
+		t.Fatal("Can't pick up item, ", err)
+	}
+
+	char.Die()
+	char.Die()
+
+	if char.Health != 0 {
+		t.Fatal("Expected health to be 0, got", char.Health)
+	}
+	if char.Bags[0].Items()[0] == nil {
+		t.Fatal("Expected the bags to be kept until the character returns")
+	}
+
+	char.ReturnDead()
+	if char.Exploring {
+		t.Fatal("Expected the character to not be exploring")
+	}
+	if char.Bags[0].Items()[0] != nil {
+		t.Fatal("Expected the bags to be empty")
+	}
+}
@@ -0,0 +1,186 @@
+package sworld
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+var (
+	// ErrUnknownClass is when the character class does not exist
+	ErrUnknownClass = errors.New("That class does not exist")
+	// ErrWeaponNotAllowed is when the class of the character can't wield a weapon
+	ErrWeaponNotAllowed = errors.New("The character can't wield that weapon")
+)
+
+// Class is the class of a character
+type Class string
+
+const (
+	// WarriorClass is a sturdy melee class
+	WarriorClass Class = "warrior"
+	// RangerClass is a fast ranged class
+	RangerClass Class = "ranger"
+	// MageClass is a fragile class with strong spells
+	MageClass Class = "mage"
+
+	// DefaultClass is the class of characters spawned without one
+	DefaultClass = WarriorClass
+)
+
+// ClassSkill describes a skill a class starts with
+type ClassSkill struct {
+	Name     string
+	Cooldown time.Duration
+	// Power is the percentage of the character damage dealt
+	Power int
+}
+
+// ClassStats are the stats of a class
+type ClassStats struct {
+	Class          Class
+	Health         int
+	HealthPerLevel int
+	Damage         int
+	DamagePerLevel int
+	Weapons        []WeaponKind
+	Skills         []ClassSkill
+}
+
+// classBook are the classes a character can be spawned with
+var classBook = []ClassStats{
+	{
+		Class:          WarriorClass,
+		Health:         100,
+		HealthPerLevel: 20,
+		Damage:         20,
+		DamagePerLevel: 20,
+		Weapons:        []WeaponKind{SwordWeapon, AxeWeapon},
+		Skills: []ClassSkill{
+			{Name: "hit", Cooldown: 500 * time.Millisecond, Power: 100},
+			{Name: "cleave", Cooldown: 2 * time.Second, Power: 180},
+		},
+	},
+	{
+		Class:          RangerClass,
+		Health:         80,
+		HealthPerLevel: 15,
+		Damage:         16,
+		DamagePerLevel: 18,
+		Weapons:        []WeaponKind{BowWeapon, DaggerWeapon},
+		Skills: []ClassSkill{
+			{Name: "shot", Cooldown: 350 * time.Millisecond, Power: 80},
+			{Name: "volley", Cooldown: 3 * time.Second, Power: 220},
+		},
+	},
+	{
+		Class:          MageClass,
+		Health:         60,
+		HealthPerLevel: 10,
+		Damage:         26,
+		DamagePerLevel: 25,
+		Weapons:        []WeaponKind{StaffWeapon, DaggerWeapon},
+		Skills: []ClassSkill{
+			{Name: "bolt", Cooldown: 700 * time.Millisecond, Power: 110},
+			{Name: "fireball", Cooldown: 4 * time.Second, Power: 300},
+		},
+	},
+}
+
+// Classes returns the classes a character can be spawned with
+func Classes() []ClassStats {
+	return classBook
+}
+
+// FindClass returns the stats of a class, an empty name is the default class
+func FindClass(name string) (ClassStats, error) {
+	if name == "" {
+		name = string(DefaultClass)
+	}
+	for _, stats := range classBook {
+		if string(stats.Class) == name {
+			return stats, nil
+		}
+	}
+	return ClassStats{}, ErrUnknownClass
+}
+
+// classStats returns the stats of a class, falling back to the default class
+func classStats(class Class) ClassStats {
+	stats, err := FindClass(string(class))
+	if err != nil {
+		stats, _ = FindClass("")
+	}
+	return stats
+}
+
+// MaxHealth returns the max health of the class at a given level
+func (s ClassStats) MaxHealth(level int) int {
+	return s.Health + (s.HealthPerLevel * (level - 1))
+}
+
+// BaseDamage returns the damage of the class at a given level
+func (s ClassStats) BaseDamage(level int) int {
+	return s.Damage + (s.DamagePerLevel * (level - 1))
+}
+
+// CanWield returns whether the class can wield a kind of weapon
+func (s ClassStats) CanWield(kind WeaponKind) bool {
+	for _, allowed := range s.Weapons {
+		if allowed == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// newSkills creates the starting skills of the class for a character
+func (s ClassStats) newSkills(c *Character) []Skill {
+	skills := make([]Skill, 0, len(s.Skills))
+	for _, skill := range s.Skills {
+		skills = append(skills, &HitSkill{
+			source:   c,
+			name:     skill.Name,
+			cooldown: skill.Cooldown,
+			power:    skill.Power,
+		})
+	}
+	return skills
+}
+
+// NextLevelXP returns the experience needed to reach the next level
+func (c Character) NextLevelXP() int {
+	return int(math.Round((4 * math.Pow(float64(c.Level), 3)) / 5))
+}
+
+// gainXP adds the experience for killing an enemy, leveling up the
+// character when it has enough
+func (c *Character) gainXP(enemy *Enemy) {
+	level := enemy.Level
+	if level < 1 {
+		level = 1
+	}
+	charLevel := c.Level
+	if charLevel < 1 {
+		charLevel = 1
+	}
+	xp := int(math.Round((float64(level) / float64(charLevel)) * float64(level)))
+	if xp < 1 {
+		xp = 1
+	}
+	c.XP += xp
+
+	for c.XP >= c.NextLevelXP() {
+		c.XP -= c.NextLevelXP()
+		c.levelUp()
+	}
+}
+
+// levelUp raises the level of the character and gives it points to spend
+// Only the max health grows, health changes need to go through the replay
+func (c *Character) levelUp() {
+	c.Level++
+	c.AttributePoints += attributePointsPerLevel
+	c.TalentPoints++
+	c.updateMaxHealth()
+}
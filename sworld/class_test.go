@@ -0,0 +1,83 @@
+package sworld
+
+import (
+	"testing"
+)
+
+func TestFindClass(t *testing.T) {
+	stats, err := FindClass("")
+	if err != nil || stats.Class != DefaultClass {
+		t.Error("Expected the default class, got", stats.Class, err)
+	}
+
+	stats, err = FindClass("mage")
+	if err != nil || stats.Class != MageClass {
+		t.Error("Expected the mage class, got", stats.Class, err)
+	}
+
+	if _, err := FindClass("bard"); err != ErrUnknownClass {
+		t.Error("Expected an unknown class error, got", err)
+	}
+}
+
+func TestNewCharacterClass(t *testing.T) {
+	mage := NewCharacter(MageClass)
+	stats, _ := FindClass("mage")
+
+	if mage.Class != MageClass {
+		t.Error("Expected a mage, got", mage.Class)
+	}
+	if mage.MaxHealth != stats.Health || mage.Health != stats.Health {
+		t.Error("Expected health to be", stats.Health, "got", mage.Health, mage.MaxHealth)
+	}
+	if mage.Damage() != stats.Damage {
+		t.Error("Expected damage to be", stats.Damage, "got", mage.Damage())
+	}
+	if len(mage.Skills) != len(stats.Skills) {
+		t.Fatal("Expected", len(stats.Skills), "skills, got", len(mage.Skills))
+	}
+	if mage.Skills[0].(*HitSkill).Name() != "bolt" {
+		t.Error("Expected the first skill to be bolt, got", mage.Skills[0].(*HitSkill).Name())
+	}
+}
+
+func TestEquipWeaponClass(t *testing.T) {
+	bag := NewStandardBag(2)
+	char := NewCharacter(RangerClass)
+	char.Bags = []Bag{bag}
+
+	bag.StoreItem(&Weapon{Kind: StaffWeapon, Damage: 10}, 0)
+	bag.StoreItem(&Weapon{Kind: BowWeapon, Damage: 10}, 1)
+
+	if err := char.EquipWeapon(0, 0); err != ErrWeaponNotAllowed {
+		t.Error("Expected a ranger to not wield a staff, got", err)
+	}
+	if err := char.EquipWeapon(0, 1); err != nil {
+		t.Error("Expected a ranger to wield a bow, got", err)
+	}
+}
+
+func TestGainXP(t *testing.T) {
+	char := NewCharacter(WarriorClass)
+	stats, _ := FindClass("warrior")
+	char.Health = 50
+
+	// A level 3 enemy gives 9 experience, enough for level 3
+	char.gainXP(&Enemy{Level: 3})
+
+	if char.Level != 3 {
+		t.Fatal("Expected level 3, got", char.Level)
+	}
+	if char.XP != 2 {
+		t.Error("Expected 2 experience left, got", char.XP)
+	}
+	if char.MaxHealth != stats.MaxHealth(3) {
+		t.Error("Expected max health to be", stats.MaxHealth(3), "got", char.MaxHealth)
+	}
+	if char.Health != 50 {
+		t.Error("Expected health to not change, got", char.Health)
+	}
+	if char.Damage() != stats.BaseDamage(3) {
+		t.Error("Expected damage to be", stats.BaseDamage(3), "got", char.Damage())
+	}
+}
@@ -0,0 +1,209 @@
+package sworld
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+var (
+	// ErrNotExploring means the character needs to be exploring to do that
+	ErrNotExploring = errors.New("The character is not exploring")
+)
+
+// ConsumableKind is the kind of effect a consumable has
+type ConsumableKind int
+
+const (
+	// HealthPotion restores Power health
+	HealthPotion ConsumableKind = iota
+	// DamageBuff adds Power damage for Duration
+	DamageBuff
+	// PortalExtender keeps the portal open Power seconds longer
+	PortalExtender
+)
+
+var consumableNames = map[ConsumableKind]string{
+	HealthPotion:   "health_potion",
+	DamageBuff:     "damage_buff",
+	PortalExtender: "portal_extender",
+}
+
+var consumableStackSizes = map[ConsumableKind]int{
+	HealthPotion:   20,
+	DamageBuff:     10,
+	PortalExtender: 5,
+}
+
+// Consumable is an item that is used up
+// A single slot can hold several of them
+type Consumable struct {
+	Kind     ConsumableKind
+	Power    int
+	Duration time.Duration
+	Quantity int
+}
+
+// Buff is a temporary bonus on a character
+type Buff struct {
+	Damage    int
+	ExpiresAt time.Time
+}
+
+// String returns the name of the consumable kind
+func (k ConsumableKind) String() string {
+	return consumableNames[k]
+}
+
+// StackKind groups consumables with the same effect
+func (c Consumable) StackKind() string {
+	return fmt.Sprintf("consumable:%s:%d:%s", c.Kind, c.Power, c.Duration)
+}
+
+// MaxStack returns the maximum quantity of a stack
+func (c Consumable) MaxStack() int {
+	return consumableStackSizes[c.Kind]
+}
+
+// Count returns the quantity of the stack
+func (c Consumable) Count() int {
+	return c.Quantity
+}
+
+// SetCount sets the quantity of the stack
+func (c *Consumable) SetCount(count int) {
+	c.Quantity = count
+}
+
+// Split creates a new stack of the same consumable
+func (c Consumable) Split(count int) Stackable {
+	return &Consumable{
+		Kind:     c.Kind,
+		Power:    c.Power,
+		Duration: c.Duration,
+		Quantity: count,
+	}
+}
+
+// Active returns true if the buff has not expired yet
+func (b Buff) Active() bool {
+	return time.Now().Before(b.ExpiresAt)
+}
+
+func (c *Character) consume(consumable *Consumable) error {
+	switch consumable.Kind {
+	case HealthPotion:
+		if !c.canHeal() {
+			return ErrNoHealing
+		}
+		c.Heal(consumable.Power)
+	case DamageBuff:
+		c.buffs = append(c.buffs, Buff{
+			Damage:    consumable.Power,
+			ExpiresAt: time.Now().Add(consumable.Duration),
+		})
+	case PortalExtender:
+		if c.portal == nil {
+			return ErrNotExploring
+		}
+		return c.portal.Extend(time.Duration(consumable.Power) * time.Second)
+	default:
+		return ErrWrongItem
+	}
+	return nil
+}
+
+// UseItem uses a consumable that is at a given location
+func (c *Character) UseItem(bagID, slot int) error {
+	if c.Health <= 0 {
+		return ErrCharacterDead
+	}
+	if bagID < 0 || bagID >= len(c.Bags) {
+		return ErrInvalidBag
+	}
+	bag := c.Bags[bagID]
+
+	item, err := bag.GetItem(slot)
+	if err != nil {
+		return err
+	}
+	consumable, ok := item.(*Consumable)
+	if !ok {
+		return ErrWrongItem
+	}
+
+	err = c.consume(consumable)
+	if err != nil {
+		return err
+	}
+
+	consumable.Quantity--
+	if consumable.Quantity <= 0 {
+		_, err = bag.DropItem(slot)
+	}
+	return err
+}
+
+func (c Character) findConsumable(kind ConsumableKind) (int, int, bool) {
+	for bagID, bag := range c.Bags {
+		for slot, item := range bag.Items() {
+			consumable, ok := item.(*Consumable)
+			if ok && consumable.Kind == kind {
+				return bagID, slot, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// AutoUseItems uses consumables when the character is below its thresholds
+func (c *Character) AutoUseItems() {
+	if c.Health <= 0 {
+		return
+	}
+
+	if c.MaxHealth > 0 && (c.Health*100)/c.MaxHealth < c.AutoPotionThreshold {
+		bagID, slot, ok := c.findConsumable(HealthPotion)
+		if ok {
+			log.Printf("Character: Drinking a potion, health is %d\n", c.Health)
+			c.UseItem(bagID, slot)
+		}
+	}
+
+	if c.portal != nil && c.portal.Open() && c.portal.TimeLeft() < c.AutoExtendThreshold {
+		bagID, slot, ok := c.findConsumable(PortalExtender)
+		if ok {
+			log.Printf("Character: Extending portal, %s left\n", c.portal.TimeLeft().String())
+			c.UseItem(bagID, slot)
+		}
+	}
+}
+
+// RandomConsumable returns a random consumable based on current portal
+func (p *Portal) RandomConsumable() *Consumable {
+	level := p.PortalStone.Level
+	quantity := 1 + p.seed.Intn(3)
+
+	switch ConsumableKind(p.seed.Intn(len(consumableNames))) {
+	case DamageBuff:
+		return &Consumable{
+			Kind:     DamageBuff,
+			Power:    5 + (5 * level),
+			Duration: 10 * time.Second,
+			Quantity: quantity,
+		}
+	case PortalExtender:
+		return &Consumable{
+			Kind:     PortalExtender,
+			Power:    5,
+			Quantity: 1,
+		}
+	default:
+		return &Consumable{
+			Kind:     HealthPotion,
+			Power:    20 + (10 * level),
+			Quantity: quantity,
+		}
+	}
+}
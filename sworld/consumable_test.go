@@ -0,0 +1,95 @@
+package sworld
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUseHealthPotion(t *testing.T) {
+	bag := NewStandardBag(2)
+	char := &Character{Health: 10, MaxHealth: 100, Bags: []Bag{bag}}
+	bag.StoreItem(&Consumable{Kind: HealthPotion, Power: 30, Quantity: 2}, 0)
+
+	if err := char.UseItem(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if char.Health != 40 {
+		t.Error("Expected health to be 40, got", char.Health)
+	}
+
+	item, err := bag.GetItem(0)
+	if err != nil {
+		t.Fatal("Expected the potion to still be there, got", err)
+	}
+	if item.(*Consumable).Quantity != 1 {
+		t.Error("Expected potion quantity to be 1, got", item.(*Consumable).Quantity)
+	}
+
+	if err := char.UseItem(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bag.GetItem(0); err != ErrEmptyBagSlot {
+		t.Error("Expected the potion to be used up, got", err)
+	}
+}
+
+func TestUseItemDeadCharacter(t *testing.T) {
+	bag := NewStandardBag(1)
+	char := &Character{Health: 0, MaxHealth: 100, Bags: []Bag{bag}}
+	bag.StoreItem(&Consumable{Kind: HealthPotion, Power: 30, Quantity: 1}, 0)
+
+	if err := char.UseItem(0, 0); err != ErrCharacterDead {
+		t.Error("Expected a dead character to not use items, got", err)
+	}
+	if _, err := bag.GetItem(0); err != nil {
+		t.Error("Expected the potion to not be used, got", err)
+	}
+}
+
+func TestUseDamageBuff(t *testing.T) {
+	bag := NewStandardBag(1)
+	char := &Character{Level: 1, Health: 100, Bags: []Bag{bag}}
+	bag.StoreItem(&Consumable{Kind: DamageBuff, Power: 5, Duration: time.Minute, Quantity: 1}, 0)
+
+	if err := char.UseItem(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if char.Damage() != 25 {
+		t.Error("Expected damage to be 25, got", char.Damage())
+	}
+}
+
+func TestUsePortalExtender(t *testing.T) {
+	bag := NewStandardBag(1)
+	char := &Character{Health: 100, Bags: []Bag{bag}}
+	bag.StoreItem(&Consumable{Kind: PortalExtender, Power: 5, Quantity: 1}, 0)
+
+	if err := char.UseItem(0, 0); err != ErrNotExploring {
+		t.Error("Expected extender to require a portal, got", err)
+	}
+	if _, err := bag.GetItem(0); err != nil {
+		t.Error("Expected the extender to not be used up, got", err)
+	}
+}
+
+func TestAutoUseItems(t *testing.T) {
+	bag := NewStandardBag(2)
+	char := &Character{
+		Health:              20,
+		MaxHealth:           100,
+		Bags:                []Bag{bag},
+		AutoPotionThreshold: 30,
+	}
+	bag.StoreItem(&Consumable{Kind: HealthPotion, Power: 30, Quantity: 1}, 1)
+
+	char.AutoUseItems()
+	if char.Health != 50 {
+		t.Error("Expected a potion to be used, health is", char.Health)
+	}
+
+	bag.StoreItem(&Consumable{Kind: HealthPotion, Power: 30, Quantity: 1}, 1)
+	char.AutoUseItems()
+	if char.Health != 50 {
+		t.Error("Expected no potion to be used above the threshold, health is", char.Health)
+	}
+}
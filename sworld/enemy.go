@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"math/rand"
+	"sync"
 	"time"
 )
 
@@ -15,22 +16,34 @@ type Enemy struct {
 	Level     int
 	MaxHealth int
 	Health    int
+	Elite     bool
 	Skills    []Skill
 
 	position int
 	portal   *Portal
 	D        chan bool
+	// mu guards Health, several explorers can hit the enemy at the same time
+	mu sync.Mutex
 }
 
 // NewEnemy creates a new enemy
 func NewEnemy(portal *Portal, position int) *Enemy {
-	health := (rand.Intn(10) * portal.PortalStone.Level) + (portal.PortalStone.Level * 10)
+	stone := portal.PortalStone
+	health := (rand.Intn(10) * stone.Level) + (stone.Level * 10)
+	if stone.HasModifier(EnemyHealthModifier) {
+		health += (health * enemyHealthBonus) / 100
+	}
+	elite := rand.Intn(100) < stone.eliteChance()
+	if elite {
+		health *= 2
+	}
 
 	enemy := &Enemy{
 		ID:        RandomID(16),
-		Level:     portal.PortalStone.Level,
+		Level:     stone.Level,
 		MaxHealth: health,
 		Health:    health,
+		Elite:     elite,
 		Skills:    make([]Skill, 0),
 		D:         make(chan bool),
 		portal:    portal,
@@ -46,21 +59,44 @@ func (e *Enemy) AddSkill(skill Skill) {
 }
 
 // ReceiveDamage handles damage dealt to this enemy
-func (e *Enemy) ReceiveDamage(source Skill, amount int) int {
+// Only the damage that kills the enemy reports it, an enemy that is already
+// dead ignores the damage
+func (e *Enemy) ReceiveDamage(source Skill, amount int) (int, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.Health <= 0 {
+		return e.Health, false
+	}
+
 	// TODO: damage reduction should be applied here
 	e.Health -= amount
+	if e.Health < 0 {
+		e.Health = 0
+	}
+	e.portal.record(ReplayEvent{
+		Kind:   ReplayDamage,
+		Target: e.ID,
+		Value:  amount,
+		Health: e.Health,
+	})
+
+	fmt.Printf("Enemy received %d damage, health is now %d\n", amount, e.Health)
 
 	if e.Health <= 0 {
-		e.Health = 0
+		e.portal.record(ReplayEvent{Kind: ReplayDeath, Target: e.ID})
 		close(e.D)
+		return e.Health, true
 	}
-	fmt.Printf("Enemy received %d damage, health is now %d\n", amount, e.Health)
 
-	return e.Health
+	return e.Health, false
 }
 
 // Damage returns the base damage dealt by the enemy
-func (e Enemy) Damage() int {
+func (e *Enemy) Damage() int {
+	if e.Elite {
+		return 20 * e.Level
+	}
 	return 10 * e.Level
 }
 
@@ -130,6 +166,11 @@ func (e *Enemy) handleMove() {
 							e.position--
 							log.Printf(" Enemy: Going back, now at %d\n", e.position)
 						}
+						e.portal.record(ReplayEvent{
+							Kind:     ReplayMove,
+							Target:   e.ID,
+							Position: e.position,
+						})
 					}
 				}
 			case _, _ = <-e.D:
@@ -161,7 +202,12 @@ func (e *Enemy) handleAttack() {
 
 						if skill != nil {
 							log.Printf(" Enemy: Attacking %v\n", explorer.Character)
-							skill.Use(explorer)
+							unlock := e.portal.lockCharacter(explorer.Character)
+							// The character might have left while waiting
+							if explorer.Character.portal == e.portal {
+								skill.Use(explorer)
+							}
+							unlock()
 						} else {
 							log.Printf(" Enemy: No skills to attack!\n")
 						}
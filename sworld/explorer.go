@@ -35,15 +35,27 @@ func (e *Explorer) ClosestEnemy() *Enemy {
 }
 
 // ReceiveDamage handles the damage received by an explorer
-func (e *Explorer) ReceiveDamage(source Skill, amount int) int {
+func (e *Explorer) ReceiveDamage(source Skill, amount int) (int, bool) {
+	if e.Character.Health <= 0 {
+		return e.Character.Health, false
+	}
+	amount -= (amount * e.Character.Mitigation()) / 100
 	e.Character.Health -= amount
 	log.Printf("Character: Received %d damage, health is now: %d\n", amount, e.Character.Health)
+	e.Portal.record(ReplayEvent{
+		Kind:   ReplayDamage,
+		Target: e.Character.ID,
+		Value:  amount,
+		Health: e.Character.Health,
+	})
 
 	if e.Character.Health <= 0 {
+		e.Portal.record(ReplayEvent{Kind: ReplayDeath, Target: e.Character.ID})
 		e.Character.Die()
+		return e.Character.Health, true
 	}
 
-	return e.Character.Health
+	return e.Character.Health, false
 }
 
 // Position returns the current position of an explorer
@@ -52,15 +64,33 @@ func (e Explorer) Position() int {
 }
 
 // Advance moves the explorer forward
+// Consumables are used here rather than when receiving damage, so the caller
+// can hold the character lock while the bags change
 func (e *Explorer) Advance() *PortalEvent {
-	e.position++
 	p := e.Portal
 
-	cleared := p.cleared
+	e.Character.AutoUseItems()
+
+	if p.Layout != nil && e.position >= p.Layout.Length() {
+		// Reached the end of the portal
+		return nil
+	}
+	e.position++
+	p.record(ReplayEvent{
+		Kind:     ReplayMove,
+		Target:   e.Character.ID,
+		Position: e.position,
+	})
 
 	var event *PortalEvent
 
-	if cleared < e.position {
+	if p.Layout != nil {
+		// Every room generates its event only once, for the first explorer
+		// reaching it
+		if p.clearRoom(e.position) {
+			event = p.PortalStone.Zone.RoomEvent(p, e.position)
+		}
+	} else {
 		event = p.PortalStone.Zone.DropEvent(p, e.position)
 	}
 
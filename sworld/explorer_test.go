@@ -1,6 +1,7 @@
 package sworld
 
 import (
+	"math/rand"
 	"testing"
 )
 
@@ -36,3 +37,67 @@ func TestClosestEnemy(t *testing.T) {
 		t.Fatal("Expected closest enemy position to be 3, got", enemy.position)
 	}
 }
+
+func TestAdvanceWithLayout(t *testing.T) {
+	zone := NewZone("Test")
+	zone.AddRoomEvent(RestRoom, func(*Portal, int) *PortalEvent {
+		return &PortalEvent{Heal: 10}
+	})
+
+	portal := &Portal{
+		PortalStone: PortalStone{Zone: zone},
+		Layout: &Layout{
+			Rooms: []RoomType{CorridorRoom, RestRoom, CorridorRoom},
+		},
+	}
+	character := &Character{Health: 50, MaxHealth: 100}
+	exploration := &Explorer{Portal: portal, Character: character}
+
+	event := exploration.Advance()
+	if event == nil || event.Heal != 10 {
+		t.Fatal("Expected advancing into a rest room to heal, got", event)
+	}
+	if character.Health != 60 {
+		t.Error("Expected character health to be 60, got", character.Health)
+	}
+
+	event = exploration.Advance()
+	if event != nil {
+		t.Error("Expected corridor to not generate events, got", event)
+	}
+
+	exploration.Advance()
+	if exploration.Position() != 2 {
+		t.Error("Expected explorer to stop at the end of the layout, got", exploration.Position())
+	}
+}
+
+func TestAdvanceWithoutLayout(t *testing.T) {
+	zone := NewZone("Test")
+	zone.AddItemDrop(0, 1, func(*Portal) Item { return nil })
+	zone.AddEventDrop(0, 1, func(*Portal, int) *PortalEvent {
+		return &PortalEvent{Gold: 5}
+	})
+
+	portal := &Portal{
+		PortalStone: PortalStone{Zone: zone},
+		seed:        rand.New(rand.NewSource(1)),
+	}
+	if err := zone.InitializePortal(portal); err != nil {
+		t.Fatal(err)
+	}
+	first := &Explorer{Portal: portal, Character: &Character{Health: 100}}
+	second := &Explorer{Portal: portal, Character: &Character{Health: 100}}
+
+	for i := 0; i < 2; i++ {
+		if event := first.Advance(); event == nil || event.Gold != 5 {
+			t.Fatal("Expected a drop event on every advance, got", event)
+		}
+		if event := second.Advance(); event == nil || event.Gold != 5 {
+			t.Fatal("Expected every explorer to get drop events, got", event)
+		}
+	}
+	if portal.cleared != 0 {
+		t.Error("Expected rooms to only be cleared with a layout, got", portal.cleared)
+	}
+}
@@ -0,0 +1,219 @@
+package sworld
+
+import (
+	"sort"
+)
+
+const (
+	// SortByLocation keeps the items in the order they are on the bags
+	SortByLocation = "location"
+	// SortByKind sorts the items by their kind
+	SortByKind = "kind"
+	// SortByLevel sorts the items by stone level
+	SortByLevel = "level"
+	// SortByDamage sorts the items by weapon damage
+	SortByDamage = "damage"
+)
+
+var itemKindOrder = map[string]int{
+	StoneItem:      0,
+	WeaponItem:     1,
+	ConsumableItem: 2,
+	BagItemKind:    3,
+	MaterialItem:   4,
+}
+
+// ItemFilter selects items from an inventory
+// Zero values mean the field is not used for filtering
+// Level filters only match stones, damage filters only match weapons
+type ItemFilter struct {
+	Kinds     []string
+	MinLevel  int
+	MaxLevel  int
+	ZoneID    string
+	MinDamage int
+	MaxDamage int
+}
+
+// InventoryQuery is a filter with sorting and pagination
+type InventoryQuery struct {
+	Filter     ItemFilter
+	SortBy     string
+	Descending bool
+	Offset     int
+	Limit      int
+}
+
+// InventoryItem is an item along with its location
+type InventoryItem struct {
+	Location ItemLocation
+	Item     Item
+}
+
+func itemLevel(item Item) (int, bool) {
+	stone, ok := item.(*PortalStone)
+	if !ok {
+		return 0, false
+	}
+	return stone.Level, true
+}
+
+func itemDamage(item Item) (int, bool) {
+	weapon, ok := item.(*Weapon)
+	if !ok {
+		return 0, false
+	}
+	return weapon.Damage, true
+}
+
+// Matches returns true if the item passes the filter
+func (f ItemFilter) Matches(item Item) bool {
+	if item == nil {
+		return false
+	}
+
+	if len(f.Kinds) > 0 {
+		kind := ItemKind(item)
+		found := false
+		for _, k := range f.Kinds {
+			if k == kind {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if f.MinLevel > 0 || f.MaxLevel > 0 {
+		level, ok := itemLevel(item)
+		if !ok || level < f.MinLevel || (f.MaxLevel > 0 && level > f.MaxLevel) {
+			return false
+		}
+	}
+
+	if f.ZoneID != "" {
+		stone, ok := item.(*PortalStone)
+		if !ok || stone.Zone == nil || stone.Zone.ID != f.ZoneID {
+			return false
+		}
+	}
+
+	if f.MinDamage > 0 || f.MaxDamage > 0 {
+		damage, ok := itemDamage(item)
+		if !ok || damage < f.MinDamage || (f.MaxDamage > 0 && damage > f.MaxDamage) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sortValue(item Item, sortBy string) int {
+	switch sortBy {
+	case SortByKind:
+		return itemKindOrder[ItemKind(item)]
+	case SortByLevel:
+		level, _ := itemLevel(item)
+		return level
+	case SortByDamage:
+		damage, _ := itemDamage(item)
+		return damage
+	}
+	return 0
+}
+
+// SearchItems returns the items from the bags matching the query, along
+// with the total amount of matches before pagination
+func SearchItems(bags []Bag, query InventoryQuery) ([]InventoryItem, int) {
+	found := make([]InventoryItem, 0)
+	for bagID, bag := range bags {
+		for slot, item := range bag.Items() {
+			if query.Filter.Matches(item) {
+				found = append(found, InventoryItem{
+					Location: ItemLocation{BagID: bagID, Slot: slot},
+					Item:     item,
+				})
+			}
+		}
+	}
+
+	if query.SortBy != "" && query.SortBy != SortByLocation {
+		sort.SliceStable(found, func(i, j int) bool {
+			a := sortValue(found[i].Item, query.SortBy)
+			b := sortValue(found[j].Item, query.SortBy)
+			if query.Descending {
+				return a > b
+			}
+			return a < b
+		})
+	} else if query.Descending {
+		for i, j := 0, len(found)-1; i < j; i, j = i+1, j-1 {
+			found[i], found[j] = found[j], found[i]
+		}
+	}
+
+	total := len(found)
+	if query.Offset > 0 {
+		if query.Offset >= total {
+			return []InventoryItem{}, total
+		}
+		found = found[query.Offset:]
+	}
+	if query.Limit > 0 && query.Limit < len(found) {
+		found = found[:query.Limit]
+	}
+
+	return found, total
+}
+
+// lessItem is the order used when sorting a bag: by kind first, then by
+// level and damage, higher first
+func lessItem(a Item, b Item) bool {
+	kindA := itemKindOrder[ItemKind(a)]
+	kindB := itemKindOrder[ItemKind(b)]
+	if kindA != kindB {
+		return kindA < kindB
+	}
+	if sortValue(a, SortByLevel) != sortValue(b, SortByLevel) {
+		return sortValue(a, SortByLevel) > sortValue(b, SortByLevel)
+	}
+	return sortValue(a, SortByDamage) > sortValue(b, SortByDamage)
+}
+
+// Sort merges the stacks on the bag and moves every item to the first
+// slots, sorted by kind
+func (b *StandardBag) Sort() {
+	items := make([]Item, 0, len(b.items))
+	for _, item := range b.items {
+		if item == nil {
+			continue
+		}
+
+		merged := false
+		for _, stack := range items {
+			target, source, ok := stackWith(stack, item)
+			if !ok {
+				continue
+			}
+			transferStack(source, target)
+			if source.Count() == 0 {
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			items = append(items, item)
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return lessItem(items[i], items[j])
+	})
+
+	for slot := range b.items {
+		b.items[slot] = nil
+	}
+	copy(b.items, items)
+}
@@ -0,0 +1,95 @@
+package sworld
+
+import (
+	"testing"
+)
+
+func buildInventory() []Bag {
+	bags := []Bag{NewStandardBag(4), NewStandardBag(4)}
+	bags[0].StoreItem(&PortalStone{Level: 1, Zone: zone1}, 0)
+	bags[0].StoreItem(&Weapon{Damage: 15}, 1)
+	bags[0].StoreItem(&PortalStone{Level: 4, Zone: zone1}, 3)
+	bags[1].StoreItem(&PortalStone{Level: 3, Zone: zone2}, 0)
+	bags[1].StoreItem(&PortalStone{Level: 5, Zone: zone1}, 2)
+	bags[1].StoreItem(&Weapon{Damage: 30}, 3)
+	return bags
+}
+
+func TestSearchItemsFilter(t *testing.T) {
+	bags := buildInventory()
+
+	items, total := SearchItems(bags, InventoryQuery{
+		Filter: ItemFilter{
+			Kinds:    []string{StoneItem},
+			MinLevel: 3,
+			ZoneID:   zone1.ID,
+		},
+	})
+	if total != 2 || len(items) != 2 {
+		t.Fatal("Expected to find 2 stones, got", total)
+	}
+	if items[0].Location.BagID != 0 || items[0].Location.Slot != 3 {
+		t.Error("Expected first stone to be at 0,3, got", items[0].Location)
+	}
+
+	items, total = SearchItems(bags, InventoryQuery{
+		Filter: ItemFilter{MinDamage: 20},
+	})
+	if total != 1 || items[0].Item.(*Weapon).Damage != 30 {
+		t.Error("Expected to find the 30 damage weapon, got", items)
+	}
+}
+
+func TestSearchItemsSortAndPaginate(t *testing.T) {
+	bags := buildInventory()
+
+	items, total := SearchItems(bags, InventoryQuery{
+		Filter:     ItemFilter{Kinds: []string{StoneItem}},
+		SortBy:     SortByLevel,
+		Descending: true,
+		Offset:     1,
+		Limit:      2,
+	})
+	if total != 4 {
+		t.Error("Expected total to be 4, got", total)
+	}
+	if len(items) != 2 {
+		t.Fatal("Expected a page of 2 items, got", len(items))
+	}
+	if items[0].Item.(*PortalStone).Level != 4 || items[1].Item.(*PortalStone).Level != 3 {
+		t.Error("Expected levels 4 and 3, got", items[0].Item, items[1].Item)
+	}
+
+	items, _ = SearchItems(bags, InventoryQuery{Offset: 10})
+	if len(items) != 0 {
+		t.Error("Expected no items past the end, got", len(items))
+	}
+}
+
+func TestSortBag(t *testing.T) {
+	bag := NewStandardBag(5)
+	bag.StoreItem(&Consumable{Kind: HealthPotion, Power: 10, Quantity: 2}, 0)
+	bag.StoreItem(&Weapon{Damage: 10}, 1)
+	bag.StoreItem(&Consumable{Kind: HealthPotion, Power: 10, Quantity: 3}, 2)
+	bag.StoreItem(&PortalStone{Level: 1}, 3)
+	bag.StoreItem(&PortalStone{Level: 2}, 4)
+
+	bag.Sort()
+
+	items := bag.Items()
+	if stone, ok := items[0].(*PortalStone); !ok || stone.Level != 2 {
+		t.Error("Expected the level 2 stone first, got", items[0])
+	}
+	if stone, ok := items[1].(*PortalStone); !ok || stone.Level != 1 {
+		t.Error("Expected the level 1 stone second, got", items[1])
+	}
+	if _, ok := items[2].(*Weapon); !ok {
+		t.Error("Expected the weapon third, got", items[2])
+	}
+	if potion, ok := items[3].(*Consumable); !ok || potion.Quantity != 5 {
+		t.Error("Expected the potions to be merged, got", items[3])
+	}
+	if items[4] != nil {
+		t.Error("Expected the last slot to be empty, got", items[4])
+	}
+}
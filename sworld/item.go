@@ -0,0 +1,32 @@
+package sworld
+
+const (
+	// StoneItem is the kind for portal stones
+	StoneItem = "stone"
+	// WeaponItem is the kind for weapons
+	WeaponItem = "weapon"
+	// ConsumableItem is the kind for consumables
+	ConsumableItem = "consumable"
+	// BagItemKind is the kind for bags that are not attached yet
+	BagItemKind = "bag"
+	// MaterialItem is the kind for crafting materials
+	MaterialItem = "material"
+)
+
+// ItemKind returns the kind of an item
+func ItemKind(item Item) string {
+	switch item.(type) {
+	case *PortalStone:
+		return StoneItem
+	case *Weapon:
+		return WeaponItem
+	case *Consumable:
+		return ConsumableItem
+	case *BagItem:
+		return BagItemKind
+	case *Material:
+		return MaterialItem
+	default:
+		return ""
+	}
+}
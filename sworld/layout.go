@@ -0,0 +1,152 @@
+package sworld
+
+import (
+	"math/rand"
+
+	"github.com/encryptio/alias"
+)
+
+// RoomType is the kind of room found at a position of a portal layout
+type RoomType int
+
+const (
+	// CorridorRoom is an empty step, nothing happens there
+	CorridorRoom RoomType = iota
+	// TreasureRoom holds an item
+	TreasureRoom
+	// AmbushRoom is full of enemies
+	AmbushRoom
+	// ShrineRoom grants a blessing to the first one reaching it
+	ShrineRoom
+	// RestRoom lets the character recover some health
+	RestRoom
+)
+
+var roomTypeNames = map[RoomType]string{
+	CorridorRoom: "corridor",
+	TreasureRoom: "treasure",
+	AmbushRoom:   "ambush",
+	ShrineRoom:   "shrine",
+	RestRoom:     "rest",
+}
+
+type roomRate struct {
+	room  RoomType
+	rate  float64
+	level int
+}
+
+// LayoutGenerator describes how the layout of a portal is generated
+// Generating a layout twice with the same seed and level gives the same result
+type LayoutGenerator struct {
+	// CorridorLength is the length of the corridor for a level 0 stone
+	CorridorLength int
+	// LengthPerLevel is added to the corridor length for each stone level
+	LengthPerLevel int
+	// Density is the chance for a step to be a room for a level 0 stone
+	Density float64
+	// DensityPerLevel is added to the density for each stone level
+	DensityPerLevel float64
+	// MaxDensity caps the density for high level stones
+	MaxDensity float64
+
+	rooms []roomRate
+}
+
+// Layout is the generated layout of a portal
+type Layout struct {
+	Seed  int64
+	Level int
+	// Rooms holds the room for each position, position 0 is the entrance
+	Rooms []RoomType
+}
+
+// String returns the name of the room type
+func (r RoomType) String() string {
+	return roomTypeNames[r]
+}
+
+// NewLayoutGenerator creates a layout generator
+func NewLayoutGenerator(length, lengthPerLevel int, density, densityPerLevel float64) *LayoutGenerator {
+	return &LayoutGenerator{
+		CorridorLength:  length,
+		LengthPerLevel:  lengthPerLevel,
+		Density:         density,
+		DensityPerLevel: densityPerLevel,
+		MaxDensity:      1,
+		rooms:           make([]roomRate, 0, 5),
+	}
+}
+
+// AddRoom registers a room type that can be generated from a given level
+func (g *LayoutGenerator) AddRoom(minLevel int, rate float64, room RoomType) {
+	g.rooms = append(g.rooms, roomRate{
+		room:  room,
+		rate:  rate,
+		level: minLevel,
+	})
+}
+
+// RoomDensity returns the chance for a step to be a room at a given level
+func (g LayoutGenerator) RoomDensity(level int) float64 {
+	density := g.Density + (g.DensityPerLevel * float64(level))
+	if density > g.MaxDensity {
+		return g.MaxDensity
+	}
+	return density
+}
+
+// Generate creates the layout for a given seed and stone level
+func (g LayoutGenerator) Generate(seed int64, level int) *Layout {
+	rng := rand.New(rand.NewSource(seed))
+
+	length := g.CorridorLength + (g.LengthPerLevel * level)
+	if length < 1 {
+		length = 1
+	}
+	layout := &Layout{
+		Seed:  seed,
+		Level: level,
+		Rooms: make([]RoomType, length+1),
+	}
+
+	available := make([]RoomType, 0, len(g.rooms))
+	rates := make([]float64, 0, len(g.rooms))
+	for _, room := range g.rooms {
+		if level >= room.level {
+			available = append(available, room.room)
+			rates = append(rates, room.rate)
+		}
+	}
+	if len(available) == 0 {
+		return layout
+	}
+
+	rooms, err := alias.New(rates)
+	if err != nil {
+		return layout
+	}
+
+	density := g.RoomDensity(level)
+	for position := 1; position <= length; position++ {
+		if rng.Float64() >= density {
+			continue
+		}
+		layout.Rooms[position] = available[rooms.Gen(rng)]
+	}
+
+	return layout
+}
+
+// Length is the last position of the layout
+func (l Layout) Length() int {
+	return len(l.Rooms) - 1
+}
+
+// RoomAt returns the room at a given position
+func (l Layout) RoomAt(position int) RoomType {
+	if position < 0 || position >= len(l.Rooms) {
+		return CorridorRoom
+	}
+	return l.Rooms[position]
+}
@@ -0,0 +1,75 @@
+package sworld
+
+import (
+	"testing"
+)
+
+func buildLayoutGenerator() *LayoutGenerator {
+	generator := NewLayoutGenerator(20, 5, 0.5, 0.1)
+	generator.AddRoom(0, 10, TreasureRoom)
+	generator.AddRoom(0, 10, RestRoom)
+	generator.AddRoom(2, 10, AmbushRoom)
+	return generator
+}
+
+func TestGenerateLayoutIsDeterministic(t *testing.T) {
+	generator := buildLayoutGenerator()
+
+	layout1 := generator.Generate(42, 3)
+	layout2 := generator.Generate(42, 3)
+
+	if len(layout1.Rooms) != len(layout2.Rooms) {
+		t.Fatal("Expected layouts to have the same length, got", len(layout1.Rooms), len(layout2.Rooms))
+	}
+	for position := range layout1.Rooms {
+		if layout1.Rooms[position] != layout2.Rooms[position] {
+			t.Error("Expected rooms at position", position, "to be equal")
+		}
+	}
+}
+
+func TestGenerateLayoutLength(t *testing.T) {
+	generator := buildLayoutGenerator()
+
+	layout := generator.Generate(1, 0)
+	if layout.Length() != 20 {
+		t.Error("Expected level 0 layout to have length 20, got", layout.Length())
+	}
+
+	layout = generator.Generate(1, 2)
+	if layout.Length() != 30 {
+		t.Error("Expected level 2 layout to have length 30, got", layout.Length())
+	}
+
+	if layout.RoomAt(0) != CorridorRoom {
+		t.Error("Expected the entrance to be a corridor, got", layout.RoomAt(0))
+	}
+	if layout.RoomAt(100) != CorridorRoom {
+		t.Error("Expected positions outside the layout to be a corridor")
+	}
+}
+
+func TestGenerateLayoutRoomLevels(t *testing.T) {
+	generator := buildLayoutGenerator()
+
+	for seed := int64(0); seed < 20; seed++ {
+		layout := generator.Generate(seed, 1)
+		for position, room := range layout.Rooms {
+			if room == AmbushRoom {
+				t.Fatal("Expected level 1 layout to not have ambush rooms, got one at", position)
+			}
+		}
+	}
+}
+
+func TestRoomDensity(t *testing.T) {
+	generator := buildLayoutGenerator()
+	generator.MaxDensity = 0.8
+
+	if generator.RoomDensity(0) != 0.5 {
+		t.Error("Expected density to be 0.5, got", generator.RoomDensity(0))
+	}
+	if generator.RoomDensity(10) != 0.8 {
+		t.Error("Expected density to be capped at 0.8, got", generator.RoomDensity(10))
+	}
+}
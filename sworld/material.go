@@ -0,0 +1,106 @@
+package sworld
+
+import (
+	"fmt"
+)
+
+// MaterialKind is the kind of a crafting material
+type MaterialKind int
+
+const (
+	// IronOre comes from weapons
+	IronOre MaterialKind = iota
+	// EssenceDust comes from magic items
+	EssenceDust
+	// StoneShard comes from portal stones
+	StoneShard
+)
+
+var materialNames = map[MaterialKind]string{
+	IronOre:     "iron_ore",
+	EssenceDust: "essence_dust",
+	StoneShard:  "stone_shard",
+}
+
+// materialStackSize is the maximum quantity of a material on a single slot
+const materialStackSize = 50
+
+// Material is used for crafting
+// A single slot can hold several of them
+type Material struct {
+	Kind     MaterialKind
+	Quantity int
+}
+
+// String returns the name of the material kind
+func (k MaterialKind) String() string {
+	return materialNames[k]
+}
+
+// MaterialKindFromName returns the material kind for a given name
+func MaterialKindFromName(name string) (MaterialKind, bool) {
+	for kind, kindName := range materialNames {
+		if kindName == name {
+			return kind, true
+		}
+	}
+	return 0, false
+}
+
+// StackKind groups materials of the same kind
+func (m Material) StackKind() string {
+	return fmt.Sprintf("material:%s", m.Kind)
+}
+
+// MaxStack returns the maximum quantity of a stack
+func (m Material) MaxStack() int {
+	return materialStackSize
+}
+
+// Count returns the quantity of the stack
+func (m Material) Count() int {
+	return m.Quantity
+}
+
+// SetCount sets the quantity of the stack
+func (m *Material) SetCount(count int) {
+	m.Quantity = count
+}
+
+// Split creates a new stack of the same material
+func (m Material) Split(count int) Stackable {
+	return &Material{
+		Kind:     m.Kind,
+		Quantity: count,
+	}
+}
+
+// Salvage breaks an item into materials
+// Items that can't be salvaged give nothing
+func Salvage(item Item) []*Material {
+	materials := make([]*Material, 0, 2)
+	add := func(kind MaterialKind, quantity int) {
+		if quantity > 0 {
+			materials = append(materials, &Material{Kind: kind, Quantity: quantity})
+		}
+	}
+
+	switch i := item.(type) {
+	case *Weapon:
+		add(IronOre, 1+(i.Damage/10))
+		add(EssenceDust, (2*int(i.Rarity))+len(i.Affixes))
+	case *PortalStone:
+		add(StoneShard, 1+i.Level)
+	}
+	return materials
+}
+
+// RandomMaterial returns a random material based on current portal
+func (p *Portal) RandomMaterial() *Material {
+	kind := MaterialKind(p.seed.Intn(len(materialNames)))
+
+	return &Material{
+		Kind:     kind,
+		Quantity: 1 + p.seed.Intn(2+p.PortalStone.Level),
+	}
+}
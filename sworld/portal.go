@@ -2,6 +2,7 @@ package sworld
 
 import (
 	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/encryptio/alias"
@@ -13,6 +14,8 @@ type Portal struct {
 	PortalStone PortalStone
 	User        *User
 	Character   *Character
+	Seed        int64
+	Layout      *Layout
 
 	// TODO: Would this be the same as checking for C != nil ?
 	IsOpen bool
@@ -20,13 +23,24 @@ type Portal struct {
 	// C is the channel that communicates the portal closing event
 	C chan bool
 
+	// LockCharacter is called before the portal changes a character, so the
+	// changes don't happen in the middle of an inventory change
+	LockCharacter func(*Character) func()
+
+	// mu guards IsOpen, startedAt, extended, cleared and waves, which are read
+	// by the explorers and requests while the portal goroutine updates them
+	mu         sync.Mutex
 	startedAt  time.Time
+	extended   time.Duration
+	extend     chan time.Duration
 	enemies    []*Enemy
 	explorers  []*Explorer
 	eventsRate *alias.Alias // TODO: rename eventDrops
 	drops      *alias.Alias
 	cleared    int
+	waves      int
 	seed       *rand.Rand
+	replay     *replayLog
 }
 
 // PortalEvent is generated by the portal and sent to a character
@@ -35,11 +49,42 @@ type PortalEvent struct {
 	Item  Item
 	Enemy *Enemy
 	Gold  int
+	Heal  int
+}
+
+// Open returns whether the portal is still open
+func (p *Portal) Open() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.IsOpen
 }
 
 // TimeLeft is the amount of time until the portal closes
 func (p *Portal) TimeLeft() time.Duration {
-	return p.PortalStone.Duration - time.Since(p.startedAt)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.timeLeft()
+}
+
+// timeLeft is TimeLeft for when the portal is already locked
+func (p *Portal) timeLeft() time.Duration {
+	return p.PortalStone.Duration + p.extended - time.Since(p.startedAt)
+}
+
+// Extend keeps the portal open for a longer time
+func (p *Portal) Extend(duration time.Duration) error {
+	if !p.Open() || p.extend == nil {
+		return ErrPortalIsClosed
+	}
+
+	select {
+	case p.extend <- duration:
+		return nil
+	case _, _ = <-p.C:
+		return ErrPortalIsClosed
+	}
 }
 
 // RandomEnemyEvent returns a random enemy
@@ -53,6 +98,12 @@ func (p *Portal) RandomEnemyEvent(position int) *PortalEvent {
 	// FIXME: I don't really like this cross-dependency
 	enemy.AddSkill(NewHitSkill(enemy))
 	p.enemies = append(p.enemies, enemy)
+	p.record(ReplayEvent{
+		Kind:     ReplaySpawn,
+		Target:   enemy.ID,
+		Position: position,
+		Health:   enemy.Health,
+	})
 
 	enemy.handleAttack()
 	enemy.handleMove()
@@ -60,6 +111,98 @@ func (p *Portal) RandomEnemyEvent(position int) *PortalEvent {
 	return &PortalEvent{Enemy: enemy}
 }
 
+// clearRoom marks the room at a position as cleared, it returns false when
+// an explorer already reached it
+func (p *Portal) clearRoom(position int) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cleared >= position {
+		return false
+	}
+	p.cleared = position
+	return true
+}
+
+// Explorers returns the explorers that entered the portal
+func (p *Portal) Explorers() []*Explorer {
+	explorers := make([]*Explorer, len(p.explorers))
+	copy(explorers, p.explorers)
+	return explorers
+}
+
+// Waves returns the amount of waves spawned by the portal
+func (p *Portal) Waves() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.waves
+}
+
+// NextWave returns the time until the next wave, or 0 if the portal
+// doesn't spawn waves
+func (p *Portal) NextWave() time.Duration {
+	interval := p.PortalStone.WaveInterval()
+	if interval == 0 || !p.Open() {
+		return 0
+	}
+
+	p.mu.Lock()
+	next := (interval * time.Duration(p.waves+1)) - time.Since(p.startedAt)
+	p.mu.Unlock()
+
+	if next < 0 {
+		return 0
+	}
+	return next
+}
+
+// SpawnWave spawns enemies next to every explorer still on the portal, and
+// drops an item for each of them
+// Waves don't depend on the explorers moving, they are spawned every
+// DropInterval of the portal stone
+func (p *Portal) SpawnWave() []*PortalEvent {
+	p.mu.Lock()
+	p.waves++
+	waves := p.waves
+	p.mu.Unlock()
+
+	p.record(ReplayEvent{Kind: ReplayWave, Value: waves})
+	events := make([]*PortalEvent, 0, len(p.explorers))
+
+	for _, explorer := range p.explorers {
+		character := explorer.Character
+		if character.Health <= 0 || character.portal != p {
+			continue
+		}
+
+		enemies := 1 + (p.PortalStone.Level / 3)
+		for i := 0; i < enemies; i++ {
+			event := p.RandomEnemyEvent(explorer.position)
+			if event != nil {
+				events = append(events, event)
+			}
+		}
+
+		item := p.PortalStone.Zone.DropItem(p)
+		if item != nil {
+			event := &PortalEvent{Item: item}
+			character.EncounterEvent(event)
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+// lockCharacter locks a character using LockCharacter, if it's set
+func (p *Portal) lockCharacter(character *Character) func() {
+	if p.LockCharacter == nil {
+		return func() {}
+	}
+	return p.LockCharacter(character)
+}
+
 // DeadEnemies returns the dead enemies on this portal
 func (p *Portal) DeadEnemies() []*Enemy {
 	enemies := make([]*Enemy, 0, len(p.enemies))
@@ -72,26 +215,39 @@ func (p *Portal) DeadEnemies() []*Enemy {
 }
 
 // OpenPortal opens a portal and sets a timer for closing it
-func OpenPortal(user *User, stone PortalStone, closeFn func(*Portal)) (*Portal, error) {
-	source := rand.NewSource(time.Now().UnixNano())
+// The seed generates the portal layout, a random one is used when it's 0
+func OpenPortal(user *User, stone PortalStone, seed int64, closeFn func(*Portal)) (*Portal, error) {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	source := rand.NewSource(seed)
 
 	p := &Portal{
 		ID:          RandomID(16),
 		PortalStone: stone,
 		IsOpen:      true,
 		User:        user,
+		Seed:        seed,
 		seed:        rand.New(source),
 		enemies:     make([]*Enemy, 0, 10),
 		explorers:   make([]*Explorer, 0, 1),
 		startedAt:   time.Now(),
+		replay:      &replayLog{},
 	}
 
-	stone.Zone.InitializePortal(p)
+	err := stone.Zone.InitializePortal(p)
+	if err != nil {
+		return nil, err
+	}
 
 	p.C = make(chan bool)
+	p.extend = make(chan time.Duration)
 	go func() {
 		defer func() {
+			p.record(ReplayEvent{Kind: ReplayClose})
+			p.mu.Lock()
 			p.IsOpen = false
+			p.mu.Unlock()
 			close(p.C)
 		}()
 
@@ -105,6 +261,15 @@ func OpenPortal(user *User, stone PortalStone, closeFn func(*Portal)) (*Portal,
 				return
 			case _, _ = <-tClose.C:
 				return
+			case duration := <-p.extend:
+				p.mu.Lock()
+				p.extended += duration
+				left := p.timeLeft()
+				p.mu.Unlock()
+
+				if tClose.Stop() {
+					tClose.Reset(left)
+				}
 			}
 		}
 	}()
@@ -15,12 +15,26 @@ var (
 	ErrIncompatibleStones = errors.New("Stones are not compatible")
 )
 
+// TODO: this should be on settings
+const (
+	// MinDropInterval is the shortest time between two waves of a portal
+	MinDropInterval = 5 * time.Second
+	// maxDropInterval is the longest interval a dropped stone can roll
+	maxDropInterval = 30 * time.Second
+	// dropIntervalChance is the chance (in percent) of a dropped stone
+	// rolling a drop interval
+	dropIntervalChance = 30
+)
+
 // PortalStone is used to open a portal
 type PortalStone struct {
-	Level        int
-	Zone         *Zone
-	Duration     time.Duration
+	Level    int
+	Zone     *Zone
+	Duration time.Duration
+	// DropInterval is how often the portal spawns a wave, stones without
+	// an interval don't spawn waves
 	DropInterval time.Duration
+	Modifiers    []StoneModifier
 }
 
 func (s PortalStone) minDuration(stone PortalStone) PortalStone {
@@ -30,13 +44,27 @@ func (s PortalStone) minDuration(stone PortalStone) PortalStone {
 	return stone
 }
 
+// maxInterval returns the stone with the slowest waves, a stone without
+// waves being the slowest
 func (s PortalStone) maxInterval(stone PortalStone) PortalStone {
-	if s.DropInterval > stone.DropInterval {
+	if s.DropInterval == 0 || (stone.DropInterval != 0 && s.DropInterval > stone.DropInterval) {
 		return s
 	}
 	return stone
 }
 
+// WaveInterval returns how often a portal opened with this stone spawns a
+// wave, or 0 if it doesn't
+func (s PortalStone) WaveInterval() time.Duration {
+	if s.DropInterval <= 0 {
+		return 0
+	}
+	if s.DropInterval < MinDropInterval {
+		return MinDropInterval
+	}
+	return s.DropInterval
+}
+
 func (s PortalStone) sortLevel(stone PortalStone) (PortalStone, PortalStone) {
 	if s.Level < stone.Level {
 		return s, stone
@@ -45,6 +73,9 @@ func (s PortalStone) sortLevel(stone PortalStone) (PortalStone, PortalStone) {
 }
 
 // Merge creates a new stone from two
+// Merging two stones of the same level keeps the modifiers of both and the
+// slowest drop interval, while extending a stone with a level 0 one keeps
+// the modifiers and drop interval of the first
 func (s PortalStone) Merge(stone PortalStone) (PortalStone, error) {
 	if s.Zone.ID != stone.Zone.ID {
 		return PortalStone{}, ErrIncompatibleZones
@@ -56,6 +87,7 @@ func (s PortalStone) Merge(stone PortalStone) (PortalStone, error) {
 			Zone:         s.Zone,
 			Duration:     s.minDuration(stone).Duration,
 			DropInterval: s.maxInterval(stone).DropInterval,
+			Modifiers:    mergeModifiers(s.Modifiers, stone.Modifiers),
 		}, nil
 	}
 
@@ -70,18 +102,20 @@ func (s PortalStone) Merge(stone PortalStone) (PortalStone, error) {
 			// TODO: Have a better error, maybe?
 			return PortalStone{}, ErrIncompatibleStones
 		}
+		// The level 0 stone only extends the duration
 		return PortalStone{
 			Level:        maxLevel.Level,
 			Zone:         s.Zone,
 			Duration:     maxLevel.Duration + (1 * time.Second),
-			DropInterval: s.maxInterval(stone).DropInterval,
+			DropInterval: maxLevel.DropInterval,
+			Modifiers:    mergeModifiers(maxLevel.Modifiers, nil),
 		}, nil
 	}
 	return PortalStone{}, ErrIncompatibleStones
 }
 
 // RandomPortalStone returns a random portal stone based on current portal
-func (p Portal) RandomPortalStone() *PortalStone {
+func (p *Portal) RandomPortalStone() *PortalStone {
 	maxDuration := int(p.PortalStone.Duration.Seconds() * 1.3)
 	minDuration := int(p.PortalStone.Duration.Seconds() * 0.8)
 	if maxDuration < 10 {
@@ -99,9 +133,17 @@ func (p Portal) RandomPortalStone() *PortalStone {
 	}
 	level := rand.Intn(p.PortalStone.Level + 1)
 
+	var interval time.Duration
+	if level > 0 && rand.Intn(100) < dropIntervalChance {
+		interval = MinDropInterval + time.Duration(rand.Int63n(int64(maxDropInterval-MinDropInterval)))
+		interval = interval.Truncate(time.Second)
+	}
+
 	return &PortalStone{
-		Level:    level,
-		Duration: time.Duration(seconds) * time.Second,
-		Zone:     p.PortalStone.Zone,
+		Level:        level,
+		Duration:     time.Duration(seconds) * time.Second,
+		Zone:         p.PortalStone.Zone,
+		DropInterval: interval,
+		Modifiers:    randomModifiers(level),
 	}
 }
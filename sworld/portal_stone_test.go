@@ -103,3 +103,44 @@ func TestMergeDifferentLevels(t *testing.T) {
 		t.Error("Expected merge to fail, got ", result)
 	}
 }
+
+func TestMergeDropInterval(t *testing.T) {
+	stone1 := PortalStone{
+		Level:        1,
+		Duration:     15 * time.Second,
+		Zone:         zone1,
+		DropInterval: 10 * time.Second,
+	}
+	stone2 := PortalStone{
+		Level:        1,
+		Duration:     15 * time.Second,
+		Zone:         zone1,
+		DropInterval: 20 * time.Second,
+	}
+	result, err := stone1.Merge(stone2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.DropInterval != stone2.DropInterval {
+		t.Error("Expected the slowest interval, got", result.DropInterval)
+	}
+
+	stone2.DropInterval = 0
+	result, _ = stone1.Merge(stone2)
+	if result.DropInterval != 0 {
+		t.Error("Expected no waves, got", result.DropInterval)
+	}
+
+	extender := PortalStone{
+		Level:    0,
+		Duration: 20 * time.Second,
+		Zone:     zone1,
+	}
+	result, err = extender.Merge(stone1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.DropInterval != stone1.DropInterval {
+		t.Error("Expected to keep the interval of the level 1 stone, got", result.DropInterval)
+	}
+}
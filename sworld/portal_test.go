@@ -53,6 +53,111 @@ func TestRandomEnemyEvent(t *testing.T) {
 	}
 }
 
+func TestOpenPortalWithSeed(t *testing.T) {
+	zone := buildZone(&PortalStone{})
+	zone.Layout = buildLayoutGenerator()
+	stone := PortalStone{
+		Level:    2,
+		Zone:     zone,
+		Duration: 50 * time.Millisecond,
+	}
+
+	portal1, err := OpenPortal(&User{}, stone, 42, func(*Portal) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	portal2, err := OpenPortal(&User{}, stone, 42, func(*Portal) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if portal1.Seed != 42 {
+		t.Error("Expected the portal to use the given seed, got", portal1.Seed)
+	}
+	for position, room := range portal1.Layout.Rooms {
+		if portal2.Layout.RoomAt(position) != room {
+			t.Error("Expected rooms at position", position, "to be equal")
+		}
+	}
+
+	portal3, err := OpenPortal(&User{}, stone, 0, func(*Portal) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if portal3.Seed == 0 {
+		t.Error("Expected a random seed when none is given")
+	}
+}
+
+func TestExtendPortal(t *testing.T) {
+	stone := PortalStone{
+		Level:    0,
+		Zone:     buildZone(&PortalStone{}),
+		Duration: 50 * time.Millisecond,
+	}
+	portal, err := OpenPortal(&User{}, stone, 0, func(*Portal) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := portal.Extend(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if portal.TimeLeft() <= 50*time.Millisecond {
+		t.Error("Expected portal time to be extended, got", portal.TimeLeft())
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if !portal.Open() {
+		t.Error("Expected extended portal to still be open")
+	}
+}
+
+func TestSpawnWave(t *testing.T) {
+	drop := &PortalStone{}
+	zone := buildZone(drop)
+	portal := &Portal{
+		seed:        rand.New(rand.NewSource(time.Now().UnixNano())),
+		IsOpen:      true,
+		C:           make(chan bool),
+		startedAt:   time.Now(),
+		PortalStone: PortalStone{Level: 3, Zone: zone, DropInterval: time.Second},
+	}
+	zone.InitializePortal(portal)
+
+	if portal.PortalStone.WaveInterval() != MinDropInterval {
+		t.Error("Expected the interval to be at least", MinDropInterval)
+	}
+	if portal.NextWave() <= 0 || portal.NextWave() > MinDropInterval {
+		t.Error("Expected the next wave to be on the first interval, got", portal.NextWave())
+	}
+
+	character := &Character{
+		Health: 10,
+		Bags:   []Bag{NewStandardBag(2)},
+	}
+	_, err := character.EnterPortal(portal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := portal.SpawnWave()
+	// Two enemies and an item
+	if len(events) != 3 {
+		t.Fatal("Expected 3 events, got", len(events))
+	}
+	if len(portal.enemies) != 2 {
+		t.Error("Expected 2 enemies, got", len(portal.enemies))
+	}
+	if item, _ := character.Bags[0].GetItem(0); item != drop {
+		t.Error("Expected the character to pick up the drop, got", item)
+	}
+	if portal.Waves() != 1 {
+		t.Error("Expected 1 wave, got", portal.Waves())
+	}
+	close(portal.C)
+}
+
 // FIXME: Find a way of testing this
 // func TestRandomItemEvent(t *testing.T) {
 //     source := rand.NewSource(time.Now().UnixNano())
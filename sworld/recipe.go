@@ -0,0 +1,202 @@
+package sworld
+
+import (
+	"errors"
+)
+
+var (
+	// ErrMissingMaterials is when the user doesn't have the materials for a recipe
+	ErrMissingMaterials = errors.New("Not enough materials")
+	// ErrNoCraftInput is when the recipe needs an item and none was given
+	ErrNoCraftInput = errors.New("The recipe needs an item")
+	// ErrMaxUpgrades is when the weapon was already upgraded too many times
+	ErrMaxUpgrades = errors.New("The weapon can't be upgraded anymore")
+)
+
+// MaxWeaponUpgrades is how many times a weapon can be upgraded
+const MaxWeaponUpgrades = 5
+
+// RecipeOutput is what a recipe does
+type RecipeOutput int
+
+const (
+	// CraftConsumable creates a consumable
+	CraftConsumable RecipeOutput = iota
+	// CraftStone creates a portal stone
+	CraftStone
+	// CraftUpgrade adds damage to the input weapon
+	CraftUpgrade
+	// CraftSalvage breaks the input item into materials
+	CraftSalvage
+)
+
+var recipeOutputNames = map[RecipeOutput]string{
+	CraftConsumable: "consumable",
+	CraftStone:      "stone",
+	CraftUpgrade:    "upgrade",
+	CraftSalvage:    "salvage",
+}
+
+// Ingredient is a material needed by a recipe
+type Ingredient struct {
+	Kind     MaterialKind
+	Quantity int
+}
+
+// Recipe describes how items are crafted
+type Recipe struct {
+	ID        string
+	Name      string
+	Materials []Ingredient
+	Gold      int
+	// Input is the kind of item the recipe takes from the bags, if any
+	// The item is used up, unless the recipe upgrades it
+	Input  string
+	Output RecipeOutput
+
+	// Consumable is created by CraftConsumable recipes
+	Consumable Consumable
+	// Stone is created by CraftStone recipes, the zone is set when crafting
+	Stone PortalStone
+	// Damage is added by CraftUpgrade recipes
+	Damage int
+}
+
+// String returns the name of the recipe output
+func (o RecipeOutput) String() string {
+	return recipeOutputNames[o]
+}
+
+// CountMaterial returns the quantity of a material on the user bags
+func (u User) CountMaterial(kind MaterialKind) int {
+	count := 0
+	for _, bag := range u.Bags {
+		for _, item := range bag.Items() {
+			material, ok := item.(*Material)
+			if ok && material.Kind == kind && !u.IsReserved(material) {
+				count += material.Quantity
+			}
+		}
+	}
+	return count
+}
+
+func (u *User) takeMaterial(kind MaterialKind, quantity int) error {
+	for _, bag := range u.Bags {
+		for slot, item := range bag.Items() {
+			if quantity == 0 {
+				return nil
+			}
+			material, ok := item.(*Material)
+			if !ok || material.Kind != kind || u.IsReserved(material) {
+				continue
+			}
+
+			taken := material.Quantity
+			if taken > quantity {
+				taken = quantity
+			}
+			material.Quantity -= taken
+			quantity -= taken
+			if material.Quantity == 0 {
+				bag.DropItem(slot)
+			}
+		}
+	}
+	if quantity > 0 {
+		return ErrMissingMaterials
+	}
+	return nil
+}
+
+// Craft runs a recipe, taking the materials and gold from the user
+// input is the location of the item used by the recipe, and zone is the
+// zone of the stones created. It returns where the resulting items are.
+func (u *User) Craft(recipe Recipe, input *ItemLocation, zone *Zone) ([]ItemLocation, error) {
+	var item Item
+	if recipe.Input != "" {
+		if input == nil {
+			return nil, ErrNoCraftInput
+		}
+		var err error
+		item, err = u.GetItem(*input)
+		if err != nil {
+			return nil, err
+		}
+		if ItemKind(item) != recipe.Input {
+			return nil, ErrWrongItem
+		}
+		if u.IsReserved(item) {
+			return nil, ErrItemReserved
+		}
+	}
+
+	locations := make([]ItemLocation, 0, 1)
+	err := u.Transaction(func() error {
+		err := u.SpendGold(recipe.Gold)
+		if err != nil {
+			return err
+		}
+		for _, ingredient := range recipe.Materials {
+			err = u.takeMaterial(ingredient.Kind, ingredient.Quantity)
+			if err != nil {
+				return err
+			}
+		}
+
+		if recipe.Output == CraftUpgrade {
+			weapon, ok := item.(*Weapon)
+			if !ok {
+				return ErrWrongItem
+			}
+			if weapon.Upgrades >= MaxWeaponUpgrades {
+				return ErrMaxUpgrades
+			}
+			weapon.Damage += recipe.Damage
+			weapon.Upgrades++
+			locations = append(locations, *input)
+			return nil
+		}
+
+		if item != nil {
+			err = u.DropItemAt(*input)
+			if err != nil {
+				return err
+			}
+		}
+
+		results := make([]Item, 0, 2)
+		switch recipe.Output {
+		case CraftConsumable:
+			consumable := recipe.Consumable
+			results = append(results, &consumable)
+		case CraftStone:
+			stone := recipe.Stone
+			stone.Zone = zone
+			if input, ok := item.(*PortalStone); ok {
+				stone.Zone = input.Zone
+			}
+			results = append(results, &stone)
+		case CraftSalvage:
+			for _, material := range Salvage(item) {
+				results = append(results, material)
+			}
+			if len(results) == 0 {
+				return ErrWrongItem
+			}
+		}
+
+		for _, result := range results {
+			location, err := u.PickupItem(result)
+			if err != nil {
+				return err
+			}
+			locations = append(locations, location)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return locations, nil
+}
@@ -0,0 +1,118 @@
+package sworld
+
+import (
+	"testing"
+)
+
+var potionRecipe = Recipe{
+	ID:        "potion",
+	Materials: []Ingredient{{Kind: EssenceDust, Quantity: 5}},
+	Gold:      10,
+	Output:    CraftConsumable,
+	Consumable: Consumable{
+		Kind:     HealthPotion,
+		Power:    40,
+		Quantity: 2,
+	},
+}
+
+func TestCraftConsumable(t *testing.T) {
+	user := &User{
+		Gold: 20,
+		Bags: []Bag{NewStandardBag(5)},
+	}
+	user.PickupItem(&Material{Kind: EssenceDust, Quantity: 3})
+	user.Bags[0].StoreItem(&Material{Kind: EssenceDust, Quantity: 4}, 2)
+
+	locations, err := user.Craft(potionRecipe, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locations) != 1 {
+		t.Fatal("Expected a single item, got", len(locations))
+	}
+	item, _ := user.GetItem(locations[0])
+	if potion, ok := item.(*Consumable); !ok || potion.Quantity != 2 {
+		t.Error("Expected two potions, got", item)
+	}
+	if user.CountMaterial(EssenceDust) != 2 {
+		t.Error("Expected 2 dust left, got", user.CountMaterial(EssenceDust))
+	}
+	if user.Gold != 10 {
+		t.Error("Expected 10 gold left, got", user.Gold)
+	}
+
+	_, err = user.Craft(potionRecipe, nil, nil)
+	if err != ErrMissingMaterials {
+		t.Error("Expected missing materials, got", err)
+	}
+	if user.CountMaterial(EssenceDust) != 2 || user.Gold != 10 {
+		t.Error("Expected a failed craft to not take anything")
+	}
+}
+
+func TestCraftUpgradeAndSalvage(t *testing.T) {
+	upgrade := Recipe{
+		Materials: []Ingredient{{Kind: IronOre, Quantity: 1}},
+		Input:     WeaponItem,
+		Output:    CraftUpgrade,
+		Damage:    5,
+	}
+	salvage := Recipe{
+		Input:  WeaponItem,
+		Output: CraftSalvage,
+	}
+
+	weapon := &Weapon{Damage: 20, Rarity: Rare, Affixes: []Affix{{}, {}}}
+	user := &User{
+		Bags: []Bag{NewStandardBag(5)},
+	}
+	location, _ := user.PickupItem(weapon)
+	user.PickupItem(&Material{Kind: IronOre, Quantity: 10})
+
+	if _, err := user.Craft(upgrade, nil, nil); err != ErrNoCraftInput {
+		t.Error("Expected the recipe to need an input, got", err)
+	}
+	for i := 0; i < MaxWeaponUpgrades; i++ {
+		if _, err := user.Craft(upgrade, &location, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if weapon.Damage != 45 {
+		t.Error("Expected damage to be 45, got", weapon.Damage)
+	}
+	if _, err := user.Craft(upgrade, &location, nil); err != ErrMaxUpgrades {
+		t.Error("Expected max upgrades, got", err)
+	}
+	if user.CountMaterial(IronOre) != 5 {
+		t.Error("Expected the failed upgrade to keep the ore, got", user.CountMaterial(IronOre))
+	}
+
+	locations, err := user.Craft(salvage, &location, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := user.FindItem(weapon); err != ErrItemNotFound {
+		t.Error("Expected the weapon to be salvaged")
+	}
+	// 1 + 45/10 ore goes to the existing stack, and 2*2 + 2 dust
+	if len(locations) != 2 {
+		t.Error("Expected two stacks of materials, got", len(locations))
+	}
+	if user.CountMaterial(IronOre) != 10 {
+		t.Error("Expected 10 ore, got", user.CountMaterial(IronOre))
+	}
+	if user.CountMaterial(EssenceDust) != 6 {
+		t.Error("Expected 6 dust, got", user.CountMaterial(EssenceDust))
+	}
+}
+
+func TestSalvageStone(t *testing.T) {
+	materials := Salvage(&PortalStone{Level: 3})
+	if len(materials) != 1 || materials[0].Kind != StoneShard || materials[0].Quantity != 4 {
+		t.Error("Expected 4 stone shards, got", materials)
+	}
+	if len(Salvage(&Consumable{})) != 0 {
+		t.Error("Expected consumables to give nothing")
+	}
+}
@@ -0,0 +1,266 @@
+package sworld
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// ReplayEventKind is the kind of something that happened on a portal
+type ReplayEventKind string
+
+const (
+	// ReplayEnter is a character entering the portal
+	ReplayEnter ReplayEventKind = "enter"
+	// ReplayLeave is a character returning to town
+	ReplayLeave ReplayEventKind = "leave"
+	// ReplaySpawn is an enemy spawning
+	ReplaySpawn ReplayEventKind = "spawn"
+	// ReplayMove is a character or an enemy changing its position
+	ReplayMove ReplayEventKind = "move"
+	// ReplaySkill is a skill being used against a target
+	ReplaySkill ReplayEventKind = "skill"
+	// ReplayDamage is a character or an enemy receiving damage
+	ReplayDamage ReplayEventKind = "damage"
+	// ReplayHeal is a character being healed
+	ReplayHeal ReplayEventKind = "heal"
+	// ReplayDeath is a character or an enemy dying
+	ReplayDeath ReplayEventKind = "death"
+	// ReplayDrop is an item picked up by a character
+	ReplayDrop ReplayEventKind = "drop"
+	// ReplayGold is gold found by a character
+	ReplayGold ReplayEventKind = "gold"
+	// ReplayWave is a wave being spawned
+	ReplayWave ReplayEventKind = "wave"
+	// ReplayClose is the portal closing
+	ReplayClose ReplayEventKind = "close"
+)
+
+// TODO: this should be on settings
+// maxReplayEvents is the amount of events recorded for a single portal,
+// the events after that are discarded
+const maxReplayEvents = 10000
+
+// ReplayEvent is an entry of the portal event log
+// Source and Target are the IDs of the characters and enemies involved
+type ReplayEvent struct {
+	// At is the time since the portal was opened
+	At       time.Duration
+	Kind     ReplayEventKind
+	Source   string
+	Target   string
+	Position int
+	// Value is the damage, heal or gold, depending on the kind
+	Value int
+	// Health is the health of the target after the event
+	Health int
+	Skill  string
+	Item   Item
+}
+
+// replayLog is the ordered list of events of a portal
+type replayLog struct {
+	mu     sync.Mutex
+	events []ReplayEvent
+}
+
+// replayActor is something that takes part on the replay
+type replayActor interface {
+	replayID() string
+}
+
+// replayTarget is a skill target that is on a portal
+type replayTarget interface {
+	replayActor
+	replayPortal() *Portal
+}
+
+func (e *Enemy) replayID() string {
+	return e.ID
+}
+
+func (e *Enemy) replayPortal() *Portal {
+	return e.portal
+}
+
+func (c *Character) replayID() string {
+	return c.ID
+}
+
+func (e *Explorer) replayID() string {
+	return e.Character.ID
+}
+
+func (e *Explorer) replayPortal() *Portal {
+	return e.Portal
+}
+
+func actorID(actor interface{}) string {
+	if a, ok := actor.(replayActor); ok {
+		return a.replayID()
+	}
+	return ""
+}
+
+// record adds an event to the replay of the portal
+// Portals without a replay log don't record anything
+func (p *Portal) record(event ReplayEvent) {
+	if p == nil || p.replay == nil {
+		return
+	}
+	if !p.startedAt.IsZero() {
+		event.At = time.Since(p.startedAt)
+	}
+
+	p.replay.mu.Lock()
+	defer p.replay.mu.Unlock()
+
+	if len(p.replay.events) < maxReplayEvents {
+		p.replay.events = append(p.replay.events, event)
+	}
+}
+
+// Replay returns the events recorded on the portal, in order
+func (p *Portal) Replay() []ReplayEvent {
+	if p.replay == nil {
+		return []ReplayEvent{}
+	}
+	p.replay.mu.Lock()
+	defer p.replay.mu.Unlock()
+
+	events := make([]ReplayEvent, len(p.replay.events))
+	copy(events, p.replay.events)
+	return events
+}
+
+// ReplayActorState is the state of a character or an enemy during a replay
+type ReplayActorState struct {
+	ID        string
+	Enemy     bool
+	Position  int
+	Health    int
+	MaxHealth int
+	Alive     bool
+	// Present is false for characters that left the portal
+	Present bool
+}
+
+// ReplayFrame is the state of the portal after an event
+type ReplayFrame struct {
+	Step   int
+	Event  *ReplayEvent
+	Actors []ReplayActorState
+	Gold   int
+	Items  int
+	Waves  int
+	Closed bool
+}
+
+// Replayer steps through the events of a portal, rebuilding its state
+type Replayer struct {
+	events []ReplayEvent
+	step   int
+	actors map[string]*ReplayActorState
+	gold   int
+	items  int
+	waves  int
+	closed bool
+}
+
+// NewReplayer creates a replayer at the beginning of the events
+func NewReplayer(events []ReplayEvent) *Replayer {
+	return &Replayer{
+		events: events,
+		actors: make(map[string]*ReplayActorState),
+	}
+}
+
+func (r *Replayer) actor(id string) *ReplayActorState {
+	actor, ok := r.actors[id]
+	if !ok {
+		actor = &ReplayActorState{ID: id, Alive: true, Present: true}
+		r.actors[id] = actor
+	}
+	return actor
+}
+
+// Len returns the amount of events of the replay
+func (r *Replayer) Len() int {
+	return len(r.events)
+}
+
+// Step applies the next event, it returns false when there are no more
+// events
+func (r *Replayer) Step() bool {
+	if r.step >= len(r.events) {
+		return false
+	}
+	event := r.events[r.step]
+	r.step++
+
+	switch event.Kind {
+	case ReplayEnter:
+		actor := r.actor(event.Target)
+		actor.Position = event.Position
+		actor.Health = event.Health
+		actor.MaxHealth = event.Value
+		actor.Present = true
+	case ReplayLeave:
+		r.actor(event.Target).Present = false
+	case ReplaySpawn:
+		actor := r.actor(event.Target)
+		actor.Enemy = true
+		actor.Position = event.Position
+		actor.Health = event.Health
+		actor.MaxHealth = event.Health
+	case ReplayMove:
+		r.actor(event.Target).Position = event.Position
+	case ReplayDamage, ReplayHeal:
+		r.actor(event.Target).Health = event.Health
+	case ReplayDeath:
+		actor := r.actor(event.Target)
+		actor.Health = 0
+		actor.Alive = false
+	case ReplayDrop:
+		r.items++
+	case ReplayGold:
+		r.gold += event.Value
+	case ReplayWave:
+		r.waves++
+	case ReplayClose:
+		r.closed = true
+	}
+	return true
+}
+
+// Seek moves the replay to the state after a given amount of events
+func (r *Replayer) Seek(step int) {
+	if step < r.step {
+		*r = *NewReplayer(r.events)
+	}
+	for r.step < step && r.Step() {
+	}
+}
+
+// Frame returns the current state of the replay
+func (r *Replayer) Frame() ReplayFrame {
+	frame := ReplayFrame{
+		Step:   r.step,
+		Actors: make([]ReplayActorState, 0, len(r.actors)),
+		Gold:   r.gold,
+		Items:  r.items,
+		Waves:  r.waves,
+		Closed: r.closed,
+	}
+	if r.step > 0 {
+		event := r.events[r.step-1]
+		frame.Event = &event
+	}
+	for _, actor := range r.actors {
+		frame.Actors = append(frame.Actors, *actor)
+	}
+	sort.Slice(frame.Actors, func(i, j int) bool {
+		return frame.Actors[i].ID < frame.Actors[j].ID
+	})
+	return frame
+}
@@ -0,0 +1,83 @@
+package sworld
+
+import (
+	"testing"
+)
+
+func TestPortalReplay(t *testing.T) {
+	portal := &Portal{
+		ID:          "portal",
+		IsOpen:      true,
+		C:           make(chan bool),
+		PortalStone: PortalStone{Level: 1},
+		replay:      &replayLog{},
+	}
+	character := &Character{
+		ID:        "character",
+		Level:     1,
+		Health:    50,
+		MaxHealth: 100,
+		User:      &User{},
+		Bags:      []Bag{NewStandardBag(2)},
+	}
+	explorer, err := character.EnterPortal(portal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	explorer.Advance()
+	event := portal.RandomEnemyEvent(1)
+	enemy := event.Enemy
+	for enemy.Health > 0 {
+		NewHitSkill(character).Use(enemy)
+	}
+	character.EncounterEvent(&PortalEvent{Gold: 10, Heal: 5})
+	character.ReturnToTown(portal)
+	close(portal.C)
+
+	events := portal.Replay()
+	kinds := make(map[ReplayEventKind]int)
+	for _, event := range events {
+		kinds[event.Kind]++
+	}
+	for _, kind := range []ReplayEventKind{
+		ReplayEnter, ReplayMove, ReplaySpawn, ReplaySkill, ReplayDamage,
+		ReplayDeath, ReplayGold, ReplayHeal, ReplayLeave,
+	} {
+		if kinds[kind] == 0 {
+			t.Error("Expected the replay to have a", kind, "event")
+		}
+	}
+	if events[0].Kind != ReplayEnter || events[len(events)-1].Kind != ReplayLeave {
+		t.Error("Expected the events to be in order, got", events[0].Kind, events[len(events)-1].Kind)
+	}
+
+	replayer := NewReplayer(events)
+	replayer.Seek(3)
+	frame := replayer.Frame()
+	if frame.Step != 3 || frame.Event.Kind != ReplaySpawn {
+		t.Fatal("Expected the third event to be the spawn, got", frame.Event)
+	}
+	if len(frame.Actors) != 2 {
+		t.Fatal("Expected a character and an enemy, got", frame.Actors)
+	}
+
+	replayer.Seek(replayer.Len())
+	frame = replayer.Frame()
+	for _, actor := range frame.Actors {
+		if actor.Enemy && actor.Alive {
+			t.Error("Expected the enemy to be dead at the end")
+		}
+		if !actor.Enemy && (actor.Present || actor.Position != 1 || actor.Health != 55) {
+			t.Error("Expected the character to leave at position 1 with 55 health, got", actor)
+		}
+	}
+	if frame.Gold != 10 {
+		t.Error("Expected 10 gold, got", frame.Gold)
+	}
+
+	replayer.Seek(1)
+	if replayer.Frame().Gold != 0 || len(replayer.Frame().Actors) != 1 {
+		t.Error("Expected seeking back to rebuild the state")
+	}
+}
@@ -0,0 +1,88 @@
+package sworld
+
+import (
+	"time"
+)
+
+// RunResult is how a portal run ended
+type RunResult string
+
+const (
+	// RunCompleted is a run where the character was alive when it ended
+	RunCompleted RunResult = "completed"
+	// RunDied is a run where the character died
+	RunDied RunResult = "died"
+)
+
+// runStats is what a character achieved on its current portal run
+type runStats struct {
+	startedAt time.Time
+	gold      int
+	kills     int
+	items     []Item
+}
+
+// RunRecord is the summary of a character run on a portal
+type RunRecord struct {
+	ID          string
+	PortalID    string
+	UserID      string
+	CharacterID string
+	Stone       PortalStone
+	Seed        int64
+	StartedAt   time.Time
+	EndedAt     time.Time
+	// Depth is the furthest position reached
+	Depth         int
+	EnemiesKilled int
+	Gold          int
+	Items         []Item
+	Waves         int
+	Result        RunResult
+}
+
+// Duration returns how long the run took
+func (r RunRecord) Duration() time.Duration {
+	return r.EndedAt.Sub(r.StartedAt)
+}
+
+// Killed counts the enemies killed by the character and gives it experience
+func (c *Character) Killed(target SkillTarget) {
+	if enemy, ok := target.(*Enemy); ok {
+		c.enemies++
+		c.run.kills++
+		c.gainXP(enemy)
+	}
+}
+
+// Record returns the summary of the run of the explorer
+func (e *Explorer) Record() *RunRecord {
+	c := e.Character
+	result := RunCompleted
+	if c.Health <= 0 {
+		result = RunDied
+	}
+
+	items := make([]Item, len(c.run.items))
+	copy(items, c.run.items)
+
+	record := &RunRecord{
+		ID:            RandomID(16),
+		PortalID:      e.Portal.ID,
+		CharacterID:   c.ID,
+		Stone:         e.Portal.PortalStone,
+		Seed:          e.Portal.Seed,
+		StartedAt:     c.run.startedAt,
+		EndedAt:       time.Now(),
+		Depth:         e.position,
+		EnemiesKilled: c.run.kills,
+		Gold:          c.run.gold,
+		Items:         items,
+		Waves:         e.Portal.Waves(),
+		Result:        result,
+	}
+	if c.User != nil {
+		record.UserID = c.User.ID
+	}
+	return record
+}
@@ -0,0 +1,67 @@
+package sworld
+
+import (
+	"testing"
+)
+
+func TestRunRecord(t *testing.T) {
+	portal := &Portal{
+		ID:          "portal",
+		Seed:        42,
+		IsOpen:      true,
+		C:           make(chan bool),
+		PortalStone: PortalStone{Level: 1},
+	}
+	user := &User{ID: "user"}
+	character := &Character{
+		ID:     "character",
+		Level:  1,
+		Health: 10,
+		User:   user,
+		Bags:   []Bag{NewStandardBag(2)},
+	}
+	explorer, err := character.EnterPortal(portal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	drop := &PortalStone{}
+	character.EncounterEvent(&PortalEvent{Gold: 30, Item: drop})
+	explorer.position = 4
+
+	enemy := &Enemy{Health: 5, D: make(chan bool)}
+	NewHitSkill(character).Use(enemy)
+
+	character.ReturnToTown(portal)
+	record := explorer.Record()
+
+	if record.UserID != "user" || record.CharacterID != "character" || record.PortalID != "portal" {
+		t.Error("Expected the record to belong to the run, got", record)
+	}
+	if record.Seed != 42 || record.Stone.Level != 1 {
+		t.Error("Expected the record to have the stone and seed, got", record.Stone, record.Seed)
+	}
+	if record.Gold != 30 || record.Depth != 4 || record.EnemiesKilled != 1 {
+		t.Error("Expected 30 gold, depth 4 and 1 kill, got", record.Gold, record.Depth, record.EnemiesKilled)
+	}
+	if len(record.Items) != 1 || record.Items[0] != drop {
+		t.Error("Expected the dropped stone, got", record.Items)
+	}
+	if record.Result != RunCompleted {
+		t.Error("Expected the run to be completed, got", record.Result)
+	}
+
+	if user.Gold != 30 || character.Gold != 0 {
+		t.Error("Expected the gold to be given to the user, got", user.Gold, character.Gold)
+	}
+	character.ReturnToTown(portal)
+	if user.Gold != 30 {
+		t.Error("Expected the gold to be given only once, got", user.Gold)
+	}
+
+	character.Health = 0
+	if explorer.Record().Result != RunDied {
+		t.Error("Expected the run to end with the character dead")
+	}
+	close(portal.C)
+}
@@ -1,15 +1,46 @@
 package sworld
 
-import "time"
+import (
+	"math/rand"
+	"time"
+)
 
 // SkillSource represents a source for a skill
 type SkillSource interface {
 	Damage() int
 }
 
+// CriticalSkillSource is a skill source that can deal critical hits
+type CriticalSkillSource interface {
+	CriticalChance() int
+}
+
+// LifeStealSkillSource is a skill source that recovers health from the
+// damage it deals
+type LifeStealSkillSource interface {
+	LifeSteal(damage int)
+}
+
+// KillerSkillSource is a skill source that keeps track of its kills
+type KillerSkillSource interface {
+	Killed(target SkillTarget)
+}
+
+// CooldownSkillSource is a skill source that reduces the cooldown of its skills
+type CooldownSkillSource interface {
+	CooldownReduction() int
+}
+
+// PowerSkillSource is a skill source that improves some of its skills
+type PowerSkillSource interface {
+	SkillPower(skill string) int
+}
+
 // SkillTarget represents the target for a skill
 type SkillTarget interface {
-	ReceiveDamage(source Skill, amount int) int
+	// ReceiveDamage returns the health left, and whether this damage killed
+	// the target
+	ReceiveDamage(source Skill, amount int) (int, bool)
 }
 
 // Skill represents a skill
@@ -23,6 +54,9 @@ type HitSkill struct {
 	lastUse  time.Time
 	source   SkillSource
 	cooldown time.Duration
+	name     string
+	// power is the percentage of the source damage dealt
+	power int
 }
 
 // NewHitSkill creates a new HitSkill
@@ -30,27 +64,67 @@ func NewHitSkill(source SkillSource) *HitSkill {
 	return &HitSkill{
 		cooldown: time.Millisecond * 500,
 		source:   source,
+		name:     "hit",
+		power:    100,
 	}
 }
 
+// Name returns the name of the skill
+func (h *HitSkill) Name() string {
+	return h.name
+}
+
 // WaitTime is the time before this skill can be used
 func (h *HitSkill) WaitTime() time.Duration {
 	if h.lastUse.IsZero() {
 		return 0
 	}
 
+	cooldown := h.cooldown
+	if source, ok := h.source.(CooldownSkillSource); ok {
+		cooldown -= (cooldown * time.Duration(source.CooldownReduction())) / 100
+	}
+
 	elapsed := time.Since(h.lastUse)
-	if elapsed >= h.cooldown {
+	if elapsed >= cooldown {
 		return 0
 	}
-	return h.cooldown - elapsed
+	return cooldown - elapsed
 }
 
 // Use the skill againgst a target
 func (h *HitSkill) Use(target SkillTarget) error {
-	damage := h.source.Damage()
+	power := h.power
+	if source, ok := h.source.(PowerSkillSource); ok {
+		power += source.SkillPower(h.name)
+	}
+	damage := (h.source.Damage() * power) / 100
+
+	if critical, ok := h.source.(CriticalSkillSource); ok {
+		if rand.Intn(100) < critical.CriticalChance() {
+			damage *= 2
+		}
+	}
 
-	target.ReceiveDamage(h, damage)
+	if t, ok := target.(replayTarget); ok {
+		t.replayPortal().record(ReplayEvent{
+			Kind:   ReplaySkill,
+			Source: actorID(h.source),
+			Target: t.replayID(),
+			Skill:  h.name,
+			Value:  damage,
+		})
+	}
+
+	if _, killed := target.ReceiveDamage(h, damage); killed {
+		if killer, ok := h.source.(KillerSkillSource); ok {
+			killer.Killed(target)
+		}
+	}
+
+	if stealer, ok := h.source.(LifeStealSkillSource); ok {
+		stealer.LifeSteal(damage)
+	}
 
 	h.lastUse = time.Now()
 	return nil
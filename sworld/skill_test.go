@@ -14,3 +14,31 @@ func TestSkill(t *testing.T) {
 		t.Error("Expected enemy to have received damage, but Health is", enemy.Health)
 	}
 }
+
+func TestSkillSameEnemyKilledOnce(t *testing.T) {
+	enemy := &Enemy{Level: 1, Health: 5, D: make(chan bool)}
+	first := &Character{Level: 1}
+	second := &Character{Level: 1}
+
+	done := make(chan bool)
+	for _, char := range []*Character{first, second} {
+		go func(char *Character) {
+			NewHitSkill(char).Use(enemy)
+			done <- true
+		}(char)
+	}
+	<-done
+	<-done
+
+	if enemy.Health != 0 {
+		t.Error("Expected the enemy to be dead, got", enemy.Health)
+	}
+	if first.run.kills+second.run.kills != 1 {
+		t.Error("Expected a single kill, got", first.run.kills, second.run.kills)
+	}
+	select {
+	case <-enemy.D:
+	default:
+		t.Error("Expected the enemy to be done")
+	}
+}
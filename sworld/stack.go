@@ -0,0 +1,87 @@
+package sworld
+
+import (
+	"errors"
+)
+
+var (
+	// ErrIncompatibleStacks is when two items can't be stacked together
+	ErrIncompatibleStacks = errors.New("Those items can't be stacked together")
+	// ErrStackFull is when a stack can't hold more items
+	ErrStackFull = errors.New("The stack is full")
+	// ErrInvalidQuantity is when the quantity is not valid for a stack
+	ErrInvalidQuantity = errors.New("That's an invalid quantity")
+)
+
+// Stackable is an item that can be stacked on a single bag slot
+type Stackable interface {
+	Item
+
+	// StackKind is the same for items that can be stacked together
+	StackKind() string
+	// MaxStack is the maximum quantity a single slot can hold
+	MaxStack() int
+	// Count is the quantity on the stack
+	Count() int
+	SetCount(count int)
+	// Split creates a new stack with the same kind and a given quantity
+	Split(count int) Stackable
+}
+
+// stackWith returns the stack an item can be merged into, if any
+func stackWith(stack Item, item Item) (Stackable, Stackable, bool) {
+	target, ok := stack.(Stackable)
+	if !ok {
+		return nil, nil, false
+	}
+	source, ok := item.(Stackable)
+	if !ok {
+		return nil, nil, false
+	}
+	if target.StackKind() != source.StackKind() {
+		return nil, nil, false
+	}
+	return target, source, true
+}
+
+// canStack returns true if the whole item fits on the stack
+func canStack(stack Item, item Item) bool {
+	target, source, ok := stackWith(stack, item)
+	if !ok {
+		return false
+	}
+	return target.Count()+source.Count() <= target.MaxStack()
+}
+
+// transferStack moves as many items as possible from one stack to another
+// It returns the quantity moved
+func transferStack(source Stackable, target Stackable) int {
+	space := target.MaxStack() - target.Count()
+	amount := source.Count()
+	if amount > space {
+		amount = space
+	}
+	if amount <= 0 {
+		return 0
+	}
+
+	target.SetCount(target.Count() + amount)
+	source.SetCount(source.Count() - amount)
+
+	return amount
+}
+
+// splitStack takes a given quantity from a stack into a new one
+func splitStack(item Item, count int) (Item, error) {
+	stack, ok := item.(Stackable)
+	if !ok {
+		return nil, ErrWrongItem
+	}
+	if count <= 0 || count >= stack.Count() {
+		return nil, ErrInvalidQuantity
+	}
+
+	stack.SetCount(stack.Count() - count)
+
+	return stack.Split(count), nil
+}
@@ -0,0 +1,152 @@
+package sworld
+
+import (
+	"testing"
+)
+
+func TestFindEmptySlotPrefersStacks(t *testing.T) {
+	bag := NewStandardBag(3)
+	bag.StoreItem(&PortalStone{}, 0)
+	bag.StoreItem(&Consumable{Kind: HealthPotion, Power: 10, Quantity: 5}, 2)
+
+	slot, err := bag.FindEmptySlot(&Consumable{Kind: HealthPotion, Power: 10, Quantity: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slot != 2 {
+		t.Error("Expected potion to go to the existing stack, got slot", slot)
+	}
+
+	slot, err = bag.FindEmptySlot(&Consumable{Kind: HealthPotion, Power: 20, Quantity: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slot != 1 {
+		t.Error("Expected a different potion to go to an empty slot, got slot", slot)
+	}
+
+	slot, err = bag.FindEmptySlot(&Consumable{Kind: HealthPotion, Power: 10, Quantity: 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slot != 1 {
+		t.Error("Expected an overflowing stack to go to an empty slot, got slot", slot)
+	}
+}
+
+func TestStoreItemMergesStacks(t *testing.T) {
+	bag := NewStandardBag(1)
+	stack := &Consumable{Kind: HealthPotion, Power: 10, Quantity: 5}
+	bag.StoreItem(stack, 0)
+
+	err := bag.StoreItem(&Consumable{Kind: HealthPotion, Power: 10, Quantity: 3}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stack.Quantity != 8 {
+		t.Error("Expected stack to have 8 potions, got", stack.Quantity)
+	}
+
+	err = bag.StoreItem(&Consumable{Kind: HealthPotion, Power: 10, Quantity: 13}, 0)
+	if err != ErrStackFull {
+		t.Error("Expected stack to be full, got", err)
+	}
+	err = bag.StoreItem(&PortalStone{}, 0)
+	if err != ErrNotEmptyBagSlot {
+		t.Error("Expected slot to not be empty, got", err)
+	}
+}
+
+func TestUserSplitAndMergeStacks(t *testing.T) {
+	user := User{Bags: []Bag{NewStandardBag(2), NewStandardBag(1)}}
+	stack := &Consumable{Kind: HealthPotion, Power: 10, Quantity: 18}
+	user.Bags[0].StoreItem(stack, 0)
+
+	location, err := user.SplitStack(ItemLocation{BagID: 0, Slot: 0}, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if location.BagID != 0 || location.Slot != 1 {
+		t.Error("Expected split stack to be at 0,1, got", location)
+	}
+	if stack.Quantity != 13 {
+		t.Error("Expected stack to have 13 potions left, got", stack.Quantity)
+	}
+
+	_, err = user.SplitStack(ItemLocation{BagID: 0, Slot: 0}, 13)
+	if err != ErrInvalidQuantity {
+		t.Error("Expected splitting the whole stack to fail, got", err)
+	}
+
+	user.Bags[1].StoreItem(&Consumable{Kind: HealthPotion, Power: 10, Quantity: 10}, 0)
+	err = user.MergeStacks(ItemLocation{BagID: 1, Slot: 0}, ItemLocation{BagID: 0, Slot: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stack.Quantity != 20 {
+		t.Error("Expected stack to be full, got", stack.Quantity)
+	}
+	item, err := user.GetItem(ItemLocation{BagID: 1, Slot: 0})
+	if err != nil {
+		t.Fatal("Expected the remaining potions to stay on the source, got", err)
+	}
+	if item.(*Consumable).Quantity != 3 {
+		t.Error("Expected 3 potions to remain, got", item.(*Consumable).Quantity)
+	}
+
+	err = user.MergeStacks(location, ItemLocation{BagID: 1, Slot: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = user.GetItem(location); err != ErrEmptyBagSlot {
+		t.Error("Expected the merged stack to be removed, got", err)
+	}
+}
+
+func TestCharacterSplitAndMergeStacks(t *testing.T) {
+	character := &Character{ID: "character", Bags: []Bag{NewStandardBag(2)}}
+	user := User{
+		Bags:       []Bag{NewStandardBag(2)},
+		Characters: []*Character{character},
+	}
+	stack := &Consumable{Kind: HealthPotion, Power: 10, Quantity: 10}
+	character.Bags[0].StoreItem(stack, 0)
+
+	source := ItemLocation{CharacterID: "character", BagID: 0, Slot: 0}
+	location, err := user.SplitStack(source, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if location.CharacterID != "character" || location.BagID != 0 || location.Slot != 1 {
+		t.Error("Expected split stack to be on the character at 0,1, got", location)
+	}
+	if items := user.Bags[0].Items(); items[0] != nil || items[1] != nil {
+		t.Error("Expected the user bags to not change, got", items)
+	}
+
+	item, err := user.GetItem(location)
+	if err != nil || item.(*Consumable).Quantity != 4 {
+		t.Fatal("Expected 4 potions on the new stack, got", item, err)
+	}
+
+	if err := user.MergeStacks(location, source); err != nil {
+		t.Fatal(err)
+	}
+	if stack.Quantity != 10 {
+		t.Error("Expected the stacks to be merged back, got", stack.Quantity)
+	}
+	if _, err := user.GetItem(location); err != ErrEmptyBagSlot {
+		t.Error("Expected the merged stack to be removed from the character, got", err)
+	}
+
+	unknown := ItemLocation{CharacterID: "unknown", BagID: 0, Slot: 0}
+	if _, err := user.SplitStack(unknown, 1); err != ErrCharacterNotFound {
+		t.Error("Expected an unknown character to fail, got", err)
+	}
+	if err := user.MergeStacks(unknown, source); err != ErrCharacterNotFound {
+		t.Error("Expected an unknown character to fail, got", err)
+	}
+	if _, err := user.GetItem(unknown); err != ErrCharacterNotFound {
+		t.Error("Expected an unknown character to fail, got", err)
+	}
+}
@@ -0,0 +1,191 @@
+package sworld
+
+import (
+	"sort"
+)
+
+// StoneMerge is a single merge of a batch
+type StoneMerge struct {
+	Source ItemLocation
+	Target ItemLocation
+	// Result is where the new stone was stored
+	Result ItemLocation
+	Stone  PortalStone
+}
+
+type plannedStone struct {
+	stone    PortalStone
+	location ItemLocation
+}
+
+type mergeStep struct {
+	source int
+	target int
+	result int
+}
+
+// planZoneMerges plans the merges for the stones of a single zone
+// Equal levels are merged first, from the lowest level up, so every merge
+// result can be merged again. A level 0 stone that is left alone is then
+// used to extend the duration of the highest stone that accepts it.
+func planZoneMerges(stones []plannedStone, indexes []int) ([]plannedStone, []mergeStep) {
+	steps := make([]mergeStep, 0)
+	levels := make(map[int][]int)
+	maxLevel := 0
+	for _, index := range indexes {
+		level := stones[index].stone.Level
+		levels[level] = append(levels[level], index)
+		if level > maxLevel {
+			maxLevel = level
+		}
+	}
+
+	leftovers := make([]int, 0)
+	for level := 0; level <= maxLevel; level++ {
+		current := levels[level]
+		// Longer stones are merged together, as the result keeps the
+		// shortest duration
+		sort.SliceStable(current, func(i, j int) bool {
+			return stones[current[i]].stone.Duration > stones[current[j]].stone.Duration
+		})
+
+		for i := 0; i+1 < len(current); i += 2 {
+			result, err := stones[current[i]].stone.Merge(stones[current[i+1]].stone)
+			if err != nil {
+				leftovers = append(leftovers, current[i], current[i+1])
+				continue
+			}
+			stones = append(stones, plannedStone{stone: result})
+			steps = append(steps, mergeStep{
+				source: current[i],
+				target: current[i+1],
+				result: len(stones) - 1,
+			})
+			levels[result.Level] = append(levels[result.Level], len(stones)-1)
+			if result.Level > maxLevel {
+				maxLevel = result.Level
+			}
+		}
+		if len(current)%2 == 1 {
+			leftovers = append(leftovers, current[len(current)-1])
+		}
+	}
+
+	for _, low := range leftovers {
+		if stones[low].stone.Level != 0 {
+			continue
+		}
+		for i := len(leftovers) - 1; i >= 0; i-- {
+			high := leftovers[i]
+			if high == low || stones[high].stone.Level == 0 {
+				continue
+			}
+			result, err := stones[low].stone.Merge(stones[high].stone)
+			if err != nil {
+				continue
+			}
+			stones = append(stones, plannedStone{stone: result})
+			steps = append(steps, mergeStep{
+				source: low,
+				target: high,
+				result: len(stones) - 1,
+			})
+			leftovers[i] = len(stones) - 1
+			break
+		}
+	}
+
+	return stones, steps
+}
+
+func (u User) planStoneMerges() ([]plannedStone, []mergeStep) {
+	stones := make([]plannedStone, 0)
+	zones := make([]string, 0)
+	byZone := make(map[string][]int)
+	for bagID, bag := range u.Bags {
+		for slot, item := range bag.Items() {
+			stone, ok := item.(*PortalStone)
+			if !ok || stone.Zone == nil || u.IsReserved(stone) {
+				continue
+			}
+			stones = append(stones, plannedStone{
+				stone:    *stone,
+				location: ItemLocation{BagID: bagID, Slot: slot},
+			})
+			if _, ok := byZone[stone.Zone.ID]; !ok {
+				zones = append(zones, stone.Zone.ID)
+			}
+			byZone[stone.Zone.ID] = append(byZone[stone.Zone.ID], len(stones)-1)
+		}
+	}
+
+	steps := make([]mergeStep, 0)
+	for _, zoneID := range zones {
+		var zoneSteps []mergeStep
+		stones, zoneSteps = planZoneMerges(stones, byZone[zoneID])
+		steps = append(steps, zoneSteps...)
+	}
+	return stones, steps
+}
+
+// MergeAllStones merges every compatible stone on the user bags
+// It returns the merges done, in order. If a merge fails, none of them is
+// applied.
+func (u *User) MergeAllStones() ([]StoneMerge, error) {
+	stones, steps := u.planStoneMerges()
+
+	merges := make([]StoneMerge, 0, len(steps))
+	err := u.Transaction(func() error {
+		for _, step := range steps {
+			source := stones[step.source].location
+			target := stones[step.target].location
+			location, err := u.MergeStones(source, target)
+			if err != nil {
+				return err
+			}
+			stones[step.result].location = location
+			merges = append(merges, StoneMerge{
+				Source: source,
+				Target: target,
+				Result: location,
+				Stone:  stones[step.result].stone,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return merges, nil
+}
+
+// PreviewMergeAllStones returns the merges MergeAllStones would do, without
+// changing the inventory
+func (u User) PreviewMergeAllStones() ([]StoneMerge, error) {
+	preview := User{
+		Bags:     make([]Bag, 0, len(u.Bags)),
+		reserved: make(map[Item]bool, len(u.reserved)),
+	}
+	// Reserved stones are skipped by MergeAllStones, so they need to be
+	// skipped by the preview too
+	for item := range u.reserved {
+		preview.reserved[item] = true
+	}
+	for _, bag := range u.Bags {
+		copied, err := NewBag(bag.Kind(), bag.Capacity())
+		if err != nil {
+			return nil, err
+		}
+		for slot, item := range bag.Items() {
+			if item == nil {
+				continue
+			}
+			err = copied.StoreItem(item, slot)
+			if err != nil {
+				return nil, err
+			}
+		}
+		preview.Bags = append(preview.Bags, copied)
+	}
+	return preview.MergeAllStones()
+}
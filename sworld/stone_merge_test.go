@@ -0,0 +1,131 @@
+package sworld
+
+import (
+	"testing"
+	"time"
+)
+
+func buildStoneUser() *User {
+	user := &User{
+		Bags: []Bag{NewStandardBag(10)},
+	}
+	user.PickupItem(&PortalStone{Level: 1, Duration: 12 * time.Second, Zone: zone1})
+	user.PickupItem(&PortalStone{Level: 0, Duration: 20 * time.Second, Zone: zone1})
+	user.PickupItem(&PortalStone{Level: 1, Duration: 14 * time.Second, Zone: zone1})
+	user.PickupItem(&PortalStone{Level: 2, Duration: 10 * time.Second, Zone: zone1})
+	user.PickupItem(&PortalStone{Level: 1, Duration: 10 * time.Second, Zone: zone2})
+	user.PickupItem(&Weapon{Damage: 10})
+	return user
+}
+
+func countStones(user *User) map[string][]*PortalStone {
+	stones := make(map[string][]*PortalStone)
+	for _, item := range user.Bags[0].Items() {
+		if stone, ok := item.(*PortalStone); ok {
+			stones[stone.Zone.ID] = append(stones[stone.Zone.ID], stone)
+		}
+	}
+	return stones
+}
+
+func TestPreviewMergeStones(t *testing.T) {
+	user := buildStoneUser()
+
+	stone, err := user.PreviewMergeStones(ItemLocation{Slot: 0}, ItemLocation{Slot: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stone.Level != 2 {
+		t.Error("Expected level to be 2, got", stone.Level)
+	}
+
+	_, err = user.PreviewMergeStones(ItemLocation{Slot: 0}, ItemLocation{Slot: 4})
+	if err != ErrIncompatibleZones {
+		t.Error("Expected zones to be incompatible, got", err)
+	}
+
+	_, err = user.PreviewMergeStones(ItemLocation{Slot: 0}, ItemLocation{Slot: 5})
+	if err != ErrWrongItem {
+		t.Error("Expected a wrong item error, got", err)
+	}
+
+	if len(countStones(user)[zone1.ID]) != 4 {
+		t.Error("Expected the preview to keep every stone")
+	}
+}
+
+func TestMergeAllStones(t *testing.T) {
+	user := buildStoneUser()
+
+	merges, err := user.MergeAllStones()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 1+1 makes a 2, 2+2 makes a 3, and the 0 extends it
+	if len(merges) != 3 {
+		t.Fatal("Expected 3 merges, got", len(merges))
+	}
+
+	stones := countStones(user)
+	if len(stones[zone1.ID]) != 1 {
+		t.Fatal("Expected a single stone on zone 1, got", len(stones[zone1.ID]))
+	}
+	stone := stones[zone1.ID][0]
+	if stone.Level != 3 {
+		t.Error("Expected level to be 3, got", stone.Level)
+	}
+	if stone.Duration != 11*time.Second {
+		t.Error("Expected duration to be extended to 11s, got", stone.Duration)
+	}
+	if len(stones[zone2.ID]) != 1 {
+		t.Error("Expected the zone 2 stone to be kept")
+	}
+
+	item, _ := user.GetItem(merges[2].Result)
+	if item != stone {
+		t.Error("Expected the last merge result to be the final stone")
+	}
+}
+
+func TestPreviewMergeAllStones(t *testing.T) {
+	user := buildStoneUser()
+
+	merges, err := user.PreviewMergeAllStones()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(merges) != 3 {
+		t.Error("Expected 3 merges, got", len(merges))
+	}
+	if len(countStones(user)[zone1.ID]) != 4 {
+		t.Error("Expected the preview to keep every stone")
+	}
+}
+
+func TestPreviewMergeAllStonesReserved(t *testing.T) {
+	user := buildStoneUser()
+	reserved, _ := user.Bags[0].GetItem(3)
+	user.ReserveItem(reserved)
+
+	preview, err := user.PreviewMergeAllStones()
+	if err != nil {
+		t.Fatal(err)
+	}
+	merges, err := user.MergeAllStones()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(preview) != len(merges) {
+		t.Fatal("Expected the preview to match the merges, got", len(preview), len(merges))
+	}
+	for i := range merges {
+		if preview[i].Source != merges[i].Source || preview[i].Target != merges[i].Target {
+			t.Error("Expected merge", i, "to match the preview, got", preview[i], merges[i])
+		}
+	}
+
+	item, _ := user.Bags[0].GetItem(3)
+	if item != reserved {
+		t.Error("Expected the reserved stone to not be merged")
+	}
+}
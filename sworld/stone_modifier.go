@@ -0,0 +1,133 @@
+package sworld
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+)
+
+var (
+	// ErrNoHealing is when the portal doesn't allow healing
+	ErrNoHealing = errors.New("Healing is not allowed on this portal")
+)
+
+// StoneModifier changes the difficulty or the rewards of a portal
+type StoneModifier int
+
+const (
+	// EnemyHealthModifier gives enemies 50% more health
+	EnemyHealthModifier StoneModifier = iota
+	// DoubleGoldModifier doubles the gold found
+	DoubleGoldModifier
+	// ExtraElitesModifier makes elite enemies more common
+	ExtraElitesModifier
+	// NoHealingModifier prevents characters from healing
+	NoHealingModifier
+)
+
+var stoneModifierNames = map[StoneModifier]string{
+	EnemyHealthModifier: "enemy_health",
+	DoubleGoldModifier:  "double_gold",
+	ExtraElitesModifier: "extra_elites",
+	NoHealingModifier:   "no_healing",
+}
+
+// modifierGoldBonus is the extra gold (in percent) given by the modifiers
+// that make a portal harder
+var modifierGoldBonus = map[StoneModifier]int{
+	EnemyHealthModifier: 25,
+	ExtraElitesModifier: 25,
+	NoHealingModifier:   50,
+}
+
+// TODO: this should be on settings
+const (
+	// enemyHealthBonus is the extra health (in percent) of EnemyHealthModifier
+	enemyHealthBonus = 50
+	// eliteChance is the chance (in percent) of an enemy being elite
+	eliteChance = 5
+	// extraEliteChance is the elite chance with ExtraElitesModifier
+	extraEliteChance = 25
+	// modifierChance is the chance (in percent), per level, of a dropped
+	// stone rolling a modifier
+	modifierChance = 10
+)
+
+// String returns the name of the modifier
+func (m StoneModifier) String() string {
+	return stoneModifierNames[m]
+}
+
+// HasModifier returns whether the stone has a given modifier
+func (s PortalStone) HasModifier(modifier StoneModifier) bool {
+	for _, m := range s.Modifiers {
+		if m == modifier {
+			return true
+		}
+	}
+	return false
+}
+
+// goldFound applies the modifiers of the stone to an amount of gold
+func (s PortalStone) goldFound(gold int) int {
+	bonus := 0
+	for _, modifier := range s.Modifiers {
+		bonus += modifierGoldBonus[modifier]
+	}
+	gold += (gold * bonus) / 100
+	if s.HasModifier(DoubleGoldModifier) {
+		gold *= 2
+	}
+	return gold
+}
+
+// eliteChance returns the chance (in percent) of an enemy being elite
+func (s PortalStone) eliteChance() int {
+	if s.HasModifier(ExtraElitesModifier) {
+		return extraEliteChance
+	}
+	return eliteChance
+}
+
+// canHeal returns false while the character is on a portal that doesn't
+// allow healing
+func (c Character) canHeal() bool {
+	return c.portal == nil || !c.portal.PortalStone.HasModifier(NoHealingModifier)
+}
+
+// mergeModifiers returns the modifiers of both lists, without repeating
+func mergeModifiers(a, b []StoneModifier) []StoneModifier {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+	modifiers := make([]StoneModifier, 0, len(a)+len(b))
+	seen := make(map[StoneModifier]bool)
+	for _, list := range [][]StoneModifier{a, b} {
+		for _, modifier := range list {
+			if !seen[modifier] {
+				seen[modifier] = true
+				modifiers = append(modifiers, modifier)
+			}
+		}
+	}
+	sort.Slice(modifiers, func(i, j int) bool {
+		return modifiers[i] < modifiers[j]
+	})
+	return modifiers
+}
+
+// randomModifiers rolls the modifiers for a stone of a given level
+// Level 0 stones have no modifiers, and higher levels roll more often
+func randomModifiers(level int) []StoneModifier {
+	if level < 1 {
+		return nil
+	}
+	chance := modifierChance * level
+	if chance > 50 {
+		chance = 50
+	}
+	if rand.Intn(100) >= chance {
+		return nil
+	}
+	return []StoneModifier{StoneModifier(rand.Intn(len(stoneModifierNames)))}
+}
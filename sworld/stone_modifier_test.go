@@ -0,0 +1,120 @@
+package sworld
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMergeModifiers(t *testing.T) {
+	stone1 := PortalStone{
+		Level:     1,
+		Duration:  10 * time.Second,
+		Zone:      zone1,
+		Modifiers: []StoneModifier{NoHealingModifier, EnemyHealthModifier},
+	}
+	stone2 := PortalStone{
+		Level:     1,
+		Duration:  10 * time.Second,
+		Zone:      zone1,
+		Modifiers: []StoneModifier{EnemyHealthModifier, DoubleGoldModifier},
+	}
+
+	result, err := stone1.Merge(stone2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []StoneModifier{EnemyHealthModifier, DoubleGoldModifier, NoHealingModifier}
+	if len(result.Modifiers) != len(expected) {
+		t.Fatal("Expected modifiers of both stones, got", result.Modifiers)
+	}
+	for i, modifier := range expected {
+		if result.Modifiers[i] != modifier {
+			t.Error("Expected", modifier, "got", result.Modifiers[i])
+		}
+	}
+
+	extender := PortalStone{
+		Level:     0,
+		Duration:  20 * time.Second,
+		Zone:      zone1,
+		Modifiers: []StoneModifier{ExtraElitesModifier},
+	}
+	result, err = extender.Merge(stone2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Modifiers) != 2 || result.HasModifier(ExtraElitesModifier) {
+		t.Error("Expected to keep the modifiers of the level 1 stone, got", result.Modifiers)
+	}
+
+	result.Modifiers[0] = NoHealingModifier
+	if stone2.Modifiers[0] != EnemyHealthModifier {
+		t.Error("Expected the merged stone to not share modifiers")
+	}
+}
+
+func TestModifiersGoldFound(t *testing.T) {
+	stone := PortalStone{}
+	if stone.goldFound(100) != 100 {
+		t.Error("Expected no bonus, got", stone.goldFound(100))
+	}
+
+	stone.Modifiers = []StoneModifier{DoubleGoldModifier}
+	if stone.goldFound(100) != 200 {
+		t.Error("Expected double gold, got", stone.goldFound(100))
+	}
+
+	stone.Modifiers = []StoneModifier{DoubleGoldModifier, NoHealingModifier}
+	if stone.goldFound(100) != 300 {
+		t.Error("Expected 300 gold, got", stone.goldFound(100))
+	}
+}
+
+func TestNoHealingModifier(t *testing.T) {
+	portal := &Portal{
+		PortalStone: PortalStone{Modifiers: []StoneModifier{NoHealingModifier}},
+	}
+	character := &Character{
+		Health:    10,
+		MaxHealth: 100,
+		Bags:      []Bag{NewStandardBag(2)},
+		portal:    portal,
+	}
+	character.Bags[0].StoreItem(&Consumable{Kind: HealthPotion, Power: 20, Quantity: 1}, 0)
+
+	character.Heal(20)
+	if character.Health != 10 {
+		t.Error("Expected the character to not heal, got", character.Health)
+	}
+	if err := character.UseItem(0, 0); err != ErrNoHealing {
+		t.Error("Expected no healing error, got", err)
+	}
+	if _, err := character.Bags[0].GetItem(0); err != nil {
+		t.Error("Expected the potion to not be used")
+	}
+
+	character.portal = nil
+	character.Heal(20)
+	if character.Health != 30 {
+		t.Error("Expected the character to heal outside the portal, got", character.Health)
+	}
+}
+
+func TestEnemyHealthModifier(t *testing.T) {
+	portal := &Portal{
+		PortalStone: PortalStone{
+			Level:     2,
+			Modifiers: []StoneModifier{EnemyHealthModifier},
+		},
+	}
+	for i := 0; i < 20; i++ {
+		enemy := NewEnemy(portal, 1)
+		minHealth := 30
+		if enemy.Elite {
+			minHealth *= 2
+		}
+		if enemy.MaxHealth < minHealth {
+			t.Error("Expected enemy health to be at least", minHealth, "got", enemy.MaxHealth)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package sworld
+
+import (
+	"errors"
+)
+
+var (
+	// ErrUnknownTalent is when the talent is not on the tree of the character class
+	ErrUnknownTalent = errors.New("That talent does not exist")
+	// ErrTalentMaxRank is when the talent can't be improved anymore
+	ErrTalentMaxRank = errors.New("The talent is already at its max rank")
+	// ErrTalentLocked is when the talent requirements are not met
+	ErrTalentLocked = errors.New("The talent is locked")
+)
+
+// TalentBonus are the bonuses given by each rank of a talent, all of them
+// are percentages
+type TalentBonus struct {
+	Damage     int
+	Health     int
+	Cooldown   int
+	Mitigation int
+	// Skill is the skill improved by SkillPower
+	Skill      string
+	SkillPower int
+}
+
+// Talent is a passive bonus on the talent tree of a class
+type Talent struct {
+	ID      string
+	Class   Class
+	Name    string
+	MaxRank int
+	// Requires is a talent that needs to be at its max rank first
+	Requires string
+	Bonus    TalentBonus
+}
+
+// talentTree are the talents of every class
+var talentTree = []Talent{
+	{ID: "might", Class: WarriorClass, Name: "Might", MaxRank: 3, Bonus: TalentBonus{Damage: 5}},
+	{ID: "iron_skin", Class: WarriorClass, Name: "Iron skin", MaxRank: 3, Bonus: TalentBonus{Mitigation: 5}},
+	{ID: "fortitude", Class: WarriorClass, Name: "Fortitude", MaxRank: 2, Requires: "iron_skin", Bonus: TalentBonus{Health: 10}},
+	{ID: "improved_cleave", Class: WarriorClass, Name: "Improved cleave", MaxRank: 2, Requires: "might", Bonus: TalentBonus{Skill: "cleave", SkillPower: 40}},
+
+	{ID: "precision", Class: RangerClass, Name: "Precision", MaxRank: 3, Bonus: TalentBonus{Damage: 4}},
+	{ID: "swiftness", Class: RangerClass, Name: "Swiftness", MaxRank: 3, Bonus: TalentBonus{Cooldown: 5}},
+	{ID: "evasion", Class: RangerClass, Name: "Evasion", MaxRank: 2, Requires: "swiftness", Bonus: TalentBonus{Mitigation: 4}},
+	{ID: "improved_volley", Class: RangerClass, Name: "Improved volley", MaxRank: 2, Requires: "precision", Bonus: TalentBonus{Skill: "volley", SkillPower: 50}},
+
+	{ID: "arcane_power", Class: MageClass, Name: "Arcane power", MaxRank: 3, Bonus: TalentBonus{Damage: 6}},
+	{ID: "focus", Class: MageClass, Name: "Focus", MaxRank: 2, Bonus: TalentBonus{Cooldown: 6}},
+	{ID: "mana_shield", Class: MageClass, Name: "Mana shield", MaxRank: 2, Requires: "focus", Bonus: TalentBonus{Mitigation: 6}},
+	{ID: "improved_fireball", Class: MageClass, Name: "Improved fireball", MaxRank: 2, Requires: "arcane_power", Bonus: TalentBonus{Skill: "fireball", SkillPower: 60}},
+}
+
+// ClassTalents returns the talents on the tree of a class
+func ClassTalents(class Class) []Talent {
+	talents := make([]Talent, 0)
+	for _, talent := range talentTree {
+		if talent.Class == class {
+			talents = append(talents, talent)
+		}
+	}
+	return talents
+}
+
+// findTalent returns a talent on the tree of a class
+func findTalent(class Class, id string) (Talent, error) {
+	for _, talent := range ClassTalents(class) {
+		if talent.ID == id {
+			return talent, nil
+		}
+	}
+	return Talent{}, ErrUnknownTalent
+}
+
+// talentBonus returns the sum of the bonuses of the talents of the
+// character, skill bonuses are not included
+func (c Character) talentBonus() TalentBonus {
+	bonus := TalentBonus{}
+	for id, rank := range c.Talents {
+		talent, err := findTalent(c.Class, id)
+		if err != nil {
+			continue
+		}
+		bonus.Damage += talent.Bonus.Damage * rank
+		bonus.Health += talent.Bonus.Health * rank
+		bonus.Cooldown += talent.Bonus.Cooldown * rank
+		bonus.Mitigation += talent.Bonus.Mitigation * rank
+	}
+	return bonus
+}
+
+// TalentUnlocked returns whether the requirements of a talent are met
+func (c Character) TalentUnlocked(talent Talent) bool {
+	if talent.Requires == "" {
+		return true
+	}
+	required, err := findTalent(c.Class, talent.Requires)
+	if err != nil {
+		return false
+	}
+	return c.Talents[required.ID] >= required.MaxRank
+}
+
+// LearnTalent spends a talent point on a talent
+func (c *Character) LearnTalent(id string) error {
+	if c.Exploring {
+		return ErrCharacterBusy
+	}
+	talent, err := findTalent(c.Class, id)
+	if err != nil {
+		return err
+	}
+	if c.Talents[id] >= talent.MaxRank {
+		return ErrTalentMaxRank
+	}
+	if !c.TalentUnlocked(talent) {
+		return ErrTalentLocked
+	}
+	if c.TalentPoints < 1 {
+		return ErrNotEnoughPoints
+	}
+
+	if c.Talents == nil {
+		c.Talents = make(map[string]int)
+	}
+	c.TalentPoints--
+	c.Talents[id]++
+	c.updateMaxHealth()
+
+	return nil
+}
+
+// SkillPower returns the extra power (in percent) of a skill
+func (c Character) SkillPower(skill string) int {
+	power := 0
+	for id, rank := range c.Talents {
+		talent, err := findTalent(c.Class, id)
+		if err == nil && talent.Bonus.Skill == skill {
+			power += talent.Bonus.SkillPower * rank
+		}
+	}
+	return power
+}
@@ -0,0 +1,72 @@
+package sworld
+
+import (
+	"testing"
+)
+
+func TestLearnTalent(t *testing.T) {
+	char := NewCharacter(WarriorClass)
+	char.TalentPoints = 5
+
+	if err := char.LearnTalent("fireball"); err != ErrUnknownTalent {
+		t.Error("Expected an unknown talent, got", err)
+	}
+	if err := char.LearnTalent("improved_fireball"); err != ErrUnknownTalent {
+		t.Error("Expected talents of other classes to be unknown, got", err)
+	}
+	if err := char.LearnTalent("improved_cleave"); err != ErrTalentLocked {
+		t.Error("Expected the talent to be locked, got", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := char.LearnTalent("might"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := char.LearnTalent("might"); err != ErrTalentMaxRank {
+		t.Error("Expected the talent to be at its max rank, got", err)
+	}
+	if err := char.LearnTalent("improved_cleave"); err != nil {
+		t.Fatal("Expected the talent to be unlocked, got", err)
+	}
+	if char.TalentPoints != 1 {
+		t.Error("Expected 1 talent point left, got", char.TalentPoints)
+	}
+
+	char.TalentPoints = 0
+	if err := char.LearnTalent("iron_skin"); err != ErrNotEnoughPoints {
+		t.Error("Expected not enough points, got", err)
+	}
+}
+
+func TestTalentBonus(t *testing.T) {
+	char := NewCharacter(WarriorClass)
+	stats, _ := FindClass("warrior")
+	char.Talents = map[string]int{
+		"might":           3,
+		"iron_skin":       3,
+		"fortitude":       2,
+		"improved_cleave": 1,
+	}
+	char.updateMaxHealth()
+
+	if char.Damage() != stats.Damage+((stats.Damage*15)/100) {
+		t.Error("Expected might to add 15% damage, got", char.Damage())
+	}
+	if char.Mitigation() != 15 {
+		t.Error("Expected 15% mitigation, got", char.Mitigation())
+	}
+	if char.MaxHealth != stats.Health+((stats.Health*20)/100) {
+		t.Error("Expected fortitude to add 20% health, got", char.MaxHealth)
+	}
+	if char.SkillPower("cleave") != 40 || char.SkillPower("hit") != 0 {
+		t.Error("Expected only cleave to be improved, got", char.SkillPower("cleave"), char.SkillPower("hit"))
+	}
+
+	explorer := &Explorer{Character: char, Portal: &Portal{}}
+	char.Health = 100
+	explorer.ReceiveDamage(nil, 20)
+	if char.Health != 83 {
+		t.Error("Expected the damage to be mitigated, got", char.Health)
+	}
+}
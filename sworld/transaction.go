@@ -0,0 +1,107 @@
+package sworld
+
+// bagSnapshot holds the slots of a bag at some point
+type bagSnapshot struct {
+	bag   Bag
+	items []Item
+}
+
+// characterSnapshot holds what an inventory change can modify on a character
+type characterSnapshot struct {
+	character *Character
+	bags      []Bag
+	weapon    *Weapon
+}
+
+// inventorySnapshot holds what an inventory change can modify on a user
+// Characters that are exploring are not included, their inventory can't be
+// changed from town
+type inventorySnapshot struct {
+	gold       int
+	bags       []Bag
+	characters []characterSnapshot
+	slots      []bagSnapshot
+	counts     map[Stackable]int
+}
+
+// bagSlots returns the slots of the bags we know about
+func bagSlots(bag Bag) *[]Item {
+	switch b := bag.(type) {
+	case *StandardBag:
+		return &b.items
+	case *RestrictedBag:
+		return &b.items
+	case *ExpandableBag:
+		return &b.items
+	}
+	return nil
+}
+
+func (s *inventorySnapshot) addBags(bags []Bag) {
+	for _, bag := range bags {
+		slots := bagSlots(bag)
+		if slots == nil {
+			continue
+		}
+		items := make([]Item, len(*slots))
+		copy(items, *slots)
+		s.slots = append(s.slots, bagSnapshot{bag: bag, items: items})
+
+		for _, item := range items {
+			if stack, ok := item.(Stackable); ok {
+				s.counts[stack] = stack.Count()
+			}
+		}
+	}
+}
+
+func (u *User) snapshot() *inventorySnapshot {
+	s := &inventorySnapshot{
+		gold:   u.Gold,
+		bags:   append([]Bag(nil), u.Bags...),
+		counts: make(map[Stackable]int),
+	}
+	s.addBags(u.Bags)
+
+	for _, character := range u.Characters {
+		if character.Exploring {
+			continue
+		}
+		s.characters = append(s.characters, characterSnapshot{
+			character: character,
+			bags:      append([]Bag(nil), character.Bags...),
+			weapon:    character.Weapon,
+		})
+		s.addBags(character.Bags)
+	}
+	return s
+}
+
+func (u *User) restore(s *inventorySnapshot) {
+	u.Gold = s.gold
+	u.Bags = s.bags
+	for _, c := range s.characters {
+		c.character.Bags = c.bags
+		c.character.Weapon = c.weapon
+	}
+	for _, b := range s.slots {
+		slots := bagSlots(b.bag)
+		*slots = b.items
+	}
+	for stack, count := range s.counts {
+		stack.SetCount(count)
+	}
+}
+
+// Transaction runs several inventory changes as a single one
+// If fn fails, the bags, stacks, weapons and gold of the user and its
+// characters are put back as they were. The caller is in charge of making
+// sure nothing else changes the inventory meanwhile.
+func (u *User) Transaction(fn func() error) error {
+	s := u.snapshot()
+	err := fn()
+	if err != nil {
+		u.restore(s)
+	}
+	return err
+}
@@ -0,0 +1,102 @@
+package sworld
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTransactionRollback(t *testing.T) {
+	character := NewCharacter(WarriorClass)
+	weapon := &Weapon{Damage: 10}
+	character.Weapon = weapon
+
+	potions := &Consumable{Kind: HealthPotion, Power: 10, Quantity: 5}
+	stone := &PortalStone{Level: 1, Zone: zone1}
+	expandable := NewExpandableBag(5, 20)
+	user := &User{
+		Gold:       100,
+		Bags:       []Bag{NewStandardBag(5), expandable},
+		Characters: []*Character{character},
+	}
+	user.Bags[0].StoreItem(potions, 0)
+	user.Bags[0].StoreItem(stone, 1)
+
+	failure := errors.New("Failure")
+	err := user.Transaction(func() error {
+		user.Gold -= 50
+		potions.Quantity = 1
+		user.DropItem(0, 1)
+		user.Bags[0].StoreItem(&Weapon{}, 3)
+		expandable.Expand()
+		user.Bags = append(user.Bags, NewStandardBag(2))
+		character.Weapon = nil
+		return failure
+	})
+	if err != failure {
+		t.Error("Expected the transaction error, got", err)
+	}
+
+	if user.Gold != 100 {
+		t.Error("Expected gold to be restored, got", user.Gold)
+	}
+	if potions.Quantity != 5 {
+		t.Error("Expected the stack to be restored, got", potions.Quantity)
+	}
+	if item, _ := user.Bags[0].GetItem(1); item != stone {
+		t.Error("Expected the stone to be back, got", item)
+	}
+	if item, _ := user.Bags[0].GetItem(3); item != nil {
+		t.Error("Expected slot 3 to be empty, got", item)
+	}
+	if expandable.Capacity() != 5 {
+		t.Error("Expected the bag capacity to be restored, got", expandable.Capacity())
+	}
+	if len(user.Bags) != 2 {
+		t.Error("Expected the new bag to be removed, got", len(user.Bags))
+	}
+	if character.Weapon != weapon {
+		t.Error("Expected the weapon to be restored")
+	}
+}
+
+func TestTransactionCommit(t *testing.T) {
+	user := &User{
+		Gold: 100,
+		Bags: []Bag{NewStandardBag(5)},
+	}
+
+	err := user.Transaction(func() error {
+		user.Gold -= 50
+		_, err := user.PickupItem(&Weapon{Damage: 10})
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Gold != 50 {
+		t.Error("Expected gold to be 50, got", user.Gold)
+	}
+	if item, _ := user.Bags[0].GetItem(0); item == nil {
+		t.Error("Expected the weapon to be stored")
+	}
+}
+
+func TestTakeCharacterItemRollback(t *testing.T) {
+	character := NewCharacter(WarriorClass)
+	character.Bags = []Bag{NewStandardBag(2)}
+	weapon := &Weapon{Damage: 10}
+	character.Bags[0].StoreItem(weapon, 0)
+
+	user := &User{
+		Bags:       []Bag{NewStonePouch(2)},
+		Characters: []*Character{character},
+	}
+
+	err := user.TakeCharacterItem(character.ID, ItemLocation{Slot: 0})
+	if err != ErrInventoryFull {
+		t.Error("Expected the inventory to be full, got", err)
+	}
+	if item, _ := character.Bags[0].GetItem(0); item != weapon {
+		t.Error("Expected the weapon to stay on the character, got", item)
+	}
+}
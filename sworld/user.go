@@ -9,6 +9,12 @@ var (
 	ErrWrongItem = errors.New("The item is not valid for that action")
 	// ErrSameSlot is when both slots are the same
 	ErrSameSlot = errors.New("Can't use the same slot twice")
+	// ErrNotEnoughGold is when the user can't afford something
+	ErrNotEnoughGold = errors.New("Not enough gold")
+	// ErrItemReserved is when the item is part of an open trade
+	ErrItemReserved = errors.New("The item is reserved")
+	// ErrItemNotFound is when the item is not on the inventory
+	ErrItemNotFound = errors.New("The item is not on the inventory")
 )
 
 // User represents a user
@@ -18,6 +24,12 @@ type User struct {
 	Characters []*Character
 	Bags       []Bag
 	Gold       int
+	// Titles are the cosmetic titles unlocked by the user
+	Titles []string
+
+	// reserved holds the items that can't be moved, like the ones offered
+	// on a trade
+	reserved map[Item]bool
 }
 
 // HasAliveCharacters returns true if the user has alive characters
@@ -48,48 +60,90 @@ func (u *User) TakeCharacterItem(characterID string, location ItemLocation) erro
 		return err
 	}
 
-	if location.BagID >= len(character.Bags) {
+	if character.Exploring {
+		return ErrCharacterBusy
+	}
+	if location.BagID < 0 || location.BagID >= len(character.Bags) {
 		return ErrInvalidBag
 	}
 
 	bag := character.Bags[location.BagID]
 
-	// TODO: Lock the bag
-	item, err := bag.GetItem(location.Slot)
-	if err != nil {
+	return u.Transaction(func() error {
+		item, err := bag.DropItem(location.Slot)
+		if err != nil {
+			return err
+		}
+		_, err = u.PickupItem(item)
 		return err
-	}
+	})
+}
 
-	// TODO: Lock the inventory
-	newLocation, err := u.findEmptyBagSlot(item)
+// DropItem drops an item that is at a given location of the user bags
+func (u *User) DropItem(bagID, slot int) error {
+	return u.DropItemAt(ItemLocation{BagID: bagID, Slot: slot})
+}
+
+// DropItemAt drops an item that is at a given location, either on the user
+// bags or on the bags of one of its characters
+func (u *User) DropItemAt(location ItemLocation) error {
+	bag, err := u.bagAt(location)
 	if err != nil {
 		return err
 	}
 
-	item, err = bag.DropItem(location.Slot)
+	item, err := bag.GetItem(location.Slot)
 	if err != nil {
 		return err
 	}
-	return u.Bags[newLocation.BagID].StoreItem(item, newLocation.Slot)
+	if u.IsReserved(item) {
+		return ErrItemReserved
+	}
+
+	_, err = bag.DropItem(location.Slot)
+	return err
 }
 
-// DropItem drops an item that is at a given location
-func (u *User) DropItem(bagID, slot int) error {
-	if bagID > len(u.Bags) {
-		return ErrInvalidBag
+// ReserveItem marks an item so it can't be dropped or moved
+func (u *User) ReserveItem(item Item) error {
+	if u.IsReserved(item) {
+		return ErrItemReserved
 	}
-	bag := u.Bags[bagID]
+	if u.reserved == nil {
+		u.reserved = make(map[Item]bool)
+	}
+	u.reserved[item] = true
+	return nil
+}
 
-	_, err := bag.DropItem(slot)
-	return err
+// ReleaseItem removes the reservation of an item
+func (u *User) ReleaseItem(item Item) {
+	delete(u.reserved, item)
+}
+
+// IsReserved returns true if the item is reserved
+func (u User) IsReserved(item Item) bool {
+	return item != nil && u.reserved[item]
+}
+
+// FindItem returns the location of an item on the user bags
+func (u User) FindItem(item Item) (ItemLocation, error) {
+	for bagID, bag := range u.Bags {
+		for slot, other := range bag.Items() {
+			if other != nil && other == item {
+				return ItemLocation{BagID: bagID, Slot: slot}, nil
+			}
+		}
+	}
+	return ItemLocation{}, ErrItemNotFound
 }
 
 // GetItem returns the item at a given location
-func (u User) GetItem(location ItemLocation) (Item, error) {
-	if location.BagID > len(u.Bags) {
-		return nil, ErrInvalidBag
+func (u *User) GetItem(location ItemLocation) (Item, error) {
+	bag, err := u.bagAt(location)
+	if err != nil {
+		return nil, err
 	}
-	bag := u.Bags[location.BagID]
 	item, err := bag.GetItem(location.Slot)
 	if err != nil {
 		return nil, err
@@ -101,13 +155,101 @@ func (u User) GetItem(location ItemLocation) (Item, error) {
 func (u User) findEmptyBagSlot(item Item) (ItemLocation, error) {
 	for id, bag := range u.Bags {
 		slot, err := bag.FindEmptySlot(item)
-		if err == nil {
-			return ItemLocation{BagID: id, Slot: slot}, nil
+		if err != nil {
+			continue
+		}
+		// Reserved stacks can't grow, so the item goes to a free slot
+		if stack, _ := bag.GetItem(slot); u.IsReserved(stack) {
+			return u.findFreeBagSlot(ItemLocation{}, item)
+		}
+		return ItemLocation{BagID: id, Slot: slot}, nil
+	}
+	return ItemLocation{}, ErrInventoryFull
+}
+
+// findFreeBagSlot returns an empty slot for an item, ignoring stacks
+// The slot is on the same bags as the given location
+func (u *User) findFreeBagSlot(location ItemLocation, item Item) (ItemLocation, error) {
+	bags, err := u.bagsOf(location.CharacterID)
+	if err != nil {
+		return ItemLocation{}, err
+	}
+	for id, bag := range bags {
+		if !bag.Accepts(item) {
+			continue
+		}
+		for slot, item := range bag.Items() {
+			if item == nil {
+				return ItemLocation{CharacterID: location.CharacterID, BagID: id, Slot: slot}, nil
+			}
 		}
 	}
 	return ItemLocation{}, ErrInventoryFull
 }
 
+// SplitStack moves a given quantity of a stack to an empty slot of the same
+// bags
+func (u *User) SplitStack(location ItemLocation, count int) (ItemLocation, error) {
+	bag, err := u.bagAt(location)
+	if err != nil {
+		return ItemLocation{}, err
+	}
+	item, err := bag.GetItem(location.Slot)
+	if err != nil {
+		return ItemLocation{}, err
+	}
+	if u.IsReserved(item) {
+		return ItemLocation{}, ErrItemReserved
+	}
+	newLocation, err := u.findFreeBagSlot(location, item)
+	if err != nil {
+		return ItemLocation{}, err
+	}
+	target, err := u.bagAt(newLocation)
+	if err != nil {
+		return ItemLocation{}, err
+	}
+
+	item, err = bag.SplitStack(location.Slot, count)
+	if err != nil {
+		return ItemLocation{}, err
+	}
+
+	return newLocation, target.StoreItem(item, newLocation.Slot)
+}
+
+// MergeStacks moves as many items as possible from one stack to another
+// Whatever does not fit stays on the source stack
+func (u *User) MergeStacks(source ItemLocation, target ItemLocation) error {
+	if source.SameAs(target) {
+		return ErrSameSlot
+	}
+	item, err := u.GetItem(source)
+	if err != nil {
+		return err
+	}
+	stack, err := u.GetItem(target)
+	if err != nil {
+		return err
+	}
+	if u.IsReserved(item) || u.IsReserved(stack) {
+		return ErrItemReserved
+	}
+
+	targetStack, sourceStack, ok := stackWith(stack, item)
+	if !ok {
+		return ErrIncompatibleStacks
+	}
+	if transferStack(sourceStack, targetStack) == 0 {
+		return ErrStackFull
+	}
+
+	if sourceStack.Count() == 0 {
+		return u.DropItemAt(source)
+	}
+	return nil
+}
+
 // FindCharacter searchs for a character from a user
 func (u User) FindCharacter(id string) (*Character, error) {
 	for _, character := range u.Characters {
@@ -118,44 +260,215 @@ func (u User) FindCharacter(id string) (*Character, error) {
 	return nil, ErrCharacterNotFound
 }
 
-// MergeStones merges two stones
-func (u *User) MergeStones(source ItemLocation, target ItemLocation) (ItemLocation, error) {
+// bagsOf returns the bags of the user, or of one of its characters when
+// characterID is set
+func (u *User) bagsOf(characterID string) ([]Bag, error) {
+	if characterID == "" {
+		return u.Bags, nil
+	}
+	character, err := u.FindCharacter(characterID)
+	if err != nil {
+		return nil, err
+	}
+	if character.Exploring {
+		return nil, ErrCharacterBusy
+	}
+	return character.Bags, nil
+}
+
+// FindBag returns a bag, either from the user or from one of its characters
+// when characterID is set
+func (u *User) FindBag(characterID string, bagID int) (Bag, error) {
+	bags, err := u.bagsOf(characterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if bagID < 0 || bagID >= len(bags) {
+		return nil, ErrInvalidBag
+	}
+	return bags[bagID], nil
+}
+
+func (u *User) bagAt(location ItemLocation) (Bag, error) {
+	return u.FindBag(location.CharacterID, location.BagID)
+}
+
+// SpendGold takes gold from the user
+func (u *User) SpendGold(amount int) error {
+	if amount < 0 || u.Gold < amount {
+		return ErrNotEnoughGold
+	}
+	u.Gold -= amount
+	return nil
+}
+
+// MoveItem moves an item to another location
+// If there is an item at the target location, both items are swapped, or
+// merged if they can be stacked together
+func (u *User) MoveItem(from ItemLocation, to ItemLocation) error {
+	if from.SameAs(to) {
+		return ErrSameSlot
+	}
+	source, err := u.bagAt(from)
+	if err != nil {
+		return err
+	}
+	target, err := u.bagAt(to)
+	if err != nil {
+		return err
+	}
+
+	item, err := source.GetItem(from.Slot)
+	if err != nil {
+		return err
+	}
+	other, err := target.GetItem(to.Slot)
+	if err == ErrEmptyBagSlot {
+		other = nil
+	} else if err != nil {
+		return err
+	}
+	if u.IsReserved(item) || u.IsReserved(other) {
+		return ErrItemReserved
+	}
+
+	if targetStack, sourceStack, ok := stackWith(other, item); ok {
+		if transferStack(sourceStack, targetStack) == 0 {
+			return ErrStackFull
+		}
+		if sourceStack.Count() == 0 {
+			_, err = source.DropItem(from.Slot)
+		}
+		return err
+	}
+
+	return u.Transaction(func() error {
+		source.DropItem(from.Slot)
+		if other != nil {
+			target.DropItem(to.Slot)
+		}
+
+		err := target.StoreItem(item, to.Slot)
+		if err != nil {
+			return err
+		}
+		if other != nil {
+			return source.StoreItem(other, from.Slot)
+		}
+		return nil
+	})
+}
+
+// AttachBag takes a bag item out of the inventory and attaches it to the
+// user, or to one of its characters when characterID is set
+func (u *User) AttachBag(location ItemLocation, characterID string) error {
+	source, err := u.bagAt(location)
+	if err != nil {
+		return err
+	}
+	item, err := source.GetItem(location.Slot)
+	if err != nil {
+		return err
+	}
+	bagItem, ok := item.(*BagItem)
+	if !ok {
+		return ErrWrongItem
+	}
+	if u.IsReserved(item) {
+		return ErrItemReserved
+	}
+
+	var character *Character
+	bags := u.Bags
+	if characterID != "" {
+		character, err = u.FindCharacter(characterID)
+		if err != nil {
+			return err
+		}
+		if character.Exploring {
+			return ErrCharacterBusy
+		}
+		bags = character.Bags
+	}
+	if len(bags) >= MaxBags {
+		return ErrTooManyBags
+	}
+
+	bag, err := bagItem.NewBag()
+	if err != nil {
+		return err
+	}
+	_, err = source.DropItem(location.Slot)
+	if err != nil {
+		return err
+	}
+
+	if character != nil {
+		character.Bags = append(character.Bags, bag)
+	} else {
+		u.Bags = append(u.Bags, bag)
+	}
+	return nil
+}
+
+func (u User) stonesAt(source ItemLocation, target ItemLocation) (*PortalStone, *PortalStone, error) {
 	if source.SameAs(target) {
-		return ItemLocation{}, ErrSameSlot
+		return nil, nil, ErrSameSlot
 	}
-	// TODO: lock inventory
 	item, err := u.GetItem(source)
 	if err != nil {
-		return ItemLocation{}, err
+		return nil, nil, err
 	}
 	stone1, ok := item.(*PortalStone)
 	if !ok {
-		return ItemLocation{}, ErrWrongItem
+		return nil, nil, ErrWrongItem
 	}
 
 	item, err = u.GetItem(target)
 	if err != nil {
-		return ItemLocation{}, err
+		return nil, nil, err
 	}
 	stone2, ok := item.(*PortalStone)
 	if !ok {
-		return ItemLocation{}, ErrWrongItem
+		return nil, nil, ErrWrongItem
 	}
+	return stone1, stone2, nil
+}
 
-	result, err := stone1.Merge(*stone2)
+// PreviewMergeStones returns the stone that merging two stones would
+// create, without changing the inventory
+func (u User) PreviewMergeStones(source ItemLocation, target ItemLocation) (PortalStone, error) {
+	stone1, stone2, err := u.stonesAt(source, target)
 	if err != nil {
-		return ItemLocation{}, err
+		return PortalStone{}, err
 	}
+	return stone1.Merge(*stone2)
+}
 
-	err = u.DropItem(source.BagID, source.Slot)
+// MergeStones merges two stones
+func (u *User) MergeStones(source ItemLocation, target ItemLocation) (ItemLocation, error) {
+	result, err := u.PreviewMergeStones(source, target)
 	if err != nil {
 		return ItemLocation{}, err
 	}
-	err = u.DropItem(target.BagID, target.Slot)
+
+	var newLocation ItemLocation
+	err = u.Transaction(func() error {
+		err := u.DropItemAt(source)
+		if err != nil {
+			return err
+		}
+		err = u.DropItemAt(target)
+		if err != nil {
+			return err
+		}
+		newLocation, err = u.PickupItem(&result)
+		return err
+	})
 	if err != nil {
 		return ItemLocation{}, err
 	}
-	newLocation, err := u.PickupItem(&result)
 
 	return newLocation, nil
 }
@@ -50,3 +50,118 @@ func TestUserMergeStones(t *testing.T) {
 		t.Error("Expected item at 0,0 to have been dropped, got:", item)
 	}
 }
+
+func TestUserMoveItem(t *testing.T) {
+	character := &Character{
+		ID:   "character",
+		Bags: []Bag{NewStandardBag(2)},
+	}
+	user := User{
+		Bags:       []Bag{NewStandardBag(2)},
+		Characters: []*Character{character},
+	}
+
+	stone := &PortalStone{Level: 1}
+	weapon := &Weapon{Damage: 10}
+	user.Bags[0].StoreItem(stone, 0)
+	character.Bags[0].StoreItem(weapon, 1)
+
+	stash := ItemLocation{BagID: 0, Slot: 0}
+	characterSlot := ItemLocation{CharacterID: "character", BagID: 0, Slot: 1}
+
+	err := user.MoveItem(stash, characterSlot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, _ := character.Bags[0].GetItem(1)
+	if item != stone {
+		t.Error("Expected the stone to be on the character bag, got", item)
+	}
+	item, _ = user.Bags[0].GetItem(0)
+	if item != weapon {
+		t.Error("Expected the weapon to be swapped to the user bag, got", item)
+	}
+
+	err = user.MoveItem(stash, ItemLocation{BagID: 0, Slot: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = user.Bags[0].GetItem(0); err != ErrEmptyBagSlot {
+		t.Error("Expected the slot to be empty after moving, got", err)
+	}
+
+	character.Exploring = true
+	err = user.MoveItem(characterSlot, stash)
+	if err != ErrCharacterBusy {
+		t.Error("Expected move to fail while exploring, got", err)
+	}
+}
+
+func TestUserMoveItemMergesStacks(t *testing.T) {
+	user := User{Bags: []Bag{NewStandardBag(2)}}
+	stack := &Consumable{Kind: HealthPotion, Power: 10, Quantity: 2}
+	user.Bags[0].StoreItem(&Consumable{Kind: HealthPotion, Power: 10, Quantity: 3}, 0)
+	user.Bags[0].StoreItem(stack, 1)
+
+	err := user.MoveItem(ItemLocation{BagID: 0, Slot: 0}, ItemLocation{BagID: 0, Slot: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stack.Quantity != 5 {
+		t.Error("Expected stacks to be merged, got", stack.Quantity)
+	}
+	if _, err = user.Bags[0].GetItem(0); err != ErrEmptyBagSlot {
+		t.Error("Expected the source slot to be empty, got", err)
+	}
+}
+
+func TestUserReservedItems(t *testing.T) {
+	stone := &PortalStone{Level: 1, Zone: zone1}
+	potions := &Consumable{Kind: HealthPotion, Power: 10, Quantity: 4}
+	user := User{
+		Bags: []Bag{NewStandardBag(5)},
+	}
+	user.Bags[0].StoreItem(stone, 0)
+	user.Bags[0].StoreItem(potions, 1)
+
+	if err := user.ReserveItem(stone); err != nil {
+		t.Fatal(err)
+	}
+	if err := user.ReserveItem(stone); err != ErrItemReserved {
+		t.Error("Expected the stone to be reserved already, got", err)
+	}
+	user.ReserveItem(potions)
+
+	if err := user.DropItem(0, 0); err != ErrItemReserved {
+		t.Error("Expected drop to fail, got", err)
+	}
+	if err := user.MoveItem(ItemLocation{Slot: 0}, ItemLocation{Slot: 3}); err != ErrItemReserved {
+		t.Error("Expected move to fail, got", err)
+	}
+	if _, err := user.SplitStack(ItemLocation{Slot: 1}, 2); err != ErrItemReserved {
+		t.Error("Expected split to fail, got", err)
+	}
+
+	more := &Consumable{Kind: HealthPotion, Power: 10, Quantity: 2}
+	location, err := user.PickupItem(more)
+	if err != nil || location.Slot == 1 {
+		t.Error("Expected the reserved stack to not grow, got", location, err)
+	}
+	if potions.Quantity != 4 {
+		t.Error("Expected the reserved stack to keep 4 potions, got", potions.Quantity)
+	}
+
+	location, err = user.FindItem(stone)
+	if err != nil || location.Slot != 0 {
+		t.Error("Expected to find the stone at slot 0, got", location, err)
+	}
+
+	user.ReleaseItem(stone)
+	if err := user.DropItem(0, 0); err != nil {
+		t.Error("Expected drop to work after releasing, got", err)
+	}
+	if _, err := user.FindItem(stone); err != ErrItemNotFound {
+		t.Error("Expected the stone to be gone, got", err)
+	}
+}
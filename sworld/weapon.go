@@ -0,0 +1,176 @@
+package sworld
+
+import (
+	"math/rand"
+
+	"github.com/encryptio/alias"
+)
+
+// Rarity is the rarity tier of an item
+type Rarity int
+
+const (
+	// Common items have no affixes
+	Common Rarity = iota
+	// Uncommon items have one affix
+	Uncommon
+	// Rare items have two affixes
+	Rare
+	// Epic items have three affixes
+	Epic
+	// Legendary items have every affix
+	Legendary
+)
+
+// AffixKind is the kind of bonus given by an affix
+type AffixKind int
+
+const (
+	// BonusDamageAffix adds flat damage
+	BonusDamageAffix AffixKind = iota
+	// LifeStealAffix heals a percentage of the damage dealt
+	LifeStealAffix
+	// CritChanceAffix is the chance (in percent) of dealing double damage
+	CritChanceAffix
+	// GoldFindAffix increases the gold found (in percent)
+	GoldFindAffix
+)
+
+// WeaponKind is the kind of a weapon, classes can only wield some kinds
+type WeaponKind int
+
+const (
+	// SwordWeapon is a one handed blade
+	SwordWeapon WeaponKind = iota
+	// AxeWeapon is a heavy blade
+	AxeWeapon
+	// BowWeapon is a ranged weapon
+	BowWeapon
+	// DaggerWeapon is a light blade
+	DaggerWeapon
+	// StaffWeapon is a magic weapon
+	StaffWeapon
+)
+
+var weaponKindNames = map[WeaponKind]string{
+	SwordWeapon:  "sword",
+	AxeWeapon:    "axe",
+	BowWeapon:    "bow",
+	DaggerWeapon: "dagger",
+	StaffWeapon:  "staff",
+}
+
+var rarityNames = map[Rarity]string{
+	Common:    "common",
+	Uncommon:  "uncommon",
+	Rare:      "rare",
+	Epic:      "epic",
+	Legendary: "legendary",
+}
+
+var affixNames = map[AffixKind]string{
+	BonusDamageAffix: "bonus_damage",
+	LifeStealAffix:   "life_steal",
+	CritChanceAffix:  "crit_chance",
+	GoldFindAffix:    "gold_find",
+}
+
+// rarityRates are the drop rates for each rarity, indexed by rarity
+var rarityRates = []float64{60, 25, 10, 4, 1}
+
+// affixRange is the range of values an affix can roll, it grows with the
+// stone level
+type affixRange struct {
+	min      int
+	max      int
+	perLevel int
+}
+
+var affixRanges = []affixRange{
+	BonusDamageAffix: {min: 2, max: 5, perLevel: 3},
+	LifeStealAffix:   {min: 1, max: 3, perLevel: 1},
+	CritChanceAffix:  {min: 2, max: 5, perLevel: 1},
+	GoldFindAffix:    {min: 5, max: 15, perLevel: 5},
+}
+
+// Affix is a random bonus rolled on an item
+type Affix struct {
+	Kind  AffixKind
+	Value int
+}
+
+// Weapon represents a weapon item
+type Weapon struct {
+	Kind    WeaponKind
+	Damage  int
+	Rarity  Rarity
+	Affixes []Affix
+	// Upgrades is how many times the weapon was upgraded by crafting
+	Upgrades int
+}
+
+// String returns the name of the rarity
+func (r Rarity) String() string {
+	return rarityNames[r]
+}
+
+// String returns the name of the weapon kind
+func (k WeaponKind) String() string {
+	return weaponKindNames[k]
+}
+
+// String returns the name of the affix kind
+func (a AffixKind) String() string {
+	return affixNames[a]
+}
+
+// AffixValue returns the sum of the affixes of a given kind
+func (w Weapon) AffixValue(kind AffixKind) int {
+	value := 0
+	for _, affix := range w.Affixes {
+		if affix.Kind == kind {
+			value += affix.Value
+		}
+	}
+	return value
+}
+
+func randomRarity(rng *rand.Rand) Rarity {
+	rates, err := alias.New(rarityRates)
+	if err != nil {
+		return Common
+	}
+	return Rarity(rates.Gen(rng))
+}
+
+func randomAffix(kind AffixKind, level int, rng *rand.Rand) Affix {
+	r := affixRanges[kind]
+	value := r.min + rng.Intn(r.max-r.min+1) + (r.perLevel * level)
+
+	return Affix{Kind: kind, Value: value}
+}
+
+// GenerateWeapon creates a random weapon for a given stone level
+func GenerateWeapon(level int, rng *rand.Rand) *Weapon {
+	rarity := randomRarity(rng)
+
+	weapon := &Weapon{
+		Damage:  10 + (5 * level) + rng.Intn(5),
+		Rarity:  rarity,
+		Affixes: make([]Affix, 0, int(rarity)),
+	}
+
+	// Each affix kind is rolled at most once
+	kinds := rng.Perm(len(affixRanges))
+	for _, kind := range kinds[:int(rarity)] {
+		weapon.Affixes = append(weapon.Affixes, randomAffix(AffixKind(kind), level, rng))
+	}
+	weapon.Kind = WeaponKind(rng.Intn(len(weaponKindNames)))
+
+	return weapon
+}
+
+// RandomWeapon returns a random weapon based on current portal
+func (p *Portal) RandomWeapon() *Weapon {
+	return GenerateWeapon(p.PortalStone.Level, p.seed)
+}
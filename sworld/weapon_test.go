@@ -0,0 +1,56 @@
+package sworld
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestGenerateWeaponAffixes(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		weapon := GenerateWeapon(2, rng)
+
+		if len(weapon.Affixes) != int(weapon.Rarity) {
+			t.Fatal("Expected", weapon.Rarity, "weapon to have", int(weapon.Rarity), "affixes, got", len(weapon.Affixes))
+		}
+
+		seen := make(map[AffixKind]bool)
+		for _, affix := range weapon.Affixes {
+			if seen[affix.Kind] {
+				t.Fatal("Expected affixes to not repeat, got", weapon.Affixes)
+			}
+			seen[affix.Kind] = true
+
+			r := affixRanges[affix.Kind]
+			if affix.Value < r.min+(2*r.perLevel) || affix.Value > r.max+(2*r.perLevel) {
+				t.Error("Affix value out of range:", affix.Kind, affix.Value)
+			}
+		}
+	}
+}
+
+func TestGenerateWeaponIsDeterministic(t *testing.T) {
+	weapon1 := GenerateWeapon(3, rand.New(rand.NewSource(7)))
+	weapon2 := GenerateWeapon(3, rand.New(rand.NewSource(7)))
+
+	if weapon1.Damage != weapon2.Damage || weapon1.Rarity != weapon2.Rarity {
+		t.Error("Expected weapons to be equal, got", weapon1, weapon2)
+	}
+}
+
+func TestWeaponAffixValue(t *testing.T) {
+	weapon := Weapon{
+		Affixes: []Affix{
+			{Kind: BonusDamageAffix, Value: 5},
+			{Kind: GoldFindAffix, Value: 20},
+		},
+	}
+
+	if weapon.AffixValue(BonusDamageAffix) != 5 {
+		t.Error("Expected bonus damage to be 5, got", weapon.AffixValue(BonusDamageAffix))
+	}
+	if weapon.AffixValue(LifeStealAffix) != 0 {
+		t.Error("Expected life steal to be 0, got", weapon.AffixValue(LifeStealAffix))
+	}
+}
@@ -21,10 +21,13 @@ type itemDropFn struct {
 // Zone defines the type of enemies that will be found
 // It's the base for creating a portal
 type Zone struct {
-	ID         string
-	Name       string
+	ID     string
+	Name   string
+	Layout *LayoutGenerator
+
 	itemDrops  []itemDropFn
 	eventDrops []eventDropFn
+	roomEvents map[RoomType]func(*Portal, int) *PortalEvent
 }
 
 // NewZone initializes a zone
@@ -34,6 +37,7 @@ func NewZone(name string) *Zone {
 		Name:       name,
 		itemDrops:  make([]itemDropFn, 0, 5),
 		eventDrops: make([]eventDropFn, 0, 5),
+		roomEvents: make(map[RoomType]func(*Portal, int) *PortalEvent),
 	}
 }
 
@@ -55,10 +59,19 @@ func (z *Zone) AddEventDrop(minLevel int, rate float64, fn func(*Portal, int) *P
 	})
 }
 
+// AddRoomEvent registers the event generated when a room is reached
+func (z *Zone) AddRoomEvent(room RoomType, fn func(*Portal, int) *PortalEvent) {
+	z.roomEvents[room] = fn
+}
+
 // InitializePortal initializes portal
 func (z *Zone) InitializePortal(portal *Portal) error {
 	level := portal.PortalStone.Level
 
+	if z.Layout != nil {
+		portal.Layout = z.Layout.Generate(portal.Seed, level)
+	}
+
 	items := make([]float64, 0, len(z.itemDrops))
 	for _, drop := range z.itemDrops {
 		if level >= drop.level {
@@ -72,21 +85,41 @@ func (z *Zone) InitializePortal(portal *Portal) error {
 		}
 	}
 
-	drops, err := alias.New(items)
-	if err != nil {
-		return err
+	// Zones with a layout may not have event drops at all, alias can't be
+	// built from an empty list
+	if len(items) > 0 {
+		drops, err := alias.New(items)
+		if err != nil {
+			return err
+		}
+		portal.drops = drops
 	}
-	portal.drops = drops
-
-	drops, err = alias.New(events)
-	if err != nil {
-		return err
+	if len(events) > 0 {
+		drops, err := alias.New(events)
+		if err != nil {
+			return err
+		}
+		portal.eventsRate = drops
 	}
-	portal.eventsRate = drops
 
 	return nil
 }
 
+// RoomEvent returns the event for the room at a given position of the portal
+func (z *Zone) RoomEvent(portal *Portal, position int) *PortalEvent {
+	if portal.Layout == nil {
+		return nil
+	}
+
+	fn := z.roomEvents[portal.Layout.RoomAt(position)]
+	if fn == nil {
+		return nil
+	}
+
+	return fn(portal, position)
+}
+
+// DropEvent returns a random event from this zone
 func (z *Zone) DropEvent(portal *Portal, position int) *PortalEvent {
 	if portal.eventsRate == nil {
 		log.Println(" ZONE: portal.drops == nil")
@@ -0,0 +1,249 @@
+package sworldservice
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/grilix/sworld/sworld"
+)
+
+var (
+	// ErrAchievementNotFound is when the achievement does not exist
+	ErrAchievementNotFound = errors.New("The achievement was not found")
+	// ErrAchievementLocked is when the achievement was not unlocked yet
+	ErrAchievementLocked = errors.New("The achievement is still locked")
+	// ErrAchievementClaimed is when the reward was already claimed
+	ErrAchievementClaimed = errors.New("The reward was already claimed")
+)
+
+// AchievementReward is what the user gets when claiming an achievement
+type AchievementReward struct {
+	Gold int
+	// Bag is the kind of bag given, from the bag catalog
+	Bag   string
+	Title string
+}
+
+// Achievement is a goal the user can reach
+// When Reach is set, the goal is reaching a value on a single event,
+// otherwise the values of every event are added up
+type Achievement struct {
+	ID          string
+	Name        string
+	Description string
+	Event       ActivityEvent
+	Goal        int
+	Reach       bool
+	Reward      AchievementReward
+}
+
+// AchievementStatus is the progress of a user on an achievement
+type AchievementStatus struct {
+	Achievement Achievement
+	Progress    int
+	Unlocked    bool
+	UnlockedAt  time.Time
+	Claimed     bool
+}
+
+// achievementBook are the achievements users can unlock
+var achievementBook = []Achievement{
+	{
+		ID:          "first_blood",
+		Name:        "First blood",
+		Description: "Kill an enemy",
+		Event:       EnemyKilledEvent,
+		Goal:        1,
+		Reward:      AchievementReward{Gold: 50},
+	},
+	{
+		ID:          "slayer",
+		Name:        "Slayer",
+		Description: "Kill 100 enemies",
+		Event:       EnemyKilledEvent,
+		Goal:        100,
+		Reward:      AchievementReward{Gold: 500, Title: "Slayer"},
+	},
+	{
+		ID:          "stonesmith",
+		Name:        "Stonesmith",
+		Description: "Merge 25 stones",
+		Event:       StoneMergedEvent,
+		Goal:        25,
+		Reward:      AchievementReward{Bag: sworld.StonePouchKind},
+	},
+	{
+		ID:          "delver",
+		Name:        "Delver",
+		Description: "Open a portal with a level 5 stone",
+		Event:       PortalOpenedEvent,
+		Goal:        5,
+		Reach:       true,
+		Reward:      AchievementReward{Gold: 250, Title: "Delver"},
+	},
+	{
+		ID:          "abyss_walker",
+		Name:        "Abyss walker",
+		Description: "Open a portal with a level 10 stone",
+		Event:       PortalOpenedEvent,
+		Goal:        10,
+		Reach:       true,
+		Reward:      AchievementReward{Bag: sworld.StandardBagKind, Title: "Abyss walker"},
+	},
+	{
+		ID:          "fallen",
+		Name:        "Fallen",
+		Description: "Lose a character on a portal",
+		Event:       CharacterDiedEvent,
+		Goal:        1,
+		Reward:      AchievementReward{Title: "Fallen"},
+	},
+	{
+		ID:          "banker",
+		Name:        "Banker",
+		Description: "Bring 10000 gold back to town",
+		Event:       GoldBankedEvent,
+		Goal:        10000,
+		Reward:      AchievementReward{Gold: 1000, Title: "Banker"},
+	},
+}
+
+func findAchievement(id string) (Achievement, error) {
+	for _, achievement := range achievementBook {
+		if achievement.ID == id {
+			return achievement, nil
+		}
+	}
+	return Achievement{}, ErrAchievementNotFound
+}
+
+// userAchievements is the progress of a user, by achievement ID
+type userAchievements struct {
+	progress   map[string]int
+	unlockedAt map[string]time.Time
+	claimed    map[string]bool
+}
+
+type achievementTracker struct {
+	mu    sync.Mutex
+	users map[string]*userAchievements
+}
+
+// forUser returns the progress of a user
+// The tracker needs to be locked
+func (t *achievementTracker) forUser(user *sworld.User) *userAchievements {
+	progress, ok := t.users[user.ID]
+	if !ok {
+		progress = &userAchievements{
+			progress:   make(map[string]int),
+			unlockedAt: make(map[string]time.Time),
+			claimed:    make(map[string]bool),
+		}
+		t.users[user.ID] = progress
+	}
+	return progress
+}
+
+func (u *userAchievements) status(achievement Achievement) AchievementStatus {
+	unlockedAt, unlocked := u.unlockedAt[achievement.ID]
+	return AchievementStatus{
+		Achievement: achievement,
+		Progress:    u.progress[achievement.ID],
+		Unlocked:    unlocked,
+		UnlockedAt:  unlockedAt,
+		Claimed:     u.claimed[achievement.ID],
+	}
+}
+
+// trackAchievements updates the achievements listening to an activity
+func (s *swService) trackAchievements(user *sworld.User, act activity) {
+	if act.value <= 0 {
+		return
+	}
+
+	s.achievements.mu.Lock()
+	defer s.achievements.mu.Unlock()
+
+	progress := s.achievements.forUser(user)
+	for _, achievement := range achievementBook {
+		if achievement.Event != act.event {
+			continue
+		}
+		if _, ok := progress.unlockedAt[achievement.ID]; ok {
+			continue
+		}
+
+		if achievement.Reach {
+			if act.value > progress.progress[achievement.ID] {
+				progress.progress[achievement.ID] = act.value
+			}
+		} else {
+			progress.progress[achievement.ID] += act.value
+		}
+
+		if progress.progress[achievement.ID] >= achievement.Goal {
+			progress.progress[achievement.ID] = achievement.Goal
+			progress.unlockedAt[achievement.ID] = time.Now()
+			log.Printf("User %s unlocked %s\n", user.ID, achievement.ID)
+		}
+	}
+}
+
+func (s *swService) ListAchievements(user *sworld.User) ([]AchievementStatus, error) {
+	s.achievements.mu.Lock()
+	defer s.achievements.mu.Unlock()
+
+	progress := s.achievements.forUser(user)
+	statuses := make([]AchievementStatus, 0, len(achievementBook))
+	for _, achievement := range achievementBook {
+		statuses = append(statuses, progress.status(achievement))
+	}
+	return statuses, nil
+}
+
+func (s *swService) ClaimAchievement(user *sworld.User, id string) (AchievementStatus, error) {
+	achievement, err := findAchievement(id)
+	if err != nil {
+		return AchievementStatus{}, err
+	}
+
+	var status AchievementStatus
+	err = s.inventoryTx(user, nil, func() error {
+		s.achievements.mu.Lock()
+		defer s.achievements.mu.Unlock()
+
+		progress := s.achievements.forUser(user)
+		if _, ok := progress.unlockedAt[id]; !ok {
+			return ErrAchievementLocked
+		}
+		if progress.claimed[id] {
+			return ErrAchievementClaimed
+		}
+
+		reward := achievement.Reward
+		user.Gold += reward.Gold
+		if reward.Bag != "" {
+			offer, err := findBagOffer(reward.Bag)
+			if err != nil {
+				return err
+			}
+			_, err = user.PickupItem(&sworld.BagItem{
+				Kind:     offer.Kind,
+				Capacity: offer.Capacity,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if reward.Title != "" {
+			user.Titles = append(user.Titles, reward.Title)
+		}
+
+		progress.claimed[id] = true
+		status = progress.status(achievement)
+		return nil
+	})
+	return status, err
+}
@@ -0,0 +1,58 @@
+package sworldservice
+
+import (
+	"github.com/grilix/sworld/sworld"
+)
+
+// ActivityEvent is something the user did, achievements and quests keep
+// track of them
+type ActivityEvent string
+
+const (
+	// EnemyKilledEvent is sent with the enemies killed on a run
+	EnemyKilledEvent ActivityEvent = "enemy_killed"
+	// DepthReachedEvent is sent with the deepest position of a run
+	DepthReachedEvent ActivityEvent = "depth_reached"
+	// StoneMergedEvent is sent with the amount of stones merged
+	StoneMergedEvent ActivityEvent = "stone_merged"
+	// PortalOpenedEvent is sent with the level of the stone used
+	PortalOpenedEvent ActivityEvent = "portal_opened"
+	// CharacterDiedEvent is sent when a character dies on a portal
+	CharacterDiedEvent ActivityEvent = "character_died"
+	// GoldBankedEvent is sent with the gold a character brings back to town
+	GoldBankedEvent ActivityEvent = "gold_banked"
+)
+
+// activity is an event, along with the stone of the portal where it
+// happened, if any
+type activity struct {
+	event ActivityEvent
+	value int
+	stone *sworld.PortalStone
+}
+
+func (s *swService) trackActivity(user *sworld.User, act activity) {
+	if user == nil {
+		return
+	}
+	s.trackAchievements(user, act)
+	s.trackQuests(user, act)
+}
+
+// trackEvent tracks an activity that didn't happen on a portal
+func (s *swService) trackEvent(user *sworld.User, event ActivityEvent, value int) {
+	s.trackActivity(user, activity{event: event, value: value})
+}
+
+// trackRun sends the events of a finished portal run
+func (s *swService) trackRun(user *sworld.User, run *sworld.RunRecord) {
+	stone := &run.Stone
+
+	s.trackActivity(user, activity{event: EnemyKilledEvent, value: run.EnemiesKilled, stone: stone})
+	s.trackActivity(user, activity{event: DepthReachedEvent, value: run.Depth, stone: stone})
+	if run.Result == sworld.RunDied {
+		s.trackActivity(user, activity{event: CharacterDiedEvent, value: 1, stone: stone})
+	} else {
+		s.trackActivity(user, activity{event: GoldBankedEvent, value: run.Gold, stone: stone})
+	}
+}
@@ -0,0 +1,374 @@
+package sworldservice
+
+import (
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/grilix/sworld/sworld"
+)
+
+var (
+	// ErrAuctionNotFound is when the auction does not exist
+	ErrAuctionNotFound = errors.New("The auction was not found")
+	// ErrAuctionClosed is when the auction already ended
+	ErrAuctionClosed = errors.New("The auction is closed")
+	// ErrItemNotListable is when the item can't be sold on the auction house
+	ErrItemNotListable = errors.New("That item can't be auctioned")
+	// ErrInvalidAuctionPrice is when the auction has no valid prices
+	ErrInvalidAuctionPrice = errors.New("The auction needs a starting bid or a buyout, and the buyout can't be lower than the starting bid")
+	// ErrInvalidAuctionDuration is when the duration is out of the allowed range
+	ErrInvalidAuctionDuration = errors.New("The auction duration is not valid")
+	// ErrBidTooLow is when the bid doesn't beat the current one
+	ErrBidTooLow = errors.New("The bid is too low")
+	// ErrOwnAuction is when the seller bids on their own auction
+	ErrOwnAuction = errors.New("You can't bid on your own auction")
+	// ErrNoBuyout is when the auction has no buyout price
+	ErrNoBuyout = errors.New("The auction has no buyout")
+)
+
+// TODO: this should be on settings
+const (
+	// auctionFeePercent is taken from what the seller gets
+	auctionFeePercent = 5
+	// bidIncrementPercent is the minimum raise over the current bid
+	bidIncrementPercent = 5
+	minAuctionDuration  = time.Minute
+	maxAuctionDuration  = 48 * time.Hour
+)
+
+// AuctionStatus is the status of an auction
+type AuctionStatus string
+
+const (
+	// AuctionActive is an auction accepting bids
+	AuctionActive AuctionStatus = "active"
+	// AuctionSold is an auction that ended with a buyer
+	AuctionSold AuctionStatus = "sold"
+	// AuctionExpired is an auction that ended without bids
+	AuctionExpired AuctionStatus = "expired"
+)
+
+// Auction is an item listed on the auction house
+// The item is held by the auction house until the auction ends
+type Auction struct {
+	ID          string
+	Seller      *sworld.User
+	Item        sworld.Item
+	StartingBid int
+	Buyout      int
+	Bid         int
+	Bidder      *sworld.User
+	CreatedAt   time.Time
+	EndsAt      time.Time
+	Status      AuctionStatus
+}
+
+type auctionHouse struct {
+	mu       sync.Mutex
+	auctions map[string]*Auction
+	// pending holds the items that didn't fit on the user bags, by user ID
+	pending map[string][]sworld.Item
+}
+
+// MinimumBid returns the lowest bid the auction accepts
+func (a Auction) MinimumBid() int {
+	if a.Bidder == nil {
+		if a.StartingBid > 0 {
+			return a.StartingBid
+		}
+		return 1
+	}
+	increment := (a.Bid * bidIncrementPercent) / 100
+	if increment < 1 {
+		increment = 1
+	}
+	return a.Bid + increment
+}
+
+func listable(item sworld.Item) bool {
+	kind := sworld.ItemKind(item)
+	return kind == sworld.StoneItem || kind == sworld.WeaponItem
+}
+
+func auctionFee(price int) int {
+	return (price * auctionFeePercent) / 100
+}
+
+// deliverItem puts an item on the user bags, or keeps it to be claimed
+// later if it doesn't fit
+// The auction house needs to be locked
+func (s *swService) deliverItem(user *sworld.User, item sworld.Item) {
+	unlock := s.lockInventory(user)
+	_, err := user.PickupItem(item)
+	unlock()
+
+	if err != nil {
+		s.auctions.pending[user.ID] = append(s.auctions.pending[user.ID], item)
+	}
+}
+
+func (s *swService) addGold(user *sworld.User, amount int) {
+	unlock := s.lockInventory(user)
+	user.Gold += amount
+	unlock()
+}
+
+// settleAuction ends an auction, giving the item to the buyer and the gold
+// to the seller, or returning the item when nobody bid
+// The auction house needs to be locked
+func (s *swService) settleAuction(auction *Auction) {
+	if auction.Status != AuctionActive {
+		return
+	}
+
+	if auction.Bidder == nil {
+		auction.Status = AuctionExpired
+		s.deliverItem(auction.Seller, auction.Item)
+		log.Printf("Auction %s expired\n", auction.ID)
+		return
+	}
+
+	auction.Status = AuctionSold
+	s.deliverItem(auction.Bidder, auction.Item)
+	s.addGold(auction.Seller, auction.Bid-auctionFee(auction.Bid))
+	log.Printf("Auction %s sold for %d\n", auction.ID, auction.Bid)
+}
+
+func (s *swService) findAuction(id string) (*Auction, error) {
+	auction, ok := s.auctions.auctions[id]
+	if !ok {
+		return nil, ErrAuctionNotFound
+	}
+	return auction, nil
+}
+
+func (s *swService) CreateAuction(user *sworld.User, location sworld.ItemLocation, startingBid, buyout int, duration time.Duration) (*Auction, error) {
+	if startingBid < 0 || buyout < 0 || (startingBid == 0 && buyout == 0) {
+		return nil, ErrInvalidAuctionPrice
+	}
+	if buyout > 0 && buyout < startingBid {
+		return nil, ErrInvalidAuctionPrice
+	}
+	if duration < minAuctionDuration || duration > maxAuctionDuration {
+		return nil, ErrInvalidAuctionDuration
+	}
+
+	var item sworld.Item
+	err := s.inventoryTx(user, []string{location.CharacterID}, func() error {
+		var err error
+		item, err = user.GetItem(location)
+		if err != nil {
+			return err
+		}
+		if !listable(item) {
+			return ErrItemNotListable
+		}
+		return user.DropItemAt(location)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	auction := &Auction{
+		ID:          sworld.RandomID(16),
+		Seller:      user,
+		Item:        item,
+		StartingBid: startingBid,
+		Buyout:      buyout,
+		CreatedAt:   now,
+		EndsAt:      now.Add(duration),
+		Status:      AuctionActive,
+	}
+
+	s.auctions.mu.Lock()
+	s.auctions.auctions[auction.ID] = auction
+	s.auctions.mu.Unlock()
+
+	time.AfterFunc(duration, func() {
+		s.auctions.mu.Lock()
+		defer s.auctions.mu.Unlock()
+
+		s.settleAuction(auction)
+	})
+
+	return auction, nil
+}
+
+func auctionSortValue(auction *Auction, sortBy string) int64 {
+	switch sortBy {
+	case "price":
+		if auction.Bidder != nil {
+			return int64(auction.Bid)
+		}
+		if auction.StartingBid > 0 {
+			return int64(auction.StartingBid)
+		}
+		return int64(auction.Buyout)
+	case sworld.SortByLevel:
+		if stone, ok := auction.Item.(*sworld.PortalStone); ok {
+			return int64(stone.Level)
+		}
+	case sworld.SortByDamage:
+		if weapon, ok := auction.Item.(*sworld.Weapon); ok {
+			return int64(weapon.Damage)
+		}
+	default:
+		return auction.EndsAt.UnixNano()
+	}
+	return 0
+}
+
+func (s *swService) SearchAuctions(user *sworld.User, query sworld.InventoryQuery) ([]*Auction, int, error) {
+	s.auctions.mu.Lock()
+	defer s.auctions.mu.Unlock()
+
+	found := make([]*Auction, 0)
+	for _, auction := range s.auctions.auctions {
+		if auction.Status == AuctionActive && query.Filter.Matches(auction.Item) {
+			found = append(found, auction)
+		}
+	}
+
+	// Auctions ending first are shown first, unless sorted by something else
+	sort.SliceStable(found, func(i, j int) bool {
+		a := auctionSortValue(found[i], query.SortBy)
+		b := auctionSortValue(found[j], query.SortBy)
+		if a == b {
+			return found[i].ID < found[j].ID
+		}
+		if query.Descending {
+			return a > b
+		}
+		return a < b
+	})
+
+	total := len(found)
+	if query.Offset > 0 {
+		if query.Offset >= total {
+			return []*Auction{}, total, nil
+		}
+		found = found[query.Offset:]
+	}
+	if query.Limit > 0 && query.Limit < len(found) {
+		found = found[:query.Limit]
+	}
+
+	return found, total, nil
+}
+
+func (s *swService) ViewAuction(id string) (*Auction, error) {
+	s.auctions.mu.Lock()
+	defer s.auctions.mu.Unlock()
+
+	return s.findAuction(id)
+}
+
+// placeBid takes the gold from the bidder and returns the gold of the
+// previous bid
+// The auction house needs to be locked
+func (s *swService) placeBid(auction *Auction, user *sworld.User, amount int) error {
+	unlock := s.lockInventory(user)
+	err := user.SpendGold(amount)
+	unlock()
+	if err != nil {
+		return err
+	}
+
+	if auction.Bidder != nil {
+		s.addGold(auction.Bidder, auction.Bid)
+	}
+	auction.Bidder = user
+	auction.Bid = amount
+	return nil
+}
+
+func (s *swService) BidAuction(user *sworld.User, id string, amount int) (*Auction, error) {
+	s.auctions.mu.Lock()
+	defer s.auctions.mu.Unlock()
+
+	auction, err := s.findAuction(id)
+	if err != nil {
+		return nil, err
+	}
+	if auction.Status != AuctionActive {
+		return nil, ErrAuctionClosed
+	}
+	if auction.Seller.ID == user.ID {
+		return nil, ErrOwnAuction
+	}
+	if amount < auction.MinimumBid() {
+		return nil, ErrBidTooLow
+	}
+	// Bidding the buyout is the same as buying it
+	if auction.Buyout > 0 && amount >= auction.Buyout {
+		amount = auction.Buyout
+	}
+
+	err = s.placeBid(auction, user, amount)
+	if err != nil {
+		return nil, err
+	}
+	if amount == auction.Buyout {
+		s.settleAuction(auction)
+	}
+
+	return auction, nil
+}
+
+func (s *swService) BuyoutAuction(user *sworld.User, id string) (*Auction, error) {
+	s.auctions.mu.Lock()
+	defer s.auctions.mu.Unlock()
+
+	auction, err := s.findAuction(id)
+	if err != nil {
+		return nil, err
+	}
+	if auction.Status != AuctionActive {
+		return nil, ErrAuctionClosed
+	}
+	if auction.Seller.ID == user.ID {
+		return nil, ErrOwnAuction
+	}
+	if auction.Buyout == 0 {
+		return nil, ErrNoBuyout
+	}
+
+	err = s.placeBid(auction, user, auction.Buyout)
+	if err != nil {
+		return nil, err
+	}
+	s.settleAuction(auction)
+
+	return auction, nil
+}
+
+func (s *swService) ClaimAuctionItems(user *sworld.User) ([]sworld.ItemLocation, int, error) {
+	s.auctions.mu.Lock()
+	defer s.auctions.mu.Unlock()
+
+	unlock := s.lockInventory(user)
+	defer unlock()
+
+	pending := s.auctions.pending[user.ID]
+	locations := make([]sworld.ItemLocation, 0, len(pending))
+	for len(pending) > 0 {
+		location, err := user.PickupItem(pending[0])
+		if err != nil {
+			break
+		}
+		locations = append(locations, location)
+		pending = pending[1:]
+	}
+
+	if len(pending) == 0 {
+		delete(s.auctions.pending, user.ID)
+	} else {
+		s.auctions.pending[user.ID] = pending
+	}
+
+	return locations, len(pending), nil
+}
@@ -13,12 +13,12 @@ type Credentials struct {
 func (s *swService) createUser(username string) (*sworld.User, error) {
 	user := &sUser{
 		u: &sworld.User{
-			Bags:     []sworld.Bag{sworld.NewStandardBag(10)}, // TODO: bag capacity
+			Bags:     []sworld.Bag{sworld.NewStandardBag(s.userBagCapacity)},
 			ID:       sworld.RandomID(16),
 			Username: username,
 		},
 	}
-	s.SpawnCharacter(user.u)
+	s.SpawnCharacter(user.u, "")
 	s.users[user.u.ID] = user
 	return user.u, nil
 }
@@ -0,0 +1,106 @@
+package sworldservice
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/grilix/sworld/sworld"
+)
+
+var (
+	// ErrBagNotForSale is when there is no bag of that kind on sale
+	ErrBagNotForSale = errors.New("That bag is not for sale")
+)
+
+type bagOffer struct {
+	Kind     string
+	Capacity int
+	Price    int
+}
+
+// TODO: this should be on settings
+var bagCatalog = []bagOffer{
+	{Kind: sworld.StandardBagKind, Capacity: 10, Price: 500},
+	{Kind: sworld.StonePouchKind, Capacity: 15, Price: 250},
+	{Kind: sworld.WeaponRackKind, Capacity: 8, Price: 250},
+	{Kind: sworld.ExpandableBagKind, Capacity: 5, Price: 300},
+}
+
+// bagUpgradePrice is the price for each slot of the bag being expanded
+const bagUpgradePrice = 20
+
+func findBagOffer(kind string) (bagOffer, error) {
+	for _, offer := range bagCatalog {
+		if offer.Kind == kind {
+			return offer, nil
+		}
+	}
+	return bagOffer{}, ErrBagNotForSale
+}
+
+func randomBagItem(portal *sworld.Portal) sworld.Item {
+	offer := bagCatalog[rand.Intn(len(bagCatalog))]
+
+	return &sworld.BagItem{
+		Kind:     offer.Kind,
+		Capacity: offer.Capacity,
+	}
+}
+
+func (s *swService) BuyBag(user *sworld.User, kind string) (sworld.ItemLocation, error) {
+	offer, err := findBagOffer(kind)
+	if err != nil {
+		return sworld.ItemLocation{}, err
+	}
+
+	var location sworld.ItemLocation
+	err = s.inventoryTx(user, nil, func() error {
+		err := user.SpendGold(offer.Price)
+		if err != nil {
+			return err
+		}
+
+		location, err = user.PickupItem(&sworld.BagItem{
+			Kind:     offer.Kind,
+			Capacity: offer.Capacity,
+		})
+		return err
+	})
+	if err != nil {
+		return sworld.ItemLocation{}, err
+	}
+
+	return location, nil
+}
+
+func (s *swService) AttachBag(user *sworld.User, location sworld.ItemLocation, characterID string) error {
+	unlock := s.lockInventory(user, location.CharacterID, characterID)
+	defer unlock()
+
+	return user.AttachBag(location, characterID)
+}
+
+func (s *swService) UpgradeBag(user *sworld.User, characterID string, bagID int) error {
+	return s.inventoryTx(user, []string{characterID}, func() error {
+		return s.upgradeBag(user, characterID, bagID)
+	})
+}
+
+func (s *swService) upgradeBag(user *sworld.User, characterID string, bagID int) error {
+	bag, err := user.FindBag(characterID, bagID)
+	if err != nil {
+		return err
+	}
+	expandable, ok := bag.(*sworld.ExpandableBag)
+	if !ok {
+		return sworld.ErrBagNotExpandable
+	}
+
+	price := expandable.Capacity() * bagUpgradePrice
+	err = user.SpendGold(price)
+	if err != nil {
+		return err
+	}
+
+	return expandable.Expand()
+}
@@ -0,0 +1,392 @@
+package sworldservice
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grilix/sworld/sworld"
+)
+
+var (
+	// ErrInvalidChatChannel is when the chat channel is not known
+	ErrInvalidChatChannel = errors.New("The chat channel is not valid")
+	// ErrInvalidChatMessage is when the message is empty or too long
+	ErrInvalidChatMessage = errors.New("The message is not valid")
+	// ErrChatRateLimited is when the user sends too many messages
+	ErrChatRateLimited = errors.New("You are sending messages too fast")
+	// ErrChatBlocked is when the recipient of a whisper blocked the user
+	ErrChatBlocked = errors.New("That user is not accepting your messages")
+	// ErrChatWithYourself is when the user whispers to itself
+	ErrChatWithYourself = errors.New("You can't whisper to yourself")
+)
+
+// ChatChannel is where a message is sent
+type ChatChannel string
+
+const (
+	// GlobalChat reaches every user
+	GlobalChat ChatChannel = "global"
+	// ZoneChat reaches the users listening to a zone
+	ZoneChat ChatChannel = "zone"
+	// PartyChat reaches the members of the party of the user
+	PartyChat ChatChannel = "party"
+	// GuildChat reaches the members of the guild of the user
+	GuildChat ChatChannel = "guild"
+	// WhisperChat reaches a single user
+	WhisperChat ChatChannel = "whisper"
+)
+
+// TODO: this should be on settings
+const (
+	// chatHistorySize is the amount of messages kept for each channel
+	chatHistorySize   = 100
+	maxChatMessageLen = 500
+	// chatRateLimit is the amount of messages a user can send on each
+	// chatRateWindow
+	chatRateLimit  = 5
+	chatRateWindow = 10 * time.Second
+	// chatBuffer is the amount of messages a subscription holds before
+	// new messages are dropped
+	chatBuffer = 64
+)
+
+// ChatMessage is a message sent to a channel
+// Target is the zone, party or guild of the channel, empty for global and
+// whisper messages
+type ChatMessage struct {
+	ID      string
+	Channel ChatChannel
+	Target  string
+	From    *sworld.User
+	To      *sworld.User
+	Text    string
+	At      time.Time
+}
+
+// ChatSubscription receives the messages for a user
+// Zone messages are only received for the zone of the subscription
+type ChatSubscription struct {
+	C <-chan *ChatMessage
+
+	user   *sworld.User
+	zoneID string
+	c      chan *ChatMessage
+}
+
+// ChatSettings are the users muted and blocked by a user
+// Muted users are hidden on global and zone channels, blocked users are
+// hidden everywhere and can't whisper
+type ChatSettings struct {
+	Muted   []*sworld.User
+	Blocked []*sworld.User
+}
+
+type chatRoom struct {
+	mu sync.Mutex
+	// history are the last messages of each channel, by channel key
+	history map[string][]*ChatMessage
+	// subscriptions are the subscriptions of each user, by user ID
+	subscriptions map[string][]*ChatSubscription
+	// muted and blocked are sets of user IDs, by user ID
+	muted   map[string]map[string]*sworld.User
+	blocked map[string]map[string]*sworld.User
+	// sent are the times of the last messages of each user, by user ID
+	sent map[string][]time.Time
+}
+
+// chatKey returns the key of the history of a channel
+func chatKey(channel ChatChannel, target string, from, to *sworld.User) string {
+	if channel == WhisperChat {
+		ids := []string{from.ID, to.ID}
+		sort.Strings(ids)
+		return string(channel) + ":" + ids[0] + ":" + ids[1]
+	}
+	if target == "" {
+		return string(channel)
+	}
+	return string(channel) + ":" + target
+}
+
+// hides returns whether the user doesn't want to see a message
+// The chat room needs to be locked
+func (r *chatRoom) hides(userID string, message *ChatMessage) bool {
+	if _, ok := r.blocked[userID][message.From.ID]; ok {
+		return true
+	}
+	if message.Channel == GlobalChat || message.Channel == ZoneChat {
+		_, ok := r.muted[userID][message.From.ID]
+		return ok
+	}
+	return false
+}
+
+// allow records a message being sent, it returns false when the user
+// reached the rate limit
+// The chat room needs to be locked
+func (r *chatRoom) allow(userID string, now time.Time) bool {
+	sent := r.sent[userID][:0]
+	for _, at := range r.sent[userID] {
+		if now.Sub(at) < chatRateWindow {
+			sent = append(sent, at)
+		}
+	}
+	if len(sent) >= chatRateLimit {
+		r.sent[userID] = sent
+		return false
+	}
+	r.sent[userID] = append(sent, now)
+	return true
+}
+
+// chatChannel returns the target of a channel for the user, and the IDs of
+// the users reached by it, nil for channels reaching everyone
+func (s *swService) chatChannel(user *sworld.User, channel ChatChannel, target string) (string, map[string]bool, *sworld.User, error) {
+	switch channel {
+	case GlobalChat:
+		return "", nil, nil, nil
+	case ZoneChat:
+		zone, err := s.findZone(target)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		return zone.ID, nil, nil, nil
+	case PartyChat:
+		s.parties.mu.Lock()
+		defer s.parties.mu.Unlock()
+
+		party, ok := s.parties.parties[s.parties.members[user.ID]]
+		if !ok {
+			return "", nil, nil, ErrNotInParty
+		}
+		recipients := make(map[string]bool, len(party.Members))
+		for _, member := range party.Members {
+			recipients[member.User.ID] = true
+		}
+		return party.ID, recipients, nil, nil
+	case GuildChat:
+		s.guilds.mu.Lock()
+		defer s.guilds.mu.Unlock()
+
+		guild, ok := s.guilds.guilds[s.guilds.members[user.ID]]
+		if !ok {
+			return "", nil, nil, ErrNotInGuild
+		}
+		recipients := make(map[string]bool, len(guild.Members))
+		for _, member := range guild.Members {
+			recipients[member.User.ID] = true
+		}
+		return guild.ID, recipients, nil, nil
+	case WhisperChat:
+		to, err := s.findUserByUsername(target)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		if to.ID == user.ID {
+			return "", nil, nil, ErrChatWithYourself
+		}
+		return "", map[string]bool{user.ID: true, to.ID: true}, to, nil
+	}
+	return "", nil, nil, ErrInvalidChatChannel
+}
+
+// SendChatMessage sends a message to a channel
+// The target is the zone ID for zone messages and the username for whispers,
+// party and guild messages go to the party and guild of the user
+func (s *swService) SendChatMessage(user *sworld.User, channel string, target string, text string) (*ChatMessage, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || len(text) > maxChatMessageLen {
+		return nil, ErrInvalidChatMessage
+	}
+	chatTarget, recipients, to, err := s.chatChannel(user, ChatChannel(channel), target)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	message := &ChatMessage{
+		ID:      sworld.RandomID(16),
+		Channel: ChatChannel(channel),
+		Target:  chatTarget,
+		From:    user,
+		To:      to,
+		Text:    text,
+		At:      now,
+	}
+
+	s.chat.mu.Lock()
+	defer s.chat.mu.Unlock()
+
+	if to != nil {
+		if _, ok := s.chat.blocked[to.ID][user.ID]; ok {
+			return nil, ErrChatBlocked
+		}
+	}
+	if !s.chat.allow(user.ID, now) {
+		return nil, ErrChatRateLimited
+	}
+
+	key := chatKey(message.Channel, message.Target, user, to)
+	history := append(s.chat.history[key], message)
+	if len(history) > chatHistorySize {
+		history = history[len(history)-chatHistorySize:]
+	}
+	s.chat.history[key] = history
+
+	for userID, subscriptions := range s.chat.subscriptions {
+		if recipients != nil && !recipients[userID] {
+			continue
+		}
+		if s.chat.hides(userID, message) {
+			continue
+		}
+		for _, subscription := range subscriptions {
+			if message.Channel == ZoneChat && subscription.zoneID != message.Target {
+				continue
+			}
+			// Slow subscribers miss messages instead of blocking the chat
+			select {
+			case subscription.c <- message:
+			default:
+			}
+		}
+	}
+
+	return message, nil
+}
+
+// ChatHistory returns the last messages of a channel, oldest first
+func (s *swService) ChatHistory(user *sworld.User, channel string, target string, limit int) ([]*ChatMessage, error) {
+	chatTarget, _, to, err := s.chatChannel(user, ChatChannel(channel), target)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > chatHistorySize {
+		limit = chatHistorySize
+	}
+
+	s.chat.mu.Lock()
+	defer s.chat.mu.Unlock()
+
+	history := s.chat.history[chatKey(ChatChannel(channel), chatTarget, user, to)]
+	messages := make([]*ChatMessage, 0, limit)
+	for i := len(history) - 1; i >= 0 && len(messages) < limit; i-- {
+		if !s.chat.hides(user.ID, history[i]) {
+			messages = append(messages, history[i])
+		}
+	}
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+	return messages, nil
+}
+
+// SubscribeChat starts receiving the messages for the user, and the zone
+// messages of a zone
+func (s *swService) SubscribeChat(user *sworld.User, zoneID string) (*ChatSubscription, error) {
+	zone, err := s.findZone(zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	c := make(chan *ChatMessage, chatBuffer)
+	subscription := &ChatSubscription{
+		C:      c,
+		user:   user,
+		zoneID: zone.ID,
+		c:      c,
+	}
+
+	s.chat.mu.Lock()
+	defer s.chat.mu.Unlock()
+
+	s.chat.subscriptions[user.ID] = append(s.chat.subscriptions[user.ID], subscription)
+	return subscription, nil
+}
+
+// UnsubscribeChat stops a subscription, closing its channel
+func (s *swService) UnsubscribeChat(subscription *ChatSubscription) {
+	s.chat.mu.Lock()
+	defer s.chat.mu.Unlock()
+
+	userID := subscription.user.ID
+	subscriptions := s.chat.subscriptions[userID][:0]
+	for _, sub := range s.chat.subscriptions[userID] {
+		if sub == subscription {
+			close(sub.c)
+			continue
+		}
+		subscriptions = append(subscriptions, sub)
+	}
+	if len(subscriptions) == 0 {
+		delete(s.chat.subscriptions, userID)
+	} else {
+		s.chat.subscriptions[userID] = subscriptions
+	}
+}
+
+// chatSettings returns the users muted and blocked by a user
+// The chat room needs to be locked
+func (r *chatRoom) chatSettings(userID string) *ChatSettings {
+	settings := &ChatSettings{
+		Muted:   make([]*sworld.User, 0, len(r.muted[userID])),
+		Blocked: make([]*sworld.User, 0, len(r.blocked[userID])),
+	}
+	for _, user := range r.muted[userID] {
+		settings.Muted = append(settings.Muted, user)
+	}
+	for _, user := range r.blocked[userID] {
+		settings.Blocked = append(settings.Blocked, user)
+	}
+	sort.Slice(settings.Muted, func(i, j int) bool {
+		return settings.Muted[i].Username < settings.Muted[j].Username
+	})
+	sort.Slice(settings.Blocked, func(i, j int) bool {
+		return settings.Blocked[i].Username < settings.Blocked[j].Username
+	})
+	return settings
+}
+
+// setChatUser adds or removes a user from a mute or block list
+func (s *swService) setChatUser(user *sworld.User, username string, list map[string]map[string]*sworld.User, add bool) (*ChatSettings, error) {
+	other, err := s.findUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+	if other.ID == user.ID {
+		return nil, ErrChatWithYourself
+	}
+
+	if add {
+		if list[user.ID] == nil {
+			list[user.ID] = make(map[string]*sworld.User)
+		}
+		list[user.ID][other.ID] = other
+	} else {
+		delete(list[user.ID], other.ID)
+	}
+	return s.chat.chatSettings(user.ID), nil
+}
+
+func (s *swService) ChatSettings(user *sworld.User) (*ChatSettings, error) {
+	s.chat.mu.Lock()
+	defer s.chat.mu.Unlock()
+
+	return s.chat.chatSettings(user.ID), nil
+}
+
+func (s *swService) MuteChatUser(user *sworld.User, username string, muted bool) (*ChatSettings, error) {
+	s.chat.mu.Lock()
+	defer s.chat.mu.Unlock()
+
+	return s.setChatUser(user, username, s.chat.muted, muted)
+}
+
+func (s *swService) BlockChatUser(user *sworld.User, username string, blocked bool) (*ChatSettings, error) {
+	s.chat.mu.Lock()
+	defer s.chat.mu.Unlock()
+
+	return s.setChatUser(user, username, s.chat.blocked, blocked)
+}
@@ -0,0 +1,131 @@
+package sworldservice
+
+import (
+	"errors"
+	"time"
+
+	"github.com/grilix/sworld/sworld"
+)
+
+var (
+	// ErrRecipeNotFound is when the recipe does not exist
+	ErrRecipeNotFound = errors.New("The recipe was not found")
+)
+
+// TODO: this should be on settings
+var recipeBook = []sworld.Recipe{
+	{
+		ID:   "health_potion",
+		Name: "Health potion",
+		Materials: []sworld.Ingredient{
+			{Kind: sworld.EssenceDust, Quantity: 3},
+		},
+		Gold:   10,
+		Output: sworld.CraftConsumable,
+		Consumable: sworld.Consumable{
+			Kind:     sworld.HealthPotion,
+			Power:    40,
+			Quantity: 3,
+		},
+	},
+	{
+		ID:   "damage_buff",
+		Name: "Damage elixir",
+		Materials: []sworld.Ingredient{
+			{Kind: sworld.IronOre, Quantity: 2},
+			{Kind: sworld.EssenceDust, Quantity: 2},
+		},
+		Gold:   20,
+		Output: sworld.CraftConsumable,
+		Consumable: sworld.Consumable{
+			Kind:     sworld.DamageBuff,
+			Power:    15,
+			Duration: 10 * time.Second,
+			Quantity: 2,
+		},
+	},
+	{
+		ID:   "portal_extender",
+		Name: "Portal extender",
+		Materials: []sworld.Ingredient{
+			{Kind: sworld.StoneShard, Quantity: 5},
+		},
+		Gold:   50,
+		Output: sworld.CraftConsumable,
+		Consumable: sworld.Consumable{
+			Kind:     sworld.PortalExtender,
+			Power:    5,
+			Quantity: 1,
+		},
+	},
+	{
+		ID:   "forge_stone",
+		Name: "Forge a portal stone",
+		Materials: []sworld.Ingredient{
+			{Kind: sworld.StoneShard, Quantity: 8},
+		},
+		Gold:   100,
+		Output: sworld.CraftStone,
+		Stone: sworld.PortalStone{
+			Level:    1,
+			Duration: 15 * time.Second,
+		},
+	},
+	{
+		ID:   "upgrade_weapon",
+		Name: "Sharpen a weapon",
+		Materials: []sworld.Ingredient{
+			{Kind: sworld.IronOre, Quantity: 5},
+			{Kind: sworld.EssenceDust, Quantity: 2},
+		},
+		Gold:   100,
+		Input:  sworld.WeaponItem,
+		Output: sworld.CraftUpgrade,
+		Damage: 5,
+	},
+	{
+		ID:     "salvage_weapon",
+		Name:   "Salvage a weapon",
+		Input:  sworld.WeaponItem,
+		Output: sworld.CraftSalvage,
+	},
+	{
+		ID:     "salvage_stone",
+		Name:   "Salvage a portal stone",
+		Input:  sworld.StoneItem,
+		Output: sworld.CraftSalvage,
+	},
+}
+
+func findRecipe(id string) (sworld.Recipe, error) {
+	for _, recipe := range recipeBook {
+		if recipe.ID == id {
+			return recipe, nil
+		}
+	}
+	return sworld.Recipe{}, ErrRecipeNotFound
+}
+
+func randomMaterial(portal *sworld.Portal) sworld.Item {
+	return portal.RandomMaterial()
+}
+
+func (s *swService) ListRecipes() ([]sworld.Recipe, error) {
+	return recipeBook, nil
+}
+
+func (s *swService) Craft(user *sworld.User, recipeID string, input *sworld.ItemLocation) ([]sworld.ItemLocation, error) {
+	recipe, err := findRecipe(recipeID)
+	if err != nil {
+		return nil, err
+	}
+
+	characterID := ""
+	if input != nil {
+		characterID = input.CharacterID
+	}
+	unlock := s.lockInventory(user, characterID)
+	defer unlock()
+
+	return user.Craft(recipe, input, s.defaultZone)
+}
@@ -0,0 +1,563 @@
+package sworldservice
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grilix/sworld/sworld"
+)
+
+var (
+	// ErrGuildNotFound is when the guild does not exist
+	ErrGuildNotFound = errors.New("The guild was not found")
+	// ErrNotInGuild is when the user is not a member of a guild
+	ErrNotInGuild = errors.New("You are not on a guild")
+	// ErrAlreadyInGuild is when the user is already a member of a guild
+	ErrAlreadyInGuild = errors.New("The user is already on a guild")
+	// ErrInvalidGuildName is when the name of the guild is not valid
+	ErrInvalidGuildName = errors.New("The guild name is not valid")
+	// ErrGuildNameTaken is when another guild has the same name
+	ErrGuildNameTaken = errors.New("There is already a guild with that name")
+	// ErrGuildFull is when the guild has no room for more members
+	ErrGuildFull = errors.New("The guild is full")
+	// ErrNotInvited is when the user was not invited to the guild
+	ErrNotInvited = errors.New("You were not invited to that guild")
+	// ErrGuildPermission is when the role of the user doesn't allow an action
+	ErrGuildPermission = errors.New("Your guild role doesn't allow that")
+	// ErrInvalidGuildRole is when the role is not known
+	ErrInvalidGuildRole = errors.New("The guild role is not valid")
+	// ErrLeaderCantLeave is when the leader leaves a guild with other members
+	ErrLeaderCantLeave = errors.New("The leader can't leave the guild, pass the lead first")
+	// ErrStashFull is when there is no room for the item on the guild stash
+	ErrStashFull = errors.New("There is no room on the guild stash")
+	// ErrInvalidAmount is when the amount of gold is not valid
+	ErrInvalidAmount = errors.New("The amount is not valid")
+	// ErrGuildPortalStone is when a guild portal is opened without a stone
+	ErrGuildPortalStone = errors.New("Guild portals need a stone")
+)
+
+// GuildRole is the role of a member on a guild
+type GuildRole string
+
+const (
+	// GuildLeaderRole is the owner of the guild, there is only one
+	GuildLeaderRole GuildRole = "leader"
+	// GuildOfficerRole manages the members and the stash
+	GuildOfficerRole GuildRole = "officer"
+	// GuildMemberRole is a regular member
+	GuildMemberRole GuildRole = "member"
+)
+
+// guildRoleRanks sorts the roles, higher ranks can do everything lower ranks
+// can
+var guildRoleRanks = map[GuildRole]int{
+	GuildMemberRole:  0,
+	GuildOfficerRole: 1,
+	GuildLeaderRole:  2,
+}
+
+// guildAction is something a member can do on a guild
+type guildAction string
+
+const (
+	depositItemAction  guildAction = "deposit_item"
+	withdrawItemAction guildAction = "withdraw_item"
+	depositGoldAction  guildAction = "deposit_gold"
+	withdrawGoldAction guildAction = "withdraw_gold"
+	inviteAction       guildAction = "invite"
+	kickAction         guildAction = "kick"
+	guildPortalAction  guildAction = "open_portal"
+	setRoleAction      guildAction = "set_role"
+)
+
+// guildPermissions is the lowest role allowed to do each action
+var guildPermissions = map[guildAction]GuildRole{
+	depositItemAction:  GuildMemberRole,
+	withdrawItemAction: GuildOfficerRole,
+	depositGoldAction:  GuildMemberRole,
+	withdrawGoldAction: GuildLeaderRole,
+	inviteAction:       GuildOfficerRole,
+	kickAction:         GuildOfficerRole,
+	guildPortalAction:  GuildOfficerRole,
+	setRoleAction:      GuildLeaderRole,
+}
+
+// TODO: this should be on settings
+const (
+	// guildCost is the gold needed to create a guild
+	guildCost          = 500
+	maxGuildMembers    = 50
+	maxGuildNameLength = 24
+	// guildStashBags is the amount of bags of the stash of new guilds
+	guildStashBags     = 2
+	guildStashCapacity = 20
+)
+
+// GuildMember is a user on a guild
+type GuildMember struct {
+	User     *sworld.User
+	Role     GuildRole
+	JoinedAt time.Time
+}
+
+// Guild is a group of users sharing a stash and a gold bank
+type Guild struct {
+	ID        string
+	Name      string
+	Members   []*GuildMember
+	Stash     []sworld.Bag
+	Gold      int
+	CreatedAt time.Time
+
+	// invites are the users allowed to join, by user ID
+	invites map[string]bool
+}
+
+type guildList struct {
+	mu     sync.Mutex
+	guilds map[string]*Guild
+	// members are the guild IDs of the users, by user ID
+	members map[string]string
+}
+
+func guildLockKey(id string) string {
+	return "guild:" + id
+}
+
+// member returns the membership of a user
+func (g *Guild) member(userID string) (*GuildMember, error) {
+	for _, member := range g.Members {
+		if member.User.ID == userID {
+			return member, nil
+		}
+	}
+	return nil, ErrNotInGuild
+}
+
+func (g *Guild) memberByUsername(username string) (*GuildMember, error) {
+	for _, member := range g.Members {
+		if member.User.Username == username {
+			return member, nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+func (g *Guild) removeMember(userID string) {
+	members := g.Members[:0]
+	for _, member := range g.Members {
+		if member.User.ID != userID {
+			members = append(members, member)
+		}
+	}
+	g.Members = members
+}
+
+// allowed returns whether a member can do an action
+func (m *GuildMember) allowed(action guildAction) error {
+	if guildRoleRanks[m.Role] < guildRoleRanks[guildPermissions[action]] {
+		return ErrGuildPermission
+	}
+	return nil
+}
+
+// guildOf returns the guild of a user
+func (s *swService) guildOf(userID string) (*Guild, error) {
+	s.guilds.mu.Lock()
+	defer s.guilds.mu.Unlock()
+
+	guildID, ok := s.guilds.members[userID]
+	if !ok {
+		return nil, ErrNotInGuild
+	}
+	return s.guilds.guilds[guildID], nil
+}
+
+// lockGuild finds the guild of the user and locks it, along with the
+// inventory of the user
+func (s *swService) lockGuild(user *sworld.User) (*Guild, *GuildMember, func(), error) {
+	guild, err := s.guildOf(user.ID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	unlock := s.locks.Lock(guildLockKey(guild.ID), userLockKey(user.ID))
+	// The user could have been kicked while waiting for the lock
+	member, err := guild.member(user.ID)
+	if err != nil {
+		unlock()
+		return nil, nil, nil, err
+	}
+	return guild, member, unlock, nil
+}
+
+func (s *swService) CreateGuild(user *sworld.User, name string) (*Guild, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxGuildNameLength {
+		return nil, ErrInvalidGuildName
+	}
+
+	unlock := s.lockInventory(user)
+	defer unlock()
+
+	s.guilds.mu.Lock()
+	defer s.guilds.mu.Unlock()
+
+	if _, ok := s.guilds.members[user.ID]; ok {
+		return nil, ErrAlreadyInGuild
+	}
+	for _, guild := range s.guilds.guilds {
+		if strings.EqualFold(guild.Name, name) {
+			return nil, ErrGuildNameTaken
+		}
+	}
+	if err := user.SpendGold(guildCost); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	guild := &Guild{
+		ID:   sworld.RandomID(16),
+		Name: name,
+		Members: []*GuildMember{
+			{User: user, Role: GuildLeaderRole, JoinedAt: now},
+		},
+		Stash:     make([]sworld.Bag, 0, guildStashBags),
+		CreatedAt: now,
+		invites:   make(map[string]bool),
+	}
+	for i := 0; i < guildStashBags; i++ {
+		guild.Stash = append(guild.Stash, sworld.NewStandardBag(guildStashCapacity))
+	}
+
+	s.guilds.guilds[guild.ID] = guild
+	s.guilds.members[user.ID] = guild.ID
+	log.Printf("Guild created: %s by %s\n", guild.ID, user.ID)
+
+	return guild, nil
+}
+
+func (s *swService) ViewGuild(user *sworld.User) (*Guild, error) {
+	guild, _, unlock, err := s.lockGuild(user)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	return guild, nil
+}
+
+func (s *swService) InviteToGuild(user *sworld.User, username string) (*Guild, error) {
+	invited, err := s.findUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	guild, member, unlock, err := s.lockGuild(user)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if err := member.allowed(inviteAction); err != nil {
+		return nil, err
+	}
+	if _, err := s.guildOf(invited.ID); err == nil {
+		return nil, ErrAlreadyInGuild
+	}
+
+	guild.invites[invited.ID] = true
+	return guild, nil
+}
+
+func (s *swService) JoinGuild(user *sworld.User, guildID string) (*Guild, error) {
+	s.guilds.mu.Lock()
+	guild, ok := s.guilds.guilds[guildID]
+	s.guilds.mu.Unlock()
+	if !ok {
+		return nil, ErrGuildNotFound
+	}
+
+	unlock := s.locks.Lock(guildLockKey(guild.ID), userLockKey(user.ID))
+	defer unlock()
+
+	if !guild.invites[user.ID] {
+		return nil, ErrNotInvited
+	}
+	if len(guild.Members) >= maxGuildMembers {
+		return nil, ErrGuildFull
+	}
+
+	s.guilds.mu.Lock()
+	defer s.guilds.mu.Unlock()
+
+	if _, ok := s.guilds.members[user.ID]; ok {
+		return nil, ErrAlreadyInGuild
+	}
+	if _, ok := s.guilds.guilds[guild.ID]; !ok {
+		// The guild was disbanded while waiting for the lock
+		return nil, ErrGuildNotFound
+	}
+
+	delete(guild.invites, user.ID)
+	guild.Members = append(guild.Members, &GuildMember{
+		User:     user,
+		Role:     GuildMemberRole,
+		JoinedAt: time.Now(),
+	})
+	s.guilds.members[user.ID] = guild.ID
+
+	return guild, nil
+}
+
+// LeaveGuild removes the user from its guild
+// A leader alone on the guild disbands it, losing the stash and the gold
+func (s *swService) LeaveGuild(user *sworld.User) error {
+	guild, member, unlock, err := s.lockGuild(user)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if member.Role == GuildLeaderRole && len(guild.Members) > 1 {
+		return ErrLeaderCantLeave
+	}
+
+	s.guilds.mu.Lock()
+	defer s.guilds.mu.Unlock()
+
+	guild.removeMember(user.ID)
+	delete(s.guilds.members, user.ID)
+	if len(guild.Members) == 0 {
+		delete(s.guilds.guilds, guild.ID)
+		log.Printf("Guild disbanded: %s\n", guild.ID)
+	}
+	return nil
+}
+
+func (s *swService) KickGuildMember(user *sworld.User, username string) (*Guild, error) {
+	guild, member, unlock, err := s.lockGuild(user)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if err := member.allowed(kickAction); err != nil {
+		return nil, err
+	}
+	kicked, err := guild.memberByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+	// Members can only be kicked by someone with a higher role
+	if guildRoleRanks[kicked.Role] >= guildRoleRanks[member.Role] {
+		return nil, ErrGuildPermission
+	}
+
+	s.guilds.mu.Lock()
+	defer s.guilds.mu.Unlock()
+
+	guild.removeMember(kicked.User.ID)
+	delete(s.guilds.members, kicked.User.ID)
+	return guild, nil
+}
+
+// SetGuildRole changes the role of a member
+// Making someone else the leader turns the current leader into an officer
+func (s *swService) SetGuildRole(user *sworld.User, username string, role string) (*Guild, error) {
+	newRole := GuildRole(role)
+	if _, ok := guildRoleRanks[newRole]; !ok {
+		return nil, ErrInvalidGuildRole
+	}
+
+	guild, member, unlock, err := s.lockGuild(user)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if err := member.allowed(setRoleAction); err != nil {
+		return nil, err
+	}
+	target, err := guild.memberByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+	if target == member {
+		return nil, ErrGuildPermission
+	}
+
+	target.Role = newRole
+	if newRole == GuildLeaderRole {
+		member.Role = GuildOfficerRole
+	}
+	return guild, nil
+}
+
+// findStashSlot returns the first slot of the stash that can hold an item
+func (g *Guild) findStashSlot(item sworld.Item) (sworld.ItemLocation, error) {
+	for bagID, bag := range g.Stash {
+		slot, err := bag.FindEmptySlot(item)
+		if err == nil {
+			return sworld.ItemLocation{BagID: bagID, Slot: slot}, nil
+		}
+	}
+	return sworld.ItemLocation{}, ErrStashFull
+}
+
+// DepositGuildItem moves an item from the user to the guild stash
+func (s *swService) DepositGuildItem(user *sworld.User, location sworld.ItemLocation) (sworld.ItemLocation, error) {
+	guild, member, unlock, err := s.lockGuild(user)
+	if err != nil {
+		return sworld.ItemLocation{}, err
+	}
+	defer unlock()
+
+	if err := member.allowed(depositItemAction); err != nil {
+		return sworld.ItemLocation{}, err
+	}
+	// Only the user bags are locked, items need to be moved out of the
+	// characters first
+	if location.CharacterID != "" {
+		return sworld.ItemLocation{}, sworld.ErrInvalidBag
+	}
+
+	// The user is already locked along with the guild, the transaction puts
+	// the item back if it can't be stored
+	var stashLocation sworld.ItemLocation
+	err = user.Transaction(func() error {
+		item, err := user.GetItem(location)
+		if err != nil {
+			return err
+		}
+		if item == nil {
+			return sworld.ErrItemNotFound
+		}
+		stashLocation, err = guild.findStashSlot(item)
+		if err != nil {
+			return err
+		}
+		if err := user.DropItemAt(location); err != nil {
+			return err
+		}
+		return guild.Stash[stashLocation.BagID].StoreItem(item, stashLocation.Slot)
+	})
+	if err != nil {
+		return sworld.ItemLocation{}, err
+	}
+
+	return stashLocation, nil
+}
+
+// WithdrawGuildItem moves an item from the guild stash to the user
+func (s *swService) WithdrawGuildItem(user *sworld.User, location sworld.ItemLocation) (sworld.ItemLocation, error) {
+	guild, member, unlock, err := s.lockGuild(user)
+	if err != nil {
+		return sworld.ItemLocation{}, err
+	}
+	defer unlock()
+
+	if err := member.allowed(withdrawItemAction); err != nil {
+		return sworld.ItemLocation{}, err
+	}
+	if location.BagID < 0 || location.BagID >= len(guild.Stash) {
+		return sworld.ItemLocation{}, sworld.ErrInvalidBag
+	}
+	bag := guild.Stash[location.BagID]
+	item, err := bag.GetItem(location.Slot)
+	if err != nil {
+		return sworld.ItemLocation{}, err
+	}
+	if item == nil {
+		return sworld.ItemLocation{}, sworld.ErrItemNotFound
+	}
+
+	// The item stays on the stash if the user has no room for it
+	var userLocation sworld.ItemLocation
+	err = user.Transaction(func() error {
+		var err error
+		userLocation, err = user.PickupItem(item)
+		if err != nil {
+			return err
+		}
+		_, err = bag.DropItem(location.Slot)
+		return err
+	})
+	if err != nil {
+		return sworld.ItemLocation{}, err
+	}
+
+	return userLocation, nil
+}
+
+// DepositGuildGold moves gold from the user to the guild bank, it returns
+// the gold of the guild
+func (s *swService) DepositGuildGold(user *sworld.User, amount int) (int, error) {
+	if amount <= 0 {
+		return 0, ErrInvalidAmount
+	}
+
+	guild, member, unlock, err := s.lockGuild(user)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	if err := member.allowed(depositGoldAction); err != nil {
+		return 0, err
+	}
+	if err := user.SpendGold(amount); err != nil {
+		return 0, err
+	}
+
+	guild.Gold += amount
+	return guild.Gold, nil
+}
+
+// WithdrawGuildGold moves gold from the guild bank to the user, it returns
+// the gold of the guild
+func (s *swService) WithdrawGuildGold(user *sworld.User, amount int) (int, error) {
+	if amount <= 0 {
+		return 0, ErrInvalidAmount
+	}
+
+	guild, member, unlock, err := s.lockGuild(user)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	if err := member.allowed(withdrawGoldAction); err != nil {
+		return 0, err
+	}
+	if guild.Gold < amount {
+		return 0, sworld.ErrNotEnoughGold
+	}
+
+	guild.Gold -= amount
+	user.Gold += amount
+	return guild.Gold, nil
+}
+
+// OpenGuildPortal opens a portal only the members of the guild can enter
+func (s *swService) OpenGuildPortal(user *sworld.User, bagID, slot int, seed int64) (*sworld.Portal, error) {
+	guild, err := s.guildOf(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock := s.locks.Lock(guildLockKey(guild.ID))
+	member, err := guild.member(user.ID)
+	if err == nil {
+		err = member.allowed(guildPortalAction)
+	}
+	unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.openPortalWithStone(user, bagID, slot, seed, portalAccess{guildID: guild.ID})
+}
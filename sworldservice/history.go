@@ -0,0 +1,128 @@
+package sworldservice
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/grilix/sworld/sworld"
+)
+
+var (
+	// ErrRunNotFound is when the portal run is not on the history
+	ErrRunNotFound = errors.New("The portal run was not found")
+)
+
+// TODO: this should be on settings
+// maxRunHistory is the amount of runs kept for each user
+const maxRunHistory = 100
+
+type runHistory struct {
+	mu sync.Mutex
+	// runs are the portal runs of each user, by user ID, newest first
+	runs map[string][]*sworld.RunRecord
+	// replays are the event logs of the portals on the history, by portal
+	// ID, the runs of a party share the same one
+	replays map[string]*portalReplay
+}
+
+// portalReplay is the event log of a portal, it's kept while there are
+// runs on the history for that portal
+type portalReplay struct {
+	events []sworld.ReplayEvent
+	runs   int
+}
+
+// recordRun adds the run of an explorer to the history of its user
+func (s *swService) recordRun(exploration *sworld.Explorer) {
+	record := exploration.Record()
+	s.leaderboards.addRun(exploration.Character.User, record)
+	s.trackRun(exploration.Character.User, record)
+
+	s.history.mu.Lock()
+	defer s.history.mu.Unlock()
+
+	replay, ok := s.history.replays[record.PortalID]
+	if !ok {
+		replay = &portalReplay{}
+		s.history.replays[record.PortalID] = replay
+	}
+	// The last run to end has the longest log
+	replay.events = exploration.Portal.Replay()
+	replay.runs++
+
+	runs := append([]*sworld.RunRecord{record}, s.history.runs[record.UserID]...)
+	if len(runs) > maxRunHistory {
+		for _, run := range runs[maxRunHistory:] {
+			s.history.releaseReplay(run.PortalID)
+		}
+		runs = runs[:maxRunHistory]
+	}
+	s.history.runs[record.UserID] = runs
+}
+
+// releaseReplay removes the replay of a portal once no run uses it
+func (h *runHistory) releaseReplay(portalID string) {
+	replay, ok := h.replays[portalID]
+	if !ok {
+		return
+	}
+	replay.runs--
+	if replay.runs <= 0 {
+		delete(h.replays, portalID)
+	}
+}
+
+func (s *swService) PortalHistory(user *sworld.User, offset, limit int) ([]*sworld.RunRecord, int, error) {
+	s.history.mu.Lock()
+	defer s.history.mu.Unlock()
+
+	runs := s.history.runs[user.ID]
+	total := len(runs)
+	if offset >= total {
+		return []*sworld.RunRecord{}, total, nil
+	}
+	if offset > 0 {
+		runs = runs[offset:]
+	}
+	if limit > 0 && limit < len(runs) {
+		runs = runs[:limit]
+	}
+
+	found := make([]*sworld.RunRecord, len(runs))
+	copy(found, runs)
+	return found, total, nil
+}
+
+func (s *swService) ViewPortalRun(user *sworld.User, runID string) (*sworld.RunRecord, error) {
+	s.history.mu.Lock()
+	defer s.history.mu.Unlock()
+
+	for _, run := range s.history.runs[user.ID] {
+		if run.ID == runID {
+			return run, nil
+		}
+	}
+	return nil, ErrRunNotFound
+}
+
+// PortalReplay returns the event log of a portal of the user, portals that
+// were already removed are looked up on the history
+func (s *swService) PortalReplay(user *sworld.User, portalID string) ([]sworld.ReplayEvent, error) {
+	portal, ok := s.portals[portalID]
+	if ok && portal.p.User.ID == user.ID {
+		return portal.p.Replay(), nil
+	}
+
+	s.history.mu.Lock()
+	defer s.history.mu.Unlock()
+
+	for _, run := range s.history.runs[user.ID] {
+		if run.PortalID != portalID {
+			continue
+		}
+		if replay, ok := s.history.replays[portalID]; ok {
+			return replay.events, nil
+		}
+	}
+	return nil, ErrPortalNotFound
+}
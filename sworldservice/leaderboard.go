@@ -0,0 +1,251 @@
+package sworldservice
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/grilix/sworld/sworld"
+)
+
+var (
+	// ErrLeaderboardNotFound is when there is no leaderboard of that kind
+	ErrLeaderboardNotFound = errors.New("There is no leaderboard of that kind")
+	// ErrInvalidPeriod is when the leaderboard period is not known
+	ErrInvalidPeriod = errors.New("The leaderboard period is not valid")
+)
+
+// LeaderboardKind is what a leaderboard ranks
+type LeaderboardKind string
+
+const (
+	// DepthLeaderboard ranks the deepest position reached on a run
+	DepthLeaderboard LeaderboardKind = "depth"
+	// GoldLeaderboard ranks the most gold found on a single run
+	GoldLeaderboard LeaderboardKind = "gold"
+	// KillsLeaderboard ranks the most enemies killed on a single run
+	KillsLeaderboard LeaderboardKind = "kills"
+	// StoneLeaderboard ranks the highest stone level opened
+	StoneLeaderboard LeaderboardKind = "stone_level"
+)
+
+// LeaderboardPeriod is the time window of a leaderboard
+type LeaderboardPeriod string
+
+const (
+	// DailyLeaderboard only has the runs of the current day
+	DailyLeaderboard LeaderboardPeriod = "daily"
+	// WeeklyLeaderboard only has the runs of the current week
+	WeeklyLeaderboard LeaderboardPeriod = "weekly"
+	// AllTimeLeaderboard has every run
+	AllTimeLeaderboard LeaderboardPeriod = "all_time"
+)
+
+// TODO: this should be on settings
+const (
+	// defaultLeaderboardSize is the amount of entries of a leaderboard
+	defaultLeaderboardSize = 10
+	maxLeaderboardSize     = 100
+)
+
+var leaderboardScores = map[LeaderboardKind]func(*sworld.RunRecord) int{
+	DepthLeaderboard: func(run *sworld.RunRecord) int { return run.Depth },
+	GoldLeaderboard:  func(run *sworld.RunRecord) int { return run.Gold },
+	KillsLeaderboard: func(run *sworld.RunRecord) int { return run.EnemiesKilled },
+	StoneLeaderboard: func(run *sworld.RunRecord) int { return run.Stone.Level },
+}
+
+// LeaderboardEntry is the best run of a user on a leaderboard
+type LeaderboardEntry struct {
+	Rank        int
+	User        *sworld.User
+	CharacterID string
+	Zone        *sworld.Zone
+	StoneLevel  int
+	Score       int
+	At          time.Time
+}
+
+// Leaderboard is the ranking of the users for a kind of score
+// ZoneID and Level are what the runs were filtered by, a Level of -1
+// being every level
+type Leaderboard struct {
+	Kind    LeaderboardKind
+	Period  LeaderboardPeriod
+	ZoneID  string
+	Level   int
+	Entries []LeaderboardEntry
+}
+
+// leaderboardRun is the part of a run needed for the leaderboards
+type leaderboardRun struct {
+	user   *sworld.User
+	run    *sworld.RunRecord
+	scores map[LeaderboardKind]int
+}
+
+// bestRunKey identifies the best run of a user on a leaderboard
+type bestRunKey struct {
+	kind   LeaderboardKind
+	zoneID string
+	level  int
+	userID string
+}
+
+type leaderboards struct {
+	mu sync.Mutex
+	// recent are the runs of the current week, used for daily and weekly
+	// leaderboards
+	recent []leaderboardRun
+	// best are the best runs of each user, by kind, zone and stone level,
+	// used for all-time leaderboards
+	best map[bestRunKey]leaderboardRun
+}
+
+// periodStart returns when the period started, daily and weekly periods
+// reset at midnight UTC, and weeks start on monday
+func periodStart(period LeaderboardPeriod, now time.Time) (time.Time, error) {
+	day := now.UTC().Truncate(24 * time.Hour)
+
+	switch period {
+	case DailyLeaderboard:
+		return day, nil
+	case WeeklyLeaderboard:
+		weekday := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -weekday), nil
+	case AllTimeLeaderboard, "":
+		return time.Time{}, nil
+	}
+	return time.Time{}, ErrInvalidPeriod
+}
+
+// addRun adds a finished run to the leaderboards
+func (l *leaderboards) addRun(user *sworld.User, run *sworld.RunRecord) {
+	// The leaderboards don't need the items of the run
+	summary := *run
+	summary.Items = nil
+	run = &summary
+
+	entry := leaderboardRun{
+		user:   user,
+		run:    run,
+		scores: make(map[LeaderboardKind]int, len(leaderboardScores)),
+	}
+	for kind, score := range leaderboardScores {
+		entry.scores[kind] = score(run)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	weekStart, _ := periodStart(WeeklyLeaderboard, run.EndedAt)
+	recent := l.recent[:0]
+	for _, r := range l.recent {
+		if !r.run.EndedAt.Before(weekStart) {
+			recent = append(recent, r)
+		}
+	}
+	l.recent = append(recent, entry)
+
+	for kind := range leaderboardScores {
+		key := bestRunKey{
+			kind:   kind,
+			zoneID: run.Stone.Zone.ID,
+			level:  run.Stone.Level,
+			userID: user.ID,
+		}
+		best, ok := l.best[key]
+		if !ok || entry.scores[kind] > best.scores[kind] {
+			l.best[key] = entry
+		}
+	}
+}
+
+func (s *swService) Leaderboard(kind string, period string, zoneID string, level int, limit int) (*Leaderboard, error) {
+	if _, ok := leaderboardScores[LeaderboardKind(kind)]; !ok {
+		return nil, ErrLeaderboardNotFound
+	}
+	since, err := periodStart(LeaderboardPeriod(period), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if period == "" {
+		period = string(AllTimeLeaderboard)
+	}
+	if limit <= 0 {
+		limit = defaultLeaderboardSize
+	}
+	if limit > maxLeaderboardSize {
+		limit = maxLeaderboardSize
+	}
+
+	board := &Leaderboard{
+		Kind:    LeaderboardKind(kind),
+		Period:  LeaderboardPeriod(period),
+		ZoneID:  zoneID,
+		Level:   level,
+		Entries: make([]LeaderboardEntry, 0, limit),
+	}
+
+	s.leaderboards.mu.Lock()
+	defer s.leaderboards.mu.Unlock()
+
+	runs := s.leaderboards.recent
+	if board.Period == AllTimeLeaderboard {
+		runs = make([]leaderboardRun, 0, len(s.leaderboards.best))
+		for key, run := range s.leaderboards.best {
+			if key.kind == board.Kind {
+				runs = append(runs, run)
+			}
+		}
+	}
+
+	// Only the best run of each user is ranked
+	best := make(map[string]leaderboardRun)
+	for _, r := range runs {
+		if r.run.EndedAt.Before(since) {
+			continue
+		}
+		if zoneID != "" && r.run.Stone.Zone.ID != zoneID {
+			continue
+		}
+		if level >= 0 && r.run.Stone.Level != level {
+			continue
+		}
+		current, ok := best[r.user.ID]
+		if !ok || r.scores[board.Kind] > current.scores[board.Kind] {
+			best[r.user.ID] = r
+		}
+	}
+
+	ranked := make([]leaderboardRun, 0, len(best))
+	for _, r := range best {
+		ranked = append(ranked, r)
+	}
+	// Ties go to whoever got there first
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := ranked[i].scores[board.Kind], ranked[j].scores[board.Kind]
+		if a == b {
+			return ranked[i].run.EndedAt.Before(ranked[j].run.EndedAt)
+		}
+		return a > b
+	})
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	for i, r := range ranked {
+		board.Entries = append(board.Entries, LeaderboardEntry{
+			Rank:        i + 1,
+			User:        r.user,
+			CharacterID: r.run.CharacterID,
+			Zone:        r.run.Stone.Zone,
+			StoneLevel:  r.run.Stone.Level,
+			Score:       r.scores[board.Kind],
+			At:          r.run.EndedAt,
+		})
+	}
+
+	return board, nil
+}
@@ -0,0 +1,88 @@
+package sworldservice
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/grilix/sworld/sworld"
+)
+
+// lockManager hands out a lock for each key
+// Keys are always locked in the same order, so two calls asking for the
+// same keys can't deadlock
+type lockManager struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func newLockManager() *lockManager {
+	return &lockManager{
+		locks: make(map[string]*sync.Mutex),
+	}
+}
+
+func userLockKey(id string) string {
+	return "user:" + id
+}
+
+func characterLockKey(id string) string {
+	return "character:" + id
+}
+
+func (l *lockManager) get(key string) *sync.Mutex {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lock, ok := l.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		l.locks[key] = lock
+	}
+	return lock
+}
+
+// Lock locks every key and returns a function that unlocks them
+func (l *lockManager) Lock(keys ...string) func() {
+	sorted := make([]string, 0, len(keys))
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			sorted = append(sorted, key)
+		}
+	}
+	sort.Strings(sorted)
+
+	locks := make([]*sync.Mutex, 0, len(sorted))
+	for _, key := range sorted {
+		lock := l.get(key)
+		lock.Lock()
+		locks = append(locks, lock)
+	}
+
+	return func() {
+		for i := len(locks) - 1; i >= 0; i-- {
+			locks[i].Unlock()
+		}
+	}
+}
+
+// lockInventory locks the inventory of a user, and of the given characters
+func (s *swService) lockInventory(user *sworld.User, characterIDs ...string) func() {
+	keys := []string{userLockKey(user.ID)}
+	for _, id := range characterIDs {
+		if id != "" {
+			keys = append(keys, characterLockKey(id))
+		}
+	}
+	return s.locks.Lock(keys...)
+}
+
+// inventoryTx runs fn holding the inventory locks, rolling back every change
+// made to the inventory if it fails
+func (s *swService) inventoryTx(user *sworld.User, characterIDs []string, fn func() error) error {
+	unlock := s.lockInventory(user, characterIDs...)
+	defer unlock()
+
+	return user.Transaction(fn)
+}
@@ -0,0 +1,174 @@
+package sworldservice
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grilix/sworld/sworld"
+)
+
+func newTestUser(t *testing.T, s *swService) *sworld.User {
+	user, err := s.createUser(sworld.RandomID(8))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+// testStone opens short portals, level 0 portals don't spawn enemies
+func testStone(s *swService) *sworld.PortalStone {
+	return &sworld.PortalStone{
+		Level:    0,
+		Zone:     s.defaultZone,
+		Duration: 100 * time.Millisecond,
+	}
+}
+
+func countItems(bags []sworld.Bag) int {
+	count := 0
+	for _, bag := range bags {
+		for _, item := range bag.Items() {
+			if item != nil {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func waitForTown(t *testing.T, s *swService, character *sworld.Character) {
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		unlock := s.locks.Lock(characterLockKey(character.ID))
+		exploring := character.Exploring
+		unlock()
+		if !exploring {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("Expected the character to leave the portal")
+}
+
+func TestConcurrentInventoryChanges(t *testing.T) {
+	s := NewService().(*swService)
+	user := newTestUser(t, s)
+	character := user.Characters[0]
+
+	stones := 4
+	for i := 0; i < stones; i++ {
+		if _, err := user.PickupItem(testStone(s)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := character.Bags[0].StoreItem(&sworld.Weapon{Damage: 1}, 0); err != nil {
+		t.Fatal(err)
+	}
+	items := countItems(user.Bags) + countItems(character.Bags)
+
+	var wg sync.WaitGroup
+	var opened int
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		for slot := 0; slot < s.userBagCapacity; slot++ {
+			portal, err := s.OpenPortalWithStone(user, 0, slot, 0)
+			if err != nil {
+				continue
+			}
+			opened++
+			_ = s.ExplorePortal(user, portal.ID, character.ID)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			from := sworld.ItemLocation{BagID: 0, Slot: i % s.userBagCapacity}
+			to := sworld.ItemLocation{BagID: 0, Slot: (i + 3) % s.userBagCapacity}
+			_ = s.MoveItem(user, from, to)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			err := s.TakeCharacterItem(user, character.ID, 0, 0)
+			if err != nil {
+				continue
+			}
+			// Put it back, the character might be exploring by now
+			for slot := 0; slot < s.userBagCapacity; slot++ {
+				from := sworld.ItemLocation{BagID: 0, Slot: slot}
+				to := sworld.ItemLocation{CharacterID: character.ID, BagID: 0, Slot: 0}
+				if s.MoveItem(user, from, to) == nil {
+					break
+				}
+			}
+		}
+	}()
+	wg.Wait()
+	waitForTown(t, s, character)
+
+	if opened == 0 {
+		t.Fatal("Expected at least one portal to be opened")
+	}
+	unlock := s.lockInventory(user, character.ID)
+	defer unlock()
+	left := countItems(user.Bags) + countItems(character.Bags)
+	if left != items-opened {
+		t.Error("Expected", items-opened, "items, got", left)
+	}
+}
+
+func TestConcurrentCharacterDeath(t *testing.T) {
+	s := NewService().(*swService)
+	user := newTestUser(t, s)
+	character := user.Characters[0]
+
+	stone := testStone(s)
+	stone.Duration = 5 * time.Second
+	if _, err := user.PickupItem(stone); err != nil {
+		t.Fatal(err)
+	}
+	if err := character.Bags[0].StoreItem(&sworld.Weapon{Damage: 1}, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	portal, err := s.OpenPortalWithStone(user, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ExplorePortal(user, portal.ID, character.ID); err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		// The portal kills characters holding the character lock
+		unlock := portal.LockCharacter(character)
+		character.Die()
+		unlock()
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			_ = s.TakeCharacterItem(user, character.ID, 0, 0)
+			_ = s.DropCharacterItem(user, character.ID, 0, 0)
+		}
+	}()
+	wg.Wait()
+	waitForTown(t, s, character)
+
+	unlock := s.lockInventory(user, character.ID)
+	defer unlock()
+	if character.Health > 0 {
+		t.Error("Expected the character to be dead, health is", character.Health)
+	}
+	if countItems(character.Bags) != 0 {
+		t.Error("Expected dead characters to lose their items")
+	}
+	if countItems(user.Bags) != 0 {
+		t.Error("Expected the weapon to not be taken while exploring")
+	}
+}
@@ -0,0 +1,508 @@
+package sworldservice
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/grilix/sworld/sworld"
+)
+
+var (
+	// ErrPartyNotFound is when the party does not exist
+	ErrPartyNotFound = errors.New("The party was not found")
+	// ErrNotInParty is when the user is not a member of a party
+	ErrNotInParty = errors.New("You are not on a party")
+	// ErrAlreadyInParty is when the user is already a member of a party
+	ErrAlreadyInParty = errors.New("The user is already on a party")
+	// ErrPartyFull is when the party has no room for more members
+	ErrPartyFull = errors.New("The party is full")
+	// ErrNotPartyLeader is when only the leader can do something
+	ErrNotPartyLeader = errors.New("Only the party leader can do that")
+	// ErrNotInvitedToParty is when the user was not invited to the party
+	ErrNotInvitedToParty = errors.New("You were not invited to that party")
+	// ErrAlreadyQueued is when the user is already on a matchmaking queue
+	ErrAlreadyQueued = errors.New("You are already on a matchmaking queue")
+	// ErrInvalidStoneLevel is when the stone level is not valid
+	ErrInvalidStoneLevel = errors.New("The stone level is not valid")
+	// ErrNotQueued is when the user is not on a matchmaking queue
+	ErrNotQueued = errors.New("You are not on a matchmaking queue")
+)
+
+// TODO: this should be on settings
+const (
+	maxPartySize = 4
+	// minMatchSize is the smallest party the matchmaking forms once the
+	// first user of the queue waited for matchWait
+	minMatchSize = 2
+	matchWait    = 30 * time.Second
+)
+
+// PartyMember is a user on a party, along with the character it brings
+type PartyMember struct {
+	User        *sworld.User
+	CharacterID string
+	JoinedAt    time.Time
+}
+
+// Party is a group of users whose characters explore portals together
+// Parties formed by the matchmaking have the zone and stone level their
+// members were queued for
+type Party struct {
+	ID        string
+	Leader    *sworld.User
+	Members   []*PartyMember
+	CreatedAt time.Time
+	Matched   bool
+	ZoneID    string
+	Level     int
+
+	// invites are the users allowed to join, by user ID
+	invites map[string]bool
+}
+
+type partyList struct {
+	mu      sync.Mutex
+	parties map[string]*Party
+	// members are the party IDs of the users, by user ID
+	members map[string]string
+}
+
+// matchQueueKey identifies a matchmaking queue
+type matchQueueKey struct {
+	zoneID string
+	level  int
+}
+
+// matchTicket is a user waiting on a matchmaking queue
+type matchTicket struct {
+	user        *sworld.User
+	characterID string
+	queuedAt    time.Time
+}
+
+type matchmaker struct {
+	mu     sync.Mutex
+	queues map[matchQueueKey][]*matchTicket
+	// tickets are the queues of the users, by user ID
+	tickets map[string]matchQueueKey
+}
+
+// MatchmakingStatus is where the user is on the matchmaking
+// Party is only set once the matchmaking found a party for the user
+type MatchmakingStatus struct {
+	ZoneID   string
+	Level    int
+	Position int
+	Queued   int
+	QueuedAt time.Time
+	Party    *Party
+}
+
+func (p *Party) removeMember(userID string) {
+	members := p.Members[:0]
+	for _, member := range p.Members {
+		if member.User.ID != userID {
+			members = append(members, member)
+		}
+	}
+	p.Members = members
+}
+
+// partyCharacter returns a character of the user that can go on a party
+func partyCharacter(user *sworld.User, characterID string) (*sworld.Character, error) {
+	character, err := user.FindCharacter(characterID)
+	if err != nil {
+		return nil, err
+	}
+	if character.Health <= 0 {
+		return nil, ErrCharacterIsDead
+	}
+	return character, nil
+}
+
+// partyOf returns the party of a user
+func (s *swService) partyOf(userID string) (*Party, error) {
+	s.parties.mu.Lock()
+	defer s.parties.mu.Unlock()
+
+	partyID, ok := s.parties.members[userID]
+	if !ok {
+		return nil, ErrNotInParty
+	}
+	return s.parties.parties[partyID], nil
+}
+
+// addParty creates a party with its members
+// The party list needs to be locked
+func (s *swService) addParty(members []*PartyMember) *Party {
+	party := &Party{
+		ID:        sworld.RandomID(16),
+		Leader:    members[0].User,
+		Members:   members,
+		CreatedAt: time.Now(),
+		invites:   make(map[string]bool),
+	}
+	s.parties.parties[party.ID] = party
+	for _, member := range members {
+		s.parties.members[member.User.ID] = party.ID
+	}
+	return party
+}
+
+func (s *swService) CreateParty(user *sworld.User, characterID string) (*Party, error) {
+	if _, err := partyCharacter(user, characterID); err != nil {
+		return nil, err
+	}
+
+	s.matchmaking.mu.Lock()
+	defer s.matchmaking.mu.Unlock()
+	s.parties.mu.Lock()
+	defer s.parties.mu.Unlock()
+
+	if _, ok := s.matchmaking.tickets[user.ID]; ok {
+		return nil, ErrAlreadyQueued
+	}
+	if _, ok := s.parties.members[user.ID]; ok {
+		return nil, ErrAlreadyInParty
+	}
+
+	party := s.addParty([]*PartyMember{
+		{User: user, CharacterID: characterID, JoinedAt: time.Now()},
+	})
+	return party, nil
+}
+
+func (s *swService) ViewParty(user *sworld.User) (*Party, error) {
+	return s.partyOf(user.ID)
+}
+
+func (s *swService) InviteToParty(user *sworld.User, username string) (*Party, error) {
+	invited, err := s.findUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	s.parties.mu.Lock()
+	defer s.parties.mu.Unlock()
+
+	party, ok := s.parties.parties[s.parties.members[user.ID]]
+	if !ok {
+		return nil, ErrNotInParty
+	}
+	if party.Leader.ID != user.ID {
+		return nil, ErrNotPartyLeader
+	}
+	if _, ok := s.parties.members[invited.ID]; ok {
+		return nil, ErrAlreadyInParty
+	}
+
+	party.invites[invited.ID] = true
+	return party, nil
+}
+
+func (s *swService) AcceptPartyInvite(user *sworld.User, partyID string, characterID string) (*Party, error) {
+	if _, err := partyCharacter(user, characterID); err != nil {
+		return nil, err
+	}
+
+	s.matchmaking.mu.Lock()
+	defer s.matchmaking.mu.Unlock()
+	s.parties.mu.Lock()
+	defer s.parties.mu.Unlock()
+
+	party, ok := s.parties.parties[partyID]
+	if !ok {
+		return nil, ErrPartyNotFound
+	}
+	if !party.invites[user.ID] {
+		return nil, ErrNotInvitedToParty
+	}
+	if _, ok := s.matchmaking.tickets[user.ID]; ok {
+		return nil, ErrAlreadyQueued
+	}
+	if _, ok := s.parties.members[user.ID]; ok {
+		return nil, ErrAlreadyInParty
+	}
+	if len(party.Members) >= maxPartySize {
+		return nil, ErrPartyFull
+	}
+
+	delete(party.invites, user.ID)
+	party.Members = append(party.Members, &PartyMember{
+		User:        user,
+		CharacterID: characterID,
+		JoinedAt:    time.Now(),
+	})
+	s.parties.members[user.ID] = party.ID
+
+	return party, nil
+}
+
+// LeaveParty removes the user from its party
+// When the leader leaves, the member who joined first leads the party
+func (s *swService) LeaveParty(user *sworld.User) error {
+	s.parties.mu.Lock()
+	defer s.parties.mu.Unlock()
+
+	party, ok := s.parties.parties[s.parties.members[user.ID]]
+	if !ok {
+		return ErrNotInParty
+	}
+
+	party.removeMember(user.ID)
+	delete(s.parties.members, user.ID)
+	if len(party.Members) == 0 {
+		delete(s.parties.parties, party.ID)
+		return nil
+	}
+	if party.Leader.ID == user.ID {
+		party.Leader = party.Members[0].User
+	}
+	return nil
+}
+
+func (s *swService) SetPartyLeader(user *sworld.User, username string) (*Party, error) {
+	s.parties.mu.Lock()
+	defer s.parties.mu.Unlock()
+
+	party, ok := s.parties.parties[s.parties.members[user.ID]]
+	if !ok {
+		return nil, ErrNotInParty
+	}
+	if party.Leader.ID != user.ID {
+		return nil, ErrNotPartyLeader
+	}
+	for _, member := range party.Members {
+		if member.User.Username == username {
+			party.Leader = member.User
+			return party, nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+// OpenPartyPortal opens a portal with a stone of the leader, every character
+// of the party enters it
+// Without a stone location, the default stone is used
+func (s *swService) OpenPartyPortal(user *sworld.User, location *sworld.ItemLocation) (*sworld.Portal, error) {
+	s.parties.mu.Lock()
+	party, ok := s.parties.parties[s.parties.members[user.ID]]
+	if !ok {
+		s.parties.mu.Unlock()
+		return nil, ErrNotInParty
+	}
+	if party.Leader.ID != user.ID {
+		s.parties.mu.Unlock()
+		return nil, ErrNotPartyLeader
+	}
+	members := make([]*PartyMember, len(party.Members))
+	copy(members, party.Members)
+	s.parties.mu.Unlock()
+
+	keys := make([]string, 0, len(members))
+	for _, member := range members {
+		keys = append(keys, characterLockKey(member.CharacterID))
+	}
+	unlock := s.locks.Lock(keys...)
+
+	// Every character needs to be ready before the stone is used
+	characters := make([]*sworld.Character, 0, len(members))
+	for _, member := range members {
+		character, err := partyCharacter(member.User, member.CharacterID)
+		if err != nil {
+			unlock()
+			return nil, err
+		}
+		if character.Exploring {
+			unlock()
+			return nil, sworld.ErrCharacterBusy
+		}
+		characters = append(characters, character)
+	}
+
+	var portal *sworld.Portal
+	var err error
+	access := portalAccess{partyID: party.ID}
+	if location != nil {
+		portal, err = s.openPortalWithStone(user, location.BagID, location.Slot, 0, access)
+	} else {
+		portal, err = s.openPortal(user, s.defaultStone(user), 0, access)
+	}
+	if err != nil {
+		unlock()
+		return nil, err
+	}
+
+	explorers := make([]*sworld.Explorer, 0, len(characters))
+	for _, character := range characters {
+		exploration, err := character.EnterPortal(portal)
+		if err != nil {
+			log.Printf("Party %s: %s can't enter: %s\n", party.ID, character.ID, err)
+			continue
+		}
+		explorers = append(explorers, exploration)
+	}
+	unlock()
+
+	for _, exploration := range explorers {
+		s.handleCharacterAttack(exploration)
+		s.handleCharacterMove(exploration)
+	}
+
+	return portal, nil
+}
+
+// matchmakingStatus returns the status of a user on the matchmaking
+// The matchmaker and the party list need to be locked
+func (s *swService) matchmakingStatus(user *sworld.User) (*MatchmakingStatus, error) {
+	key, ok := s.matchmaking.tickets[user.ID]
+	if !ok {
+		party, ok := s.parties.parties[s.parties.members[user.ID]]
+		if !ok || !party.Matched {
+			return nil, ErrNotQueued
+		}
+		return &MatchmakingStatus{
+			ZoneID: party.ZoneID,
+			Level:  party.Level,
+			Party:  party,
+		}, nil
+	}
+
+	queue := s.matchmaking.queues[key]
+	status := &MatchmakingStatus{
+		ZoneID: key.zoneID,
+		Level:  key.level,
+		Queued: len(queue),
+	}
+	for i, ticket := range queue {
+		if ticket.user.ID == user.ID {
+			status.Position = i + 1
+			status.QueuedAt = ticket.queuedAt
+		}
+	}
+	return status, nil
+}
+
+// formParties creates parties from a queue, full parties are formed right
+// away and smaller ones once the first user waited long enough
+// The matchmaker needs to be locked
+func (s *swService) formParties(key matchQueueKey, now time.Time) {
+	queue := s.matchmaking.queues[key]
+
+	s.parties.mu.Lock()
+	defer s.parties.mu.Unlock()
+
+	for len(queue) >= maxPartySize ||
+		(len(queue) >= minMatchSize && now.Sub(queue[0].queuedAt) >= matchWait) {
+
+		size := len(queue)
+		if size > maxPartySize {
+			size = maxPartySize
+		}
+
+		members := make([]*PartyMember, 0, size)
+		for _, ticket := range queue[:size] {
+			members = append(members, &PartyMember{
+				User:        ticket.user,
+				CharacterID: ticket.characterID,
+				JoinedAt:    now,
+			})
+			delete(s.matchmaking.tickets, ticket.user.ID)
+		}
+		queue = queue[size:]
+
+		party := s.addParty(members)
+		party.Matched = true
+		party.ZoneID = key.zoneID
+		party.Level = key.level
+		log.Printf("Matchmaking: party %s formed with %d members\n", party.ID, size)
+	}
+
+	if len(queue) == 0 {
+		delete(s.matchmaking.queues, key)
+	} else {
+		s.matchmaking.queues[key] = queue
+	}
+}
+
+// JoinMatchmaking puts the user on the queue of a zone and stone level
+func (s *swService) JoinMatchmaking(user *sworld.User, characterID string, zoneID string, level int) (*MatchmakingStatus, error) {
+	zone, err := s.findZone(zoneID)
+	if err != nil {
+		return nil, err
+	}
+	if level < 0 {
+		return nil, ErrInvalidStoneLevel
+	}
+	if _, err := partyCharacter(user, characterID); err != nil {
+		return nil, err
+	}
+
+	s.matchmaking.mu.Lock()
+	defer s.matchmaking.mu.Unlock()
+
+	if _, ok := s.matchmaking.tickets[user.ID]; ok {
+		return nil, ErrAlreadyQueued
+	}
+	if _, err := s.partyOf(user.ID); err == nil {
+		return nil, ErrAlreadyInParty
+	}
+
+	now := time.Now()
+	key := matchQueueKey{zoneID: zone.ID, level: level}
+	s.matchmaking.queues[key] = append(s.matchmaking.queues[key], &matchTicket{
+		user:        user,
+		characterID: characterID,
+		queuedAt:    now,
+	})
+	s.matchmaking.tickets[user.ID] = key
+	s.formParties(key, now)
+
+	// Smaller parties are formed if nobody else joins in time
+	time.AfterFunc(matchWait, func() {
+		s.matchmaking.mu.Lock()
+		defer s.matchmaking.mu.Unlock()
+
+		s.formParties(key, time.Now())
+	})
+
+	s.parties.mu.Lock()
+	defer s.parties.mu.Unlock()
+
+	return s.matchmakingStatus(user)
+}
+
+func (s *swService) LeaveMatchmaking(user *sworld.User) error {
+	s.matchmaking.mu.Lock()
+	defer s.matchmaking.mu.Unlock()
+
+	key, ok := s.matchmaking.tickets[user.ID]
+	if !ok {
+		return ErrNotQueued
+	}
+
+	queue := s.matchmaking.queues[key][:0]
+	for _, ticket := range s.matchmaking.queues[key] {
+		if ticket.user.ID != user.ID {
+			queue = append(queue, ticket)
+		}
+	}
+	if len(queue) == 0 {
+		delete(s.matchmaking.queues, key)
+	} else {
+		s.matchmaking.queues[key] = queue
+	}
+	delete(s.matchmaking.tickets, user.ID)
+	return nil
+}
+
+func (s *swService) MatchmakingStatus(user *sworld.User) (*MatchmakingStatus, error) {
+	s.matchmaking.mu.Lock()
+	defer s.matchmaking.mu.Unlock()
+	s.parties.mu.Lock()
+	defer s.parties.mu.Unlock()
+
+	return s.matchmakingStatus(user)
+}
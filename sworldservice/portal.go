@@ -23,20 +23,25 @@ func (s *swService) defaultStone(user *sworld.User) sworld.PortalStone {
 	}
 }
 
-func (s *swService) openPortal(user *sworld.User, stone sworld.PortalStone) (*sworld.Portal, error) {
-	portal, err := sworld.OpenPortal(user, stone, func(portal *sworld.Portal) {
+// openPortal opens a portal with a stone, the access says who else can
+// enter it
+func (s *swService) openPortal(user *sworld.User, stone sworld.PortalStone, seed int64, access portalAccess) (*sworld.Portal, error) {
+	portal, err := sworld.OpenPortal(user, stone, seed, func(portal *sworld.Portal) {
 		log.Printf("Portal closed: %s\n", portal.ID)
 	})
 	if err != nil {
 		return portal, err
 	}
 	log.Printf("Portal open: %s\n", portal.ID)
+	portal.LockCharacter = func(character *sworld.Character) func() {
+		return s.locks.Lock(characterLockKey(character.ID))
+	}
 
 	userID := user.ID
 	// Close old portal(s)
 	// TODO: lock portal list
 	for id, portal := range s.portals {
-		if portal.p.IsOpen {
+		if portal.p.Open() {
 			continue
 		}
 
@@ -48,14 +53,69 @@ func (s *swService) openPortal(user *sworld.User, stone sworld.PortalStone) (*sw
 	// TODO: unlock portal list
 
 	sportal := &sPortal{
-		p: portal,
+		p:            portal,
+		portalAccess: access,
 	}
 
 	s.portals[sportal.p.ID] = sportal
 
+	if portal.PortalStone.WaveInterval() > 0 {
+		s.handlePortalWaves(portal)
+	}
+	s.trackActivity(user, activity{
+		event: PortalOpenedEvent,
+		value: stone.Level,
+		stone: &portal.PortalStone,
+	})
+
 	return portal, nil
 }
 
+// canEnterPortal returns whether the user is allowed to enter a portal
+func (s *swService) canEnterPortal(user *sworld.User, portal *sPortal) bool {
+	if portal.p.User.ID == user.ID {
+		return true
+	}
+	if portal.guildID != "" {
+		if guild, err := s.guildOf(user.ID); err == nil && guild.ID == portal.guildID {
+			return true
+		}
+	}
+	if portal.partyID != "" {
+		if party, err := s.partyOf(user.ID); err == nil && party.ID == portal.partyID {
+			return true
+		}
+	}
+	return false
+}
+
+// handlePortalWaves spawns the waves of a portal until it closes
+func (s *swService) handlePortalWaves(portal *sworld.Portal) {
+	go func() {
+		waveTimer := time.NewTicker(portal.PortalStone.WaveInterval())
+		defer waveTimer.Stop()
+
+		for {
+			select {
+			case <-waveTimer.C:
+				explorers := portal.Explorers()
+				keys := make([]string, 0, len(explorers))
+				for _, explorer := range explorers {
+					keys = append(keys, characterLockKey(explorer.Character.ID))
+				}
+
+				// Waves give items to the characters
+				unlock := s.locks.Lock(keys...)
+				events := portal.SpawnWave()
+				unlock()
+				log.Printf("Portal %s: wave %d, %d events\n", portal.ID, portal.Waves(), len(events))
+			case _, _ = <-portal.C:
+				return
+			}
+		}
+	}()
+}
+
 func (s *swService) handleCharacterMove(exploration *sworld.Explorer) {
 	// TODO: Not sure what was this for
 	if exploration.Portal.C == nil {
@@ -70,9 +130,15 @@ func (s *swService) handleCharacterMove(exploration *sworld.Explorer) {
 			moveTimer.Stop()
 
 			// FIXME: Handle this somewhere else
-			if exploration.Character.Health > 0 {
-				exploration.Character.ReturnToTown(exploration.Portal)
+			character := exploration.Character
+			unlock := s.lockInventory(character.User, character.ID)
+			if character.Health > 0 {
+				character.ReturnToTown(exploration.Portal)
+			} else {
+				character.ReturnDead()
 			}
+			s.recordRun(exploration)
+			unlock()
 		}()
 
 		for {
@@ -80,10 +146,17 @@ func (s *swService) handleCharacterMove(exploration *sworld.Explorer) {
 			case <-moveTimer.C:
 				enemy := exploration.ClosestEnemy()
 
+				// Advancing picks up items and uses consumables, both change
+				// the bags so they need the character lock
+				unlock := s.locks.Lock(characterLockKey(exploration.Character.ID))
 				if enemy == nil {
 					_ = exploration.Advance()
 					log.Printf(" Character: Advancing, now at %d\n", exploration.Position())
+				} else {
+					// Potions are still used while fighting
+					exploration.Character.AutoUseItems()
 				}
+				unlock()
 			case _, _ = <-exploration.Character.D:
 				return
 			case _, _ = <-exploration.Portal.C:
@@ -114,6 +187,9 @@ func (s *swService) handleCharacterAttack(exploration *sworld.Explorer) {
 				enemy := exploration.ClosestEnemy()
 
 				if enemy != nil {
+					// Killing the enemy gives experience to the character,
+					// which needs the character lock
+					unlock := s.locks.Lock(characterLockKey(character.ID))
 					skill := character.AvailableSkill()
 					if skill != nil {
 						log.Printf(" Character: Attacking %v\n", enemy)
@@ -121,6 +197,7 @@ func (s *swService) handleCharacterAttack(exploration *sworld.Explorer) {
 					} else {
 						log.Printf(" Character: No skills to attack!\n")
 					}
+					unlock()
 				}
 			case _, _ = <-exploration.Character.D:
 				return
@@ -0,0 +1,427 @@
+package sworldservice
+
+import (
+	"errors"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/grilix/sworld/sworld"
+)
+
+var (
+	// ErrQuestNotFound is when the quest is not on the current rotation
+	ErrQuestNotFound = errors.New("The quest was not found")
+	// ErrQuestAlreadyAccepted is when the user already accepted the quest
+	ErrQuestAlreadyAccepted = errors.New("The quest was already accepted")
+	// ErrQuestNotAccepted is when the user didn't accept the quest yet
+	ErrQuestNotAccepted = errors.New("The quest was not accepted")
+	// ErrQuestNotCompleted is when the objective was not reached yet
+	ErrQuestNotCompleted = errors.New("The quest is not completed")
+	// ErrQuestClaimed is when the reward was already claimed
+	ErrQuestClaimed = errors.New("The reward was already claimed")
+)
+
+// QuestPeriod is how often the quests of a pool rotate
+type QuestPeriod string
+
+const (
+	// DailyQuest rotates every day at midnight UTC
+	DailyQuest QuestPeriod = "daily"
+	// WeeklyQuest rotates every monday at midnight UTC
+	WeeklyQuest QuestPeriod = "weekly"
+)
+
+// QuestState is where the user is on a quest
+type QuestState string
+
+const (
+	// QuestAvailable is a quest the user can accept
+	QuestAvailable QuestState = "available"
+	// QuestAccepted is a quest the user is working on
+	QuestAccepted QuestState = "accepted"
+	// QuestCompleted is a quest with its objective reached
+	QuestCompleted QuestState = "completed"
+	// QuestClaimed is a quest with its reward already paid
+	QuestClaimed QuestState = "claimed"
+)
+
+// TODO: this should be on settings
+var questsPerRotation = map[QuestPeriod]int{
+	DailyQuest:  3,
+	WeeklyQuest: 2,
+}
+
+// QuestObjective is what the user has to do to complete a quest
+// Zone and MinLevel only count the events that happened on portals of that
+// zone and with stones of at least that level
+// When Reach is set, the goal is reaching a value on a single event,
+// otherwise the values of every event are added up
+type QuestObjective struct {
+	Event    ActivityEvent
+	Goal     int
+	Reach    bool
+	Zone     string
+	MinLevel int
+}
+
+// QuestReward is what the user gets when claiming a quest
+type QuestReward struct {
+	Gold  int
+	Items []func(zone *sworld.Zone) sworld.Item
+}
+
+// Quest is an objective available for a period of time
+type Quest struct {
+	ID          string
+	Name        string
+	Description string
+	Period      QuestPeriod
+	Objective   QuestObjective
+	Reward      QuestReward
+}
+
+// QuestStatus is the progress of a user on a quest of the current rotation
+type QuestStatus struct {
+	Quest     Quest
+	State     QuestState
+	Progress  int
+	ExpiresAt time.Time
+	// RewardItems are the items given when claiming the quest
+	RewardItems []sworld.Item
+}
+
+func stoneReward(level int) func(zone *sworld.Zone) sworld.Item {
+	return func(zone *sworld.Zone) sworld.Item {
+		return &sworld.PortalStone{
+			Level:    level,
+			Zone:     zone,
+			Duration: 30 * time.Second,
+		}
+	}
+}
+
+func consumableReward(consumable sworld.Consumable) func(zone *sworld.Zone) sworld.Item {
+	return func(zone *sworld.Zone) sworld.Item {
+		item := consumable
+		return &item
+	}
+}
+
+// questBook are the quests the rotations pick from
+var questBook = []Quest{
+	{
+		ID:          "forest_hunter",
+		Name:        "Forest hunter",
+		Description: "Kill 20 enemies in Forest",
+		Period:      DailyQuest,
+		Objective:   QuestObjective{Event: EnemyKilledEvent, Goal: 20, Zone: "Forest"},
+		Reward:      QuestReward{Gold: 100},
+	},
+	{
+		ID:          "stone_merger",
+		Name:        "Stone merger",
+		Description: "Merge 5 stones",
+		Period:      DailyQuest,
+		Objective:   QuestObjective{Event: StoneMergedEvent, Goal: 5},
+		Reward:      QuestReward{Gold: 50, Items: []func(*sworld.Zone) sworld.Item{stoneReward(1)}},
+	},
+	{
+		ID:          "deep_dive",
+		Name:        "Deep dive",
+		Description: "Reach depth 30 with a level 3 stone",
+		Period:      DailyQuest,
+		Objective:   QuestObjective{Event: DepthReachedEvent, Goal: 30, Reach: true, MinLevel: 3},
+		Reward:      QuestReward{Gold: 150, Items: []func(*sworld.Zone) sworld.Item{stoneReward(2)}},
+	},
+	{
+		ID:          "gold_rush",
+		Name:        "Gold rush",
+		Description: "Bring 500 gold back to town",
+		Period:      DailyQuest,
+		Objective:   QuestObjective{Event: GoldBankedEvent, Goal: 500},
+		Reward: QuestReward{Items: []func(*sworld.Zone) sworld.Item{
+			consumableReward(sworld.Consumable{
+				Kind:     sworld.HealthPotion,
+				Power:    40,
+				Quantity: 5,
+			}),
+		}},
+	},
+	{
+		ID:          "portal_opener",
+		Name:        "Portal opener",
+		Description: "Open 3 portals",
+		Period:      DailyQuest,
+		Objective:   QuestObjective{Event: PortalOpenedEvent, Goal: 3},
+		Reward:      QuestReward{Gold: 75},
+	},
+	{
+		ID:          "weekly_slayer",
+		Name:        "Weekly slayer",
+		Description: "Kill 200 enemies with a level 2 stone",
+		Period:      WeeklyQuest,
+		Objective:   QuestObjective{Event: EnemyKilledEvent, Goal: 200, MinLevel: 2},
+		Reward:      QuestReward{Gold: 1000, Items: []func(*sworld.Zone) sworld.Item{stoneReward(3)}},
+	},
+	{
+		ID:          "weekly_delver",
+		Name:        "Weekly delver",
+		Description: "Reach depth 30 with a level 5 stone",
+		Period:      WeeklyQuest,
+		Objective:   QuestObjective{Event: DepthReachedEvent, Goal: 30, Reach: true, MinLevel: 5},
+		Reward:      QuestReward{Gold: 1500},
+	},
+	{
+		ID:          "weekly_merger",
+		Name:        "Weekly merger",
+		Description: "Merge 40 stones",
+		Period:      WeeklyQuest,
+		Objective:   QuestObjective{Event: StoneMergedEvent, Goal: 40},
+		Reward: QuestReward{Gold: 500, Items: []func(*sworld.Zone) sworld.Item{
+			consumableReward(sworld.Consumable{
+				Kind:     sworld.PortalExtender,
+				Power:    5,
+				Quantity: 2,
+			}),
+		}},
+	},
+}
+
+// questRotation returns when the current rotation of a period started and
+// when it ends
+func questRotation(period QuestPeriod, now time.Time) (time.Time, time.Time) {
+	if period == WeeklyQuest {
+		start, _ := periodStart(WeeklyLeaderboard, now)
+		return start, start.AddDate(0, 0, 7)
+	}
+	start, _ := periodStart(DailyLeaderboard, now)
+	return start, start.AddDate(0, 0, 1)
+}
+
+// currentQuests returns the quests of the current rotations
+// The quests only depend on the rotation, so they are the same for everyone
+func currentQuests(now time.Time) []Quest {
+	quests := make([]Quest, 0)
+	for _, period := range []QuestPeriod{DailyQuest, WeeklyQuest} {
+		pool := make([]Quest, 0, len(questBook))
+		for _, quest := range questBook {
+			if quest.Period == period {
+				pool = append(pool, quest)
+			}
+		}
+
+		start, _ := questRotation(period, now)
+		rng := rand.New(rand.NewSource(start.Unix()))
+		size := questsPerRotation[period]
+		if size > len(pool) {
+			size = len(pool)
+		}
+		for _, index := range rng.Perm(len(pool))[:size] {
+			quests = append(quests, pool[index])
+		}
+	}
+	return quests
+}
+
+func findQuest(id string, now time.Time) (Quest, error) {
+	for _, quest := range currentQuests(now) {
+		if quest.ID == id {
+			return quest, nil
+		}
+	}
+	return Quest{}, ErrQuestNotFound
+}
+
+// matches returns whether an activity counts for the objective
+func (o QuestObjective) matches(act activity) bool {
+	if act.event != o.Event {
+		return false
+	}
+	if o.Zone == "" && o.MinLevel == 0 {
+		return true
+	}
+	if act.stone == nil {
+		return false
+	}
+	if o.Zone != "" && (act.stone.Zone == nil || act.stone.Zone.Name != o.Zone) {
+		return false
+	}
+	return act.stone.Level >= o.MinLevel
+}
+
+// questProgress is the progress of a user on a quest of a rotation
+type questProgress struct {
+	// rotation is when the rotation the quest was accepted on started
+	rotation time.Time
+	progress int
+	claimed  bool
+}
+
+type questLog struct {
+	mu sync.Mutex
+	// users are the accepted quests of each user, by quest ID
+	users map[string]map[string]*questProgress
+}
+
+// forUser returns the quests accepted by a user on the current rotations
+// The log needs to be locked
+func (l *questLog) forUser(user *sworld.User, now time.Time) map[string]*questProgress {
+	quests, ok := l.users[user.ID]
+	if !ok {
+		quests = make(map[string]*questProgress)
+		l.users[user.ID] = quests
+	}
+
+	// Quests from past rotations are not available anymore
+	current := make(map[string]bool)
+	for _, quest := range currentQuests(now) {
+		start, _ := questRotation(quest.Period, now)
+		if progress, ok := quests[quest.ID]; ok && progress.rotation.Equal(start) {
+			current[quest.ID] = true
+		}
+	}
+	for id := range quests {
+		if !current[id] {
+			delete(quests, id)
+		}
+	}
+	return quests
+}
+
+func (s *swService) questStatus(quest Quest, progress *questProgress, now time.Time) QuestStatus {
+	_, end := questRotation(quest.Period, now)
+	status := QuestStatus{
+		Quest:       quest,
+		State:       QuestAvailable,
+		ExpiresAt:   end,
+		RewardItems: make([]sworld.Item, 0, len(quest.Reward.Items)),
+	}
+	for _, item := range quest.Reward.Items {
+		status.RewardItems = append(status.RewardItems, item(s.defaultZone))
+	}
+	if progress == nil {
+		return status
+	}
+
+	status.Progress = progress.progress
+	switch {
+	case progress.claimed:
+		status.State = QuestClaimed
+	case progress.progress >= quest.Objective.Goal:
+		status.State = QuestCompleted
+	default:
+		status.State = QuestAccepted
+	}
+	return status
+}
+
+// trackQuests updates the accepted quests listening to an activity
+func (s *swService) trackQuests(user *sworld.User, act activity) {
+	if act.value <= 0 {
+		return
+	}
+	now := time.Now()
+
+	s.quests.mu.Lock()
+	defer s.quests.mu.Unlock()
+
+	accepted := s.quests.forUser(user, now)
+	for _, quest := range currentQuests(now) {
+		progress, ok := accepted[quest.ID]
+		if !ok || progress.progress >= quest.Objective.Goal {
+			continue
+		}
+		if !quest.Objective.matches(act) {
+			continue
+		}
+
+		if quest.Objective.Reach {
+			if act.value > progress.progress {
+				progress.progress = act.value
+			}
+		} else {
+			progress.progress += act.value
+		}
+
+		if progress.progress >= quest.Objective.Goal {
+			progress.progress = quest.Objective.Goal
+			log.Printf("User %s completed %s\n", user.ID, quest.ID)
+		}
+	}
+}
+
+func (s *swService) ListQuests(user *sworld.User) ([]QuestStatus, error) {
+	now := time.Now()
+
+	s.quests.mu.Lock()
+	defer s.quests.mu.Unlock()
+
+	accepted := s.quests.forUser(user, now)
+	quests := currentQuests(now)
+	statuses := make([]QuestStatus, 0, len(quests))
+	for _, quest := range quests {
+		statuses = append(statuses, s.questStatus(quest, accepted[quest.ID], now))
+	}
+	return statuses, nil
+}
+
+func (s *swService) AcceptQuest(user *sworld.User, id string) (QuestStatus, error) {
+	now := time.Now()
+	quest, err := findQuest(id, now)
+	if err != nil {
+		return QuestStatus{}, err
+	}
+
+	s.quests.mu.Lock()
+	defer s.quests.mu.Unlock()
+
+	accepted := s.quests.forUser(user, now)
+	if _, ok := accepted[id]; ok {
+		return QuestStatus{}, ErrQuestAlreadyAccepted
+	}
+
+	start, _ := questRotation(quest.Period, now)
+	progress := &questProgress{rotation: start}
+	accepted[id] = progress
+
+	return s.questStatus(quest, progress, now), nil
+}
+
+func (s *swService) ClaimQuest(user *sworld.User, id string) (QuestStatus, error) {
+	now := time.Now()
+	quest, err := findQuest(id, now)
+	if err != nil {
+		return QuestStatus{}, err
+	}
+
+	var status QuestStatus
+	err = s.inventoryTx(user, nil, func() error {
+		s.quests.mu.Lock()
+		defer s.quests.mu.Unlock()
+
+		progress, ok := s.quests.forUser(user, now)[id]
+		if !ok {
+			return ErrQuestNotAccepted
+		}
+		if progress.claimed {
+			return ErrQuestClaimed
+		}
+		if progress.progress < quest.Objective.Goal {
+			return ErrQuestNotCompleted
+		}
+
+		user.Gold += quest.Reward.Gold
+		for _, item := range quest.Reward.Items {
+			if _, err := user.PickupItem(item(s.defaultZone)); err != nil {
+				return err
+			}
+		}
+
+		progress.claimed = true
+		status = s.questStatus(quest, progress, now)
+		return nil
+	})
+	return status, err
+}
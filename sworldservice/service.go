@@ -22,8 +22,17 @@ var (
 )
 
 // FIXME: I'd say we can get rid of these two
+// portalAccess is who can enter a portal besides its owner
+type portalAccess struct {
+	// guildID is the guild whose members can enter the portal
+	guildID string
+	// partyID is the party whose members can enter the portal
+	partyID string
+}
+
 type sPortal struct {
 	p *sworld.Portal
+	portalAccess
 }
 
 type sUser struct {
@@ -35,19 +44,101 @@ type Service interface {
 	Authenticate(ctx context.Context, c Credentials) (*sworld.User, error)
 	FindUser(id string) *sworld.User
 	ViewUserInventory(user *sworld.User) ([]sworld.Bag, error)
+	SearchInventory(user *sworld.User, query sworld.InventoryQuery) ([]sworld.InventoryItem, int, error)
+	SortBag(user *sworld.User, characterID string, bagID int) error
 	MergeStones(user *sworld.User, source sworld.ItemLocation, target sworld.ItemLocation) (sworld.ItemLocation, error)
-
-	SpawnCharacter(user *sworld.User) (*sworld.Character, error)
+	PreviewMergeStones(user *sworld.User, source sworld.ItemLocation, target sworld.ItemLocation) (sworld.PortalStone, error)
+	MergeAllStones(user *sworld.User, dryRun bool) ([]sworld.StoneMerge, error)
+	SplitStack(user *sworld.User, location sworld.ItemLocation, count int) (sworld.ItemLocation, error)
+	MergeStacks(user *sworld.User, source sworld.ItemLocation, target sworld.ItemLocation) error
+	MoveItem(user *sworld.User, from sworld.ItemLocation, to sworld.ItemLocation) error
+	BuyBag(user *sworld.User, kind string) (sworld.ItemLocation, error)
+	AttachBag(user *sworld.User, location sworld.ItemLocation, characterID string) error
+	UpgradeBag(user *sworld.User, characterID string, bagID int) error
+
+	ViewShop(user *sworld.User, zoneID string) (*Shop, error)
+	BuyShopItem(user *sworld.User, zoneID string, itemID string) (sworld.ItemLocation, error)
+	SellItem(user *sworld.User, location sworld.ItemLocation) (int, error)
+
+	OpenTrade(user *sworld.User, username string) (*Trade, error)
+	ViewTrade(user *sworld.User, tradeID string) (*Trade, error)
+	ListTrades(user *sworld.User) ([]*Trade, error)
+	SetTradeOffer(user *sworld.User, tradeID string, locations []sworld.ItemLocation, gold int) (*Trade, error)
+	ConfirmTrade(user *sworld.User, tradeID string) (*Trade, error)
+	CancelTrade(user *sworld.User, tradeID string) (*Trade, error)
+
+	CreateAuction(user *sworld.User, location sworld.ItemLocation, startingBid, buyout int, duration time.Duration) (*Auction, error)
+	SearchAuctions(user *sworld.User, query sworld.InventoryQuery) ([]*Auction, int, error)
+	ViewAuction(id string) (*Auction, error)
+	BidAuction(user *sworld.User, id string, amount int) (*Auction, error)
+	BuyoutAuction(user *sworld.User, id string) (*Auction, error)
+	ClaimAuctionItems(user *sworld.User) ([]sworld.ItemLocation, int, error)
+
+	ListRecipes() ([]sworld.Recipe, error)
+	Craft(user *sworld.User, recipeID string, input *sworld.ItemLocation) ([]sworld.ItemLocation, error)
+
+	SpawnCharacter(user *sworld.User, class string) (*sworld.Character, error)
+	ListClasses() ([]sworld.ClassStats, error)
+	AllocateAttributes(user *sworld.User, characterID string, points sworld.Attributes) (*sworld.Character, error)
+	ListTalents(user *sworld.User, characterID string) ([]TalentStatus, int, error)
+	LearnTalent(user *sworld.User, characterID string, talentID string) (*sworld.Character, error)
+	RespecCharacter(user *sworld.User, characterID string) (*sworld.Character, int, error)
 	ListCharacters(user *sworld.User) ([]*sworld.Character, error)
 	ViewCharacterInventory(characterID string) ([]sworld.Bag, error)
 	DropCharacterItem(user *sworld.User, characterID string, bagID, slot int) error
 	TakeCharacterItem(user *sworld.User, characterID string, bagID, slot int) error
+	EquipCharacterItem(user *sworld.User, characterID string, bagID, slot int) error
+	UseItem(user *sworld.User, characterID string, bagID, slot int) error
 
-	OpenDefaultPortal(user *sworld.User) (*sworld.Portal, error)
-	OpenPortalWithStone(user *sworld.User, bagID, slot int) (*sworld.Portal, error)
+	OpenDefaultPortal(user *sworld.User, seed int64) (*sworld.Portal, error)
+	OpenPortalWithStone(user *sworld.User, bagID, slot int, seed int64) (*sworld.Portal, error)
 	ExplorePortal(user *sworld.User, portalID, characterID string) error
 	ViewPortal(portalID string) (*sworld.Portal, error)
 	ListPortals(user *sworld.User) ([]*sworld.Portal, error)
+	PortalHistory(user *sworld.User, offset, limit int) ([]*sworld.RunRecord, int, error)
+	ViewPortalRun(user *sworld.User, runID string) (*sworld.RunRecord, error)
+	PortalReplay(user *sworld.User, portalID string) ([]sworld.ReplayEvent, error)
+
+	Leaderboard(kind string, period string, zoneID string, level int, limit int) (*Leaderboard, error)
+
+	ListAchievements(user *sworld.User) ([]AchievementStatus, error)
+	ClaimAchievement(user *sworld.User, id string) (AchievementStatus, error)
+
+	ListQuests(user *sworld.User) ([]QuestStatus, error)
+	AcceptQuest(user *sworld.User, id string) (QuestStatus, error)
+	ClaimQuest(user *sworld.User, id string) (QuestStatus, error)
+
+	CreateGuild(user *sworld.User, name string) (*Guild, error)
+	ViewGuild(user *sworld.User) (*Guild, error)
+	InviteToGuild(user *sworld.User, username string) (*Guild, error)
+	JoinGuild(user *sworld.User, guildID string) (*Guild, error)
+	LeaveGuild(user *sworld.User) error
+	KickGuildMember(user *sworld.User, username string) (*Guild, error)
+	SetGuildRole(user *sworld.User, username string, role string) (*Guild, error)
+	DepositGuildItem(user *sworld.User, location sworld.ItemLocation) (sworld.ItemLocation, error)
+	WithdrawGuildItem(user *sworld.User, location sworld.ItemLocation) (sworld.ItemLocation, error)
+	DepositGuildGold(user *sworld.User, amount int) (int, error)
+	WithdrawGuildGold(user *sworld.User, amount int) (int, error)
+	OpenGuildPortal(user *sworld.User, bagID, slot int, seed int64) (*sworld.Portal, error)
+
+	CreateParty(user *sworld.User, characterID string) (*Party, error)
+	ViewParty(user *sworld.User) (*Party, error)
+	InviteToParty(user *sworld.User, username string) (*Party, error)
+	AcceptPartyInvite(user *sworld.User, partyID string, characterID string) (*Party, error)
+	LeaveParty(user *sworld.User) error
+	SetPartyLeader(user *sworld.User, username string) (*Party, error)
+	OpenPartyPortal(user *sworld.User, location *sworld.ItemLocation) (*sworld.Portal, error)
+	JoinMatchmaking(user *sworld.User, characterID string, zoneID string, level int) (*MatchmakingStatus, error)
+	LeaveMatchmaking(user *sworld.User) error
+	MatchmakingStatus(user *sworld.User) (*MatchmakingStatus, error)
+
+	SendChatMessage(user *sworld.User, channel string, target string, text string) (*ChatMessage, error)
+	ChatHistory(user *sworld.User, channel string, target string, limit int) ([]*ChatMessage, error)
+	SubscribeChat(user *sworld.User, zoneID string) (*ChatSubscription, error)
+	UnsubscribeChat(subscription *ChatSubscription)
+	ChatSettings(user *sworld.User) (*ChatSettings, error)
+	MuteChatUser(user *sworld.User, username string, muted bool) (*ChatSettings, error)
+	BlockChatUser(user *sworld.User, username string, blocked bool) (*ChatSettings, error)
 }
 
 type swService struct {
@@ -56,22 +147,83 @@ type swService struct {
 	defaultPortalDuration time.Duration
 	characters            map[string]*sworld.Character
 	defaultZone           *sworld.Zone
+	userBagCapacity       int
+	locks                 *lockManager
+	vendors               map[string]*vendor
+	trades                tradeList
+	auctions              auctionHouse
+	history               runHistory
+	leaderboards          leaderboards
+	achievements          achievementTracker
+	quests                questLog
+	guilds                guildList
+	parties               partyList
+	matchmaking           matchmaker
+	chat                  chatRoom
 }
 
 // NewService creates the service
 func NewService() Service {
+	defaultZone := createDefaultZone()
+
 	return &swService{
 		users:      make(map[string]*sUser),
 		portals:    make(map[string]*sPortal),
 		characters: make(map[string]*sworld.Character),
 		// TODO: this should be on settings
 		defaultPortalDuration: time.Second * 10,
-		defaultZone:           createDefaultZone(),
+		defaultZone:           defaultZone,
+		userBagCapacity:       10,
+		locks:                 newLockManager(),
+		vendors: map[string]*vendor{
+			defaultZone.ID: newVendor(defaultZone),
+		},
+		trades: tradeList{
+			trades: make(map[string]*Trade),
+		},
+		auctions: auctionHouse{
+			auctions: make(map[string]*Auction),
+			pending:  make(map[string][]sworld.Item),
+		},
+		history: runHistory{
+			runs:    make(map[string][]*sworld.RunRecord),
+			replays: make(map[string]*portalReplay),
+		},
+		leaderboards: leaderboards{
+			best: make(map[bestRunKey]leaderboardRun),
+		},
+		achievements: achievementTracker{
+			users: make(map[string]*userAchievements),
+		},
+		quests: questLog{
+			users: make(map[string]map[string]*questProgress),
+		},
+		guilds: guildList{
+			guilds:  make(map[string]*Guild),
+			members: make(map[string]string),
+		},
+		parties: partyList{
+			parties: make(map[string]*Party),
+			members: make(map[string]string),
+		},
+		matchmaking: matchmaker{
+			queues:  make(map[matchQueueKey][]*matchTicket),
+			tickets: make(map[string]matchQueueKey),
+		},
+		chat: chatRoom{
+			history:       make(map[string][]*ChatMessage),
+			subscriptions: make(map[string][]*ChatSubscription),
+			muted:         make(map[string]map[string]*sworld.User),
+			blocked:       make(map[string]map[string]*sworld.User),
+			sent:          make(map[string][]time.Time),
+		},
 	}
 }
 
 func (s *swService) TakeCharacterItem(user *sworld.User, characterID string, bagID, slot int) error {
-	// TODO: Fail if the character is exploring
+	unlock := s.lockInventory(user, characterID)
+	defer unlock()
+
 	return user.TakeCharacterItem(characterID, sworld.ItemLocation{
 		BagID: bagID,
 		Slot:  slot,
@@ -79,7 +231,9 @@ func (s *swService) TakeCharacterItem(user *sworld.User, characterID string, bag
 }
 
 func (s *swService) DropCharacterItem(user *sworld.User, characterID string, bagID, slot int) error {
-	// TODO: fail if the character is exploring
+	unlock := s.lockInventory(user, characterID)
+	defer unlock()
+
 	character, err := user.FindCharacter(characterID)
 	if err != nil {
 		return err
@@ -87,14 +241,98 @@ func (s *swService) DropCharacterItem(user *sworld.User, characterID string, bag
 	if character.Health <= 0 {
 		return ErrCharacterIsDead
 	}
+	if character.Exploring {
+		return sworld.ErrCharacterBusy
+	}
 	_, err = character.DropItem(bagID, slot)
 
 	return err
 }
 
+func (s *swService) EquipCharacterItem(user *sworld.User, characterID string, bagID, slot int) error {
+	unlock := s.lockInventory(user, characterID)
+	defer unlock()
+
+	character, err := user.FindCharacter(characterID)
+	if err != nil {
+		return err
+	}
+	if character.Health <= 0 {
+		return ErrCharacterIsDead
+	}
+
+	return character.EquipWeapon(bagID, slot)
+}
+
+func (s *swService) UseItem(user *sworld.User, characterID string, bagID, slot int) error {
+	// The character might be exploring, so only its own lock is taken
+	unlock := s.locks.Lock(characterLockKey(characterID))
+	defer unlock()
+
+	character, err := user.FindCharacter(characterID)
+	if err != nil {
+		return err
+	}
+	if character.Health <= 0 {
+		return ErrCharacterIsDead
+	}
+
+	return character.UseItem(bagID, slot)
+}
+
 func (s *swService) MergeStones(user *sworld.User, source sworld.ItemLocation, target sworld.ItemLocation) (sworld.ItemLocation, error) {
-	// FIXME: call user.MergeStones directly?
-	return user.MergeStones(source, target)
+	unlock := s.lockInventory(user, source.CharacterID, target.CharacterID)
+	defer unlock()
+
+	location, err := user.MergeStones(source, target)
+	if err == nil {
+		s.trackEvent(user, StoneMergedEvent, 1)
+	}
+	return location, err
+}
+
+func (s *swService) PreviewMergeStones(user *sworld.User, source sworld.ItemLocation, target sworld.ItemLocation) (sworld.PortalStone, error) {
+	unlock := s.lockInventory(user, source.CharacterID, target.CharacterID)
+	defer unlock()
+
+	return user.PreviewMergeStones(source, target)
+}
+
+func (s *swService) MergeAllStones(user *sworld.User, dryRun bool) ([]sworld.StoneMerge, error) {
+	unlock := s.lockInventory(user)
+	defer unlock()
+
+	if dryRun {
+		return user.PreviewMergeAllStones()
+	}
+	merges, err := user.MergeAllStones()
+	if err == nil {
+		s.trackEvent(user, StoneMergedEvent, len(merges))
+	}
+	return merges, err
+}
+
+func (s *swService) SplitStack(user *sworld.User, location sworld.ItemLocation, count int) (sworld.ItemLocation, error) {
+	var newLocation sworld.ItemLocation
+	err := s.inventoryTx(user, []string{location.CharacterID}, func() error {
+		var err error
+		newLocation, err = user.SplitStack(location, count)
+		return err
+	})
+	return newLocation, err
+}
+
+func (s *swService) MergeStacks(user *sworld.User, source sworld.ItemLocation, target sworld.ItemLocation) error {
+	return s.inventoryTx(user, []string{source.CharacterID, target.CharacterID}, func() error {
+		return user.MergeStacks(source, target)
+	})
+}
+
+func (s *swService) MoveItem(user *sworld.User, from sworld.ItemLocation, to sworld.ItemLocation) error {
+	unlock := s.lockInventory(user, from.CharacterID, to.CharacterID)
+	defer unlock()
+
+	return user.MoveItem(from, to)
 }
 
 func (s *swService) ViewUserInventory(user *sworld.User) ([]sworld.Bag, error) {
@@ -102,6 +340,32 @@ func (s *swService) ViewUserInventory(user *sworld.User) ([]sworld.Bag, error) {
 	return user.Bags, nil
 }
 
+func (s *swService) SearchInventory(user *sworld.User, query sworld.InventoryQuery) ([]sworld.InventoryItem, int, error) {
+	unlock := s.lockInventory(user)
+	defer unlock()
+
+	items, total := sworld.SearchItems(user.Bags, query)
+	return items, total, nil
+}
+
+func (s *swService) SortBag(user *sworld.User, characterID string, bagID int) error {
+	unlock := s.lockInventory(user, characterID)
+	defer unlock()
+
+	bag, err := user.FindBag(characterID, bagID)
+	if err != nil {
+		return err
+	}
+	// Sorting merges stacks, that would change the items offered on a trade
+	for _, item := range bag.Items() {
+		if user.IsReserved(item) {
+			return sworld.ErrItemReserved
+		}
+	}
+	bag.Sort()
+	return nil
+}
+
 func (s *swService) ViewCharacterInventory(characterID string) ([]sworld.Bag, error) {
 	// TODO: Fail if the character is explorig
 	character := s.characters[characterID]
@@ -130,8 +394,13 @@ func (s *swService) ListPortals(user *sworld.User) ([]*sworld.Portal, error) {
 	portals := make([]*sworld.Portal, 0, len(s.portals))
 	userID := user.ID
 
+	guildID := ""
+	if guild, err := s.guildOf(userID); err == nil {
+		guildID = guild.ID
+	}
+
 	for _, portal := range s.portals {
-		if portal.p.User.ID == userID {
+		if portal.p.User.ID == userID || (guildID != "" && portal.guildID == guildID) {
 			portals = append(portals, portal.p)
 		}
 	}
@@ -162,18 +431,25 @@ func (s *swService) ExplorePortal(user *sworld.User, portalID, characterID strin
 	if sportal == nil {
 		return ErrPortalNotFound
 	}
-	if sportal.p.User.ID != user.ID {
+	if !s.canEnterPortal(user, sportal) {
 		return ErrCantEnterPortal
 	}
+
+	// Entering the portal makes the inventory of the character read only, so
+	// it can't happen in the middle of an inventory change
+	unlock := s.lockInventory(user, characterID)
 	character, err := user.FindCharacter(characterID)
 	if err != nil {
+		unlock()
 		return err
 	}
 	if character.Health <= 0 {
+		unlock()
 		return ErrCharacterIsDead
 	}
 
 	exploration, err := character.EnterPortal(sportal.p)
+	unlock()
 	if err != nil {
 		return err
 	}
@@ -184,41 +460,54 @@ func (s *swService) ExplorePortal(user *sworld.User, portalID, characterID strin
 	return nil
 }
 
-func (s *swService) OpenPortalWithStone(user *sworld.User, bagID, slot int) (*sworld.Portal, error) {
-	// TODO: lock inventory
-	item, err := user.GetItem(sworld.ItemLocation{BagID: bagID, Slot: slot})
-	if err != nil {
-		return nil, err
-	}
-	stone, ok := item.(*sworld.PortalStone)
-	if !ok {
-		return nil, ErrWrongItem
-	}
-	portal, err := s.openPortal(user, *stone)
+func (s *swService) OpenPortalWithStone(user *sworld.User, bagID, slot int, seed int64) (*sworld.Portal, error) {
+	return s.openPortalWithStone(user, bagID, slot, seed, portalAccess{})
+}
+
+func (s *swService) openPortalWithStone(user *sworld.User, bagID, slot int, seed int64, access portalAccess) (*sworld.Portal, error) {
+	var portal *sworld.Portal
+	err := s.inventoryTx(user, nil, func() error {
+		item, err := user.GetItem(sworld.ItemLocation{BagID: bagID, Slot: slot})
+		if err != nil {
+			return err
+		}
+		stone, ok := item.(*sworld.PortalStone)
+		if !ok {
+			return ErrWrongItem
+		}
+		err = user.DropItem(bagID, slot)
+		if err != nil {
+			return err
+		}
 
-	err = user.DropItem(bagID, slot)
-	// TODO: unlock inventory
+		// The stone is put back if the portal can't be opened
+		portal, err = s.openPortal(user, *stone, seed, access)
+		return err
+	})
 	if err != nil {
-		// TODO: if an error occurs here, we should close the portal
 		return nil, err
 	}
 
 	return portal, nil
 }
 
-func (s *swService) OpenDefaultPortal(user *sworld.User) (*sworld.Portal, error) {
+func (s *swService) OpenDefaultPortal(user *sworld.User, seed int64) (*sworld.Portal, error) {
 	stone := s.defaultStone(user)
-	portal, err := s.openPortal(user, stone)
+	portal, err := s.openPortal(user, stone, seed, portalAccess{})
 
 	return portal, err
 }
 
-func (s *swService) SpawnCharacter(user *sworld.User) (*sworld.Character, error) {
+func (s *swService) SpawnCharacter(user *sworld.User, class string) (*sworld.Character, error) {
 	if user.HasAliveCharacters() {
 		return nil, ErrAlreadyHasCharacters
 	}
+	stats, err := sworld.FindClass(class)
+	if err != nil {
+		return nil, err
+	}
 
-	character := sworld.NewCharacter()
+	character := sworld.NewCharacter(stats.Class)
 	character.User = user
 	user.Characters = append(user.Characters, character)
 
@@ -226,3 +515,7 @@ func (s *swService) SpawnCharacter(user *sworld.User) (*sworld.Character, error)
 
 	return character, nil
 }
+
+func (s *swService) ListClasses() ([]sworld.ClassStats, error) {
+	return sworld.Classes(), nil
+}
@@ -0,0 +1,318 @@
+package sworldservice
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/grilix/sworld/sworld"
+)
+
+var (
+	// ErrShopNotFound is when there is no vendor on a zone
+	ErrShopNotFound = errors.New("There is no shop on that zone")
+	// ErrShopItemNotFound is when the item is not on sale right now
+	ErrShopItemNotFound = errors.New("That item is not on sale")
+	// ErrOutOfStock is when the vendor already sold every unit of an item
+	ErrOutOfStock = errors.New("That item is out of stock")
+	// ErrItemNotSellable is when the vendor doesn't buy that item
+	ErrItemNotSellable = errors.New("The vendor doesn't buy that item")
+)
+
+// TODO: this should be on settings
+const (
+	// shopRotation is how often the stock of the vendors changes
+	shopRotation = time.Hour
+	// shopSize is the amount of different items on sale at the same time
+	shopSize = 6
+)
+
+// ShopItem is an item on sale at a vendor
+type ShopItem struct {
+	ID    string
+	Item  sworld.Item
+	Price int
+	Stock int
+}
+
+// SellOffer is what the vendor pays for an item of the user
+type SellOffer struct {
+	Location sworld.ItemLocation
+	Item     sworld.Item
+	Price    int
+}
+
+// Shop is what a vendor has on sale until the next rotation
+type Shop struct {
+	Zone       *sworld.Zone
+	Items      []ShopItem
+	RotatesAt  time.Time
+	SellOffers []SellOffer
+}
+
+// shopEntry is an item a vendor can have on sale
+type shopEntry struct {
+	id    string
+	price int
+	// stock is the amount of units available on each rotation
+	stock int
+	item  func(zone *sworld.Zone) sworld.Item
+}
+
+type vendor struct {
+	zone    *sworld.Zone
+	catalog []shopEntry
+
+	mu       sync.Mutex
+	rotation int64
+	offers   []int
+	stock    map[string]int
+}
+
+func stoneEntry(id string, level, price, stock int, duration time.Duration) shopEntry {
+	return shopEntry{
+		id:    id,
+		price: price,
+		stock: stock,
+		item: func(zone *sworld.Zone) sworld.Item {
+			return &sworld.PortalStone{
+				Level:    level,
+				Zone:     zone,
+				Duration: duration,
+			}
+		},
+	}
+}
+
+func consumableEntry(id string, price, stock int, consumable sworld.Consumable) shopEntry {
+	return shopEntry{
+		id:    id,
+		price: price,
+		stock: stock,
+		item: func(zone *sworld.Zone) sworld.Item {
+			item := consumable
+			return &item
+		},
+	}
+}
+
+func bagEntry(offer bagOffer) shopEntry {
+	return shopEntry{
+		id:    "bag_" + offer.Kind,
+		price: offer.Price,
+		stock: 1,
+		item: func(zone *sworld.Zone) sworld.Item {
+			return &sworld.BagItem{
+				Kind:     offer.Kind,
+				Capacity: offer.Capacity,
+			}
+		},
+	}
+}
+
+func newVendor(zone *sworld.Zone) *vendor {
+	catalog := []shopEntry{
+		stoneEntry("stone_0", 0, 50, 10, 30*time.Second),
+		stoneEntry("stone_1", 1, 100, 5, 15*time.Second),
+		stoneEntry("stone_2", 2, 250, 3, 15*time.Second),
+		consumableEntry("health_potion", 60, 10, sworld.Consumable{
+			Kind:     sworld.HealthPotion,
+			Power:    40,
+			Quantity: 5,
+		}),
+		consumableEntry("damage_buff", 80, 5, sworld.Consumable{
+			Kind:     sworld.DamageBuff,
+			Power:    15,
+			Duration: 10 * time.Second,
+			Quantity: 3,
+		}),
+		consumableEntry("portal_extender", 120, 3, sworld.Consumable{
+			Kind:     sworld.PortalExtender,
+			Power:    5,
+			Quantity: 1,
+		}),
+	}
+	for _, offer := range bagCatalog {
+		catalog = append(catalog, bagEntry(offer))
+	}
+
+	return &vendor{
+		zone:     zone,
+		catalog:  catalog,
+		rotation: -1,
+	}
+}
+
+// rotate changes the stock when a new rotation starts
+// The offers only depend on the rotation, so they are the same for everyone
+func (v *vendor) rotate(now time.Time) {
+	rotation := now.Unix() / int64(shopRotation.Seconds())
+	if rotation == v.rotation {
+		return
+	}
+
+	rng := rand.New(rand.NewSource(rotation))
+	size := shopSize
+	if size > len(v.catalog) {
+		size = len(v.catalog)
+	}
+
+	v.rotation = rotation
+	v.offers = rng.Perm(len(v.catalog))[:size]
+	v.stock = make(map[string]int)
+	for _, index := range v.offers {
+		v.stock[v.catalog[index].id] = v.catalog[index].stock
+	}
+}
+
+func (v *vendor) rotatesAt() time.Time {
+	return time.Unix((v.rotation+1)*int64(shopRotation.Seconds()), 0)
+}
+
+func (v *vendor) findOffer(id string) (shopEntry, error) {
+	for _, index := range v.offers {
+		if v.catalog[index].id == id {
+			return v.catalog[index], nil
+		}
+	}
+	return shopEntry{}, ErrShopItemNotFound
+}
+
+// sellPrice returns what a vendor pays for an item, 0 if it doesn't buy it
+func sellPrice(item sworld.Item) int {
+	switch i := item.(type) {
+	case *sworld.PortalStone:
+		price := 25 * (i.Level + 1)
+		// Stones spawning waves more often are worth more
+		if interval := i.WaveInterval(); interval > 0 {
+			price += 10 * int(time.Minute/interval)
+		}
+		return price
+	case *sworld.Weapon:
+		return (2 * i.Damage) + (50 * int(i.Rarity))
+	case *sworld.Consumable:
+		return i.Quantity * (1 + (i.Power / 10))
+	case *sworld.BagItem:
+		return 10 * i.Capacity
+	case *sworld.Material:
+		return 2 * i.Quantity
+	}
+	return 0
+}
+
+func (s *swService) findVendor(zoneID string) (*vendor, error) {
+	if zoneID == "" {
+		zoneID = s.defaultZone.ID
+	}
+	v, ok := s.vendors[zoneID]
+	if !ok {
+		return nil, ErrShopNotFound
+	}
+	return v, nil
+}
+
+func (s *swService) ViewShop(user *sworld.User, zoneID string) (*Shop, error) {
+	v, err := s.findVendor(zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	v.mu.Lock()
+	v.rotate(time.Now())
+	shop := &Shop{
+		Zone:      v.zone,
+		Items:     make([]ShopItem, 0, len(v.offers)),
+		RotatesAt: v.rotatesAt(),
+	}
+	for _, index := range v.offers {
+		entry := v.catalog[index]
+		shop.Items = append(shop.Items, ShopItem{
+			ID:    entry.id,
+			Item:  entry.item(v.zone),
+			Price: entry.price,
+			Stock: v.stock[entry.id],
+		})
+	}
+	v.mu.Unlock()
+
+	unlock := s.lockInventory(user)
+	defer unlock()
+
+	shop.SellOffers = make([]SellOffer, 0)
+	for bagID, bag := range user.Bags {
+		for slot, item := range bag.Items() {
+			price := sellPrice(item)
+			if price == 0 {
+				continue
+			}
+			shop.SellOffers = append(shop.SellOffers, SellOffer{
+				Location: sworld.ItemLocation{BagID: bagID, Slot: slot},
+				Item:     item,
+				Price:    price,
+			})
+		}
+	}
+
+	return shop, nil
+}
+
+func (s *swService) BuyShopItem(user *sworld.User, zoneID string, itemID string) (sworld.ItemLocation, error) {
+	v, err := s.findVendor(zoneID)
+	if err != nil {
+		return sworld.ItemLocation{}, err
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.rotate(time.Now())
+	entry, err := v.findOffer(itemID)
+	if err != nil {
+		return sworld.ItemLocation{}, err
+	}
+	if v.stock[entry.id] <= 0 {
+		return sworld.ItemLocation{}, ErrOutOfStock
+	}
+
+	var location sworld.ItemLocation
+	err = s.inventoryTx(user, nil, func() error {
+		err := user.SpendGold(entry.price)
+		if err != nil {
+			return err
+		}
+		location, err = user.PickupItem(entry.item(v.zone))
+		return err
+	})
+	if err != nil {
+		return sworld.ItemLocation{}, err
+	}
+
+	v.stock[entry.id]--
+	return location, nil
+}
+
+func (s *swService) SellItem(user *sworld.User, location sworld.ItemLocation) (int, error) {
+	var price int
+	err := s.inventoryTx(user, []string{location.CharacterID}, func() error {
+		item, err := user.GetItem(location)
+		if err != nil {
+			return err
+		}
+		price = sellPrice(item)
+		if price == 0 {
+			return ErrItemNotSellable
+		}
+
+		err = user.DropItemAt(location)
+		if err != nil {
+			return err
+		}
+		user.Gold += price
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return price, nil
+}
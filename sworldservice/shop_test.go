@@ -0,0 +1,78 @@
+package sworldservice
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/grilix/sworld/sworld"
+)
+
+func TestConcurrentBuyAndSell(t *testing.T) {
+	s := NewService().(*swService)
+	user := newTestUser(t, s)
+	character := user.Characters[0]
+
+	shop, err := s.ViewShop(user, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	offer := shop.Items[0]
+	for _, item := range shop.Items {
+		if item.Price < offer.Price {
+			offer = item
+		}
+	}
+	user.Gold = offer.Price * 2
+	if err := character.Bags[0].StoreItem(&sworld.Weapon{Damage: 10}, 0); err != nil {
+		t.Fatal(err)
+	}
+	gold := user.Gold
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var spent, earned int
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			if _, err := s.BuyShopItem(user, "", offer.ID); err == nil {
+				mu.Lock()
+				spent += offer.Price
+				mu.Unlock()
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			location := sworld.ItemLocation{BagID: 0, Slot: i % s.userBagCapacity}
+			if price, err := s.SellItem(user, location); err == nil {
+				mu.Lock()
+				earned += price
+				mu.Unlock()
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		location := sworld.ItemLocation{CharacterID: character.ID, BagID: 0, Slot: 0}
+		if price, err := s.SellItem(user, location); err == nil {
+			mu.Lock()
+			earned += price
+			mu.Unlock()
+		}
+	}()
+	wg.Wait()
+
+	unlock := s.lockInventory(user, character.ID)
+	defer unlock()
+	if user.Gold < 0 {
+		t.Fatal("Expected gold to never be negative, got", user.Gold)
+	}
+	if user.Gold != gold-spent+earned {
+		t.Error("Expected", gold-spent+earned, "gold, got", user.Gold)
+	}
+	if countItems(character.Bags) != 0 {
+		t.Error("Expected the weapon of the character to be sold")
+	}
+}
@@ -0,0 +1,98 @@
+package sworldservice
+
+import (
+	"github.com/grilix/sworld/sworld"
+)
+
+// TODO: this should be on settings
+const (
+	// respecCostPerLevel is the gold paid for each level of the character
+	// when refunding its points
+	respecCostPerLevel = 100
+)
+
+// TalentStatus is a talent on the tree of a character
+type TalentStatus struct {
+	Talent   sworld.Talent
+	Rank     int
+	Unlocked bool
+}
+
+// RespecCost returns the gold needed to refund the points of a character
+func RespecCost(character *sworld.Character) int {
+	return character.Level * respecCostPerLevel
+}
+
+// progressCharacter runs fn on a living character holding its locks
+func (s *swService) progressCharacter(user *sworld.User, characterID string, fn func(*sworld.Character) error) (*sworld.Character, error) {
+	unlock := s.lockInventory(user, characterID)
+	defer unlock()
+
+	character, err := user.FindCharacter(characterID)
+	if err != nil {
+		return nil, err
+	}
+	if character.Health <= 0 {
+		return nil, ErrCharacterIsDead
+	}
+
+	if err := fn(character); err != nil {
+		return nil, err
+	}
+	return character, nil
+}
+
+func (s *swService) AllocateAttributes(user *sworld.User, characterID string, points sworld.Attributes) (*sworld.Character, error) {
+	return s.progressCharacter(user, characterID, func(character *sworld.Character) error {
+		return character.AllocateAttributes(points)
+	})
+}
+
+// ListTalents returns the talents on the tree of a character, and its
+// unspent talent points
+func (s *swService) ListTalents(user *sworld.User, characterID string) ([]TalentStatus, int, error) {
+	unlock := s.locks.Lock(characterLockKey(characterID))
+	defer unlock()
+
+	character, err := user.FindCharacter(characterID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	talents := sworld.ClassTalents(character.Class)
+	statuses := make([]TalentStatus, 0, len(talents))
+	for _, talent := range talents {
+		statuses = append(statuses, TalentStatus{
+			Talent:   talent,
+			Rank:     character.Talents[talent.ID],
+			Unlocked: character.TalentUnlocked(talent),
+		})
+	}
+	return statuses, character.TalentPoints, nil
+}
+
+func (s *swService) LearnTalent(user *sworld.User, characterID string, talentID string) (*sworld.Character, error) {
+	return s.progressCharacter(user, characterID, func(character *sworld.Character) error {
+		return character.LearnTalent(talentID)
+	})
+}
+
+// RespecCharacter refunds every attribute and talent point of a character,
+// the user pays RespecCost for it
+// It returns the gold the user has left
+func (s *swService) RespecCharacter(user *sworld.User, characterID string) (*sworld.Character, int, error) {
+	gold := 0
+	character, err := s.progressCharacter(user, characterID, func(character *sworld.Character) error {
+		cost := RespecCost(character)
+		if err := user.SpendGold(cost); err != nil {
+			return err
+		}
+		if err := character.Respec(); err != nil {
+			user.Gold += cost
+			return err
+		}
+		gold = user.Gold
+		return nil
+	})
+	return character, gold, err
+}
@@ -0,0 +1,409 @@
+package sworldservice
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/grilix/sworld/sworld"
+)
+
+var (
+	// ErrUserNotFound is when there is no user with that name
+	ErrUserNotFound = errors.New("The user was not found")
+	// ErrTradeNotFound is when the trade does not exist
+	ErrTradeNotFound = errors.New("The trade was not found")
+	// ErrTradeClosed is when the trade is not open anymore
+	ErrTradeClosed = errors.New("The trade is closed")
+	// ErrTradeWithYourself is when both sides of a trade are the same user
+	ErrTradeWithYourself = errors.New("You can't trade with yourself")
+	// ErrTradeChanged is when an offered item changed after being offered
+	ErrTradeChanged = errors.New("The offer changed, it needs to be confirmed again")
+	// ErrTradeCharacterItem is when the offered item is on a character
+	ErrTradeCharacterItem = errors.New("Only items on the user bags can be traded")
+)
+
+// TODO: this should be on settings
+const (
+	tradeTimeout = 5 * time.Minute
+	// maxTradeAudit is the amount of closed trades kept for auditing
+	maxTradeAudit = 1000
+)
+
+// TradeStatus is the status of a trade
+type TradeStatus string
+
+const (
+	// TradeOpen is a trade waiting for both users to confirm
+	TradeOpen TradeStatus = "open"
+	// TradeCompleted is a trade whose items were exchanged
+	TradeCompleted TradeStatus = "completed"
+	// TradeCancelled is a trade cancelled by one of the users
+	TradeCancelled TradeStatus = "cancelled"
+	// TradeExpired is a trade nobody confirmed in time
+	TradeExpired TradeStatus = "expired"
+)
+
+// TradeItem is an item offered on a trade
+type TradeItem struct {
+	Item sworld.Item
+	// Quantity is the size of the stack when it was offered
+	Quantity int
+}
+
+// TradeOffer is what a user gives on a trade
+type TradeOffer struct {
+	User      *sworld.User
+	Items     []TradeItem
+	Gold      int
+	Confirmed bool
+}
+
+// TradeEvent is an entry of the trade audit log
+type TradeEvent struct {
+	At     time.Time
+	UserID string
+	Action string
+}
+
+// Trade is an exchange of items and gold between two users
+type Trade struct {
+	ID        string
+	Offers    [2]*TradeOffer
+	Status    TradeStatus
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	Log       []TradeEvent
+}
+
+// TradeAudit is what is kept of a trade once it's closed
+type TradeAudit struct {
+	ID      string
+	UserIDs [2]string
+	Status  TradeStatus
+	Log     []TradeEvent
+}
+
+// tradeList holds the open trades, closed trades are removed and only their
+// audit record is kept
+type tradeList struct {
+	mu     sync.Mutex
+	trades map[string]*Trade
+	audit  []TradeAudit
+}
+
+func tradeLockKey(id string) string {
+	return "trade:" + id
+}
+
+func stackCount(item sworld.Item) int {
+	if stack, ok := item.(sworld.Stackable); ok {
+		return stack.Count()
+	}
+	return 1
+}
+
+func (t *Trade) record(user *sworld.User, action string) {
+	event := TradeEvent{At: time.Now(), Action: action}
+	if user != nil {
+		event.UserID = user.ID
+	}
+	t.Log = append(t.Log, event)
+	log.Printf("Trade %s: %s %s\n", t.ID, event.UserID, action)
+}
+
+// offer returns the offer of a user, and the offer of the other side
+func (t *Trade) offer(user *sworld.User) (*TradeOffer, *TradeOffer, error) {
+	for i, offer := range t.Offers {
+		if offer.User.ID == user.ID {
+			return offer, t.Offers[1-i], nil
+		}
+	}
+	return nil, nil, ErrTradeNotFound
+}
+
+func (t *Trade) releaseItems() {
+	for _, offer := range t.Offers {
+		for _, item := range offer.Items {
+			offer.User.ReleaseItem(item.Item)
+		}
+	}
+}
+
+func (t *Trade) reserveItems() {
+	for _, offer := range t.Offers {
+		for _, item := range offer.Items {
+			offer.User.ReserveItem(item.Item)
+		}
+	}
+}
+
+func (t *Trade) close(status TradeStatus, user *sworld.User) {
+	t.releaseItems()
+	t.Status = status
+	t.record(user, string(status))
+}
+
+// closeTrade closes a trade and takes it out of the list
+func (s *swService) closeTrade(trade *Trade, status TradeStatus, user *sworld.User) {
+	trade.close(status, user)
+
+	s.trades.mu.Lock()
+	defer s.trades.mu.Unlock()
+
+	delete(s.trades.trades, trade.ID)
+	s.trades.audit = append(s.trades.audit, TradeAudit{
+		ID:      trade.ID,
+		UserIDs: [2]string{trade.Offers[0].User.ID, trade.Offers[1].User.ID},
+		Status:  trade.Status,
+		Log:     trade.Log,
+	})
+	if len(s.trades.audit) > maxTradeAudit {
+		s.trades.audit = s.trades.audit[1:]
+	}
+}
+
+func (s *swService) findUserByUsername(username string) (*sworld.User, error) {
+	for _, user := range s.users {
+		if user.u.Username == username {
+			return user.u, nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+// lockTrade finds a trade of the user and locks it, along with the
+// inventories of both users
+func (s *swService) lockTrade(user *sworld.User, tradeID string) (*Trade, func(), error) {
+	s.trades.mu.Lock()
+	trade, ok := s.trades.trades[tradeID]
+	s.trades.mu.Unlock()
+	if !ok {
+		return nil, nil, ErrTradeNotFound
+	}
+	if _, _, err := trade.offer(user); err != nil {
+		return nil, nil, err
+	}
+
+	unlock := s.locks.Lock(
+		tradeLockKey(trade.ID),
+		userLockKey(trade.Offers[0].User.ID),
+		userLockKey(trade.Offers[1].User.ID),
+	)
+	return trade, unlock, nil
+}
+
+func (s *swService) OpenTrade(user *sworld.User, username string) (*Trade, error) {
+	partner, err := s.findUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+	if partner.ID == user.ID {
+		return nil, ErrTradeWithYourself
+	}
+
+	now := time.Now()
+	trade := &Trade{
+		ID: sworld.RandomID(16),
+		Offers: [2]*TradeOffer{
+			{User: user},
+			{User: partner},
+		},
+		Status:    TradeOpen,
+		CreatedAt: now,
+		ExpiresAt: now.Add(tradeTimeout),
+	}
+	trade.record(user, "opened")
+
+	s.trades.mu.Lock()
+	s.trades.trades[trade.ID] = trade
+	s.trades.mu.Unlock()
+
+	time.AfterFunc(tradeTimeout, func() {
+		unlock := s.locks.Lock(
+			tradeLockKey(trade.ID),
+			userLockKey(trade.Offers[0].User.ID),
+			userLockKey(trade.Offers[1].User.ID),
+		)
+		defer unlock()
+
+		if trade.Status == TradeOpen {
+			s.closeTrade(trade, TradeExpired, nil)
+		}
+	})
+
+	return trade, nil
+}
+
+func (s *swService) ViewTrade(user *sworld.User, tradeID string) (*Trade, error) {
+	trade, unlock, err := s.lockTrade(user, tradeID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	return trade, nil
+}
+
+func (s *swService) ListTrades(user *sworld.User) ([]*Trade, error) {
+	s.trades.mu.Lock()
+	defer s.trades.mu.Unlock()
+
+	trades := make([]*Trade, 0)
+	for _, trade := range s.trades.trades {
+		if _, _, err := trade.offer(user); err == nil {
+			trades = append(trades, trade)
+		}
+	}
+	return trades, nil
+}
+
+func (s *swService) SetTradeOffer(user *sworld.User, tradeID string, locations []sworld.ItemLocation, gold int) (*Trade, error) {
+	trade, unlock, err := s.lockTrade(user, tradeID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if trade.Status != TradeOpen {
+		return nil, ErrTradeClosed
+	}
+	if gold < 0 || gold > user.Gold {
+		return nil, sworld.ErrNotEnoughGold
+	}
+	offer, other, _ := trade.offer(user)
+
+	for _, item := range offer.Items {
+		user.ReleaseItem(item.Item)
+	}
+
+	items := make([]TradeItem, 0, len(locations))
+	for _, location := range locations {
+		// Only the user bags are locked by the trade
+		var item sworld.Item
+		err := ErrTradeCharacterItem
+		if location.CharacterID == "" {
+			item, err = user.GetItem(location)
+		}
+		if err == nil {
+			err = user.ReserveItem(item)
+		}
+		if err != nil {
+			// Put the previous offer back
+			for _, item := range items {
+				user.ReleaseItem(item.Item)
+			}
+			for _, item := range offer.Items {
+				user.ReserveItem(item.Item)
+			}
+			return nil, err
+		}
+		items = append(items, TradeItem{Item: item, Quantity: stackCount(item)})
+	}
+
+	offer.Items = items
+	offer.Gold = gold
+	offer.Confirmed = false
+	other.Confirmed = false
+	trade.record(user, "offered")
+
+	return trade, nil
+}
+
+// giveItems moves the offered items and gold from one side to the other
+func giveItems(offer *TradeOffer, to *sworld.User) error {
+	from := offer.User
+	err := from.SpendGold(offer.Gold)
+	if err != nil {
+		return err
+	}
+	to.Gold += offer.Gold
+
+	for _, item := range offer.Items {
+		location, err := from.FindItem(item.Item)
+		if err != nil {
+			return err
+		}
+		if stackCount(item.Item) != item.Quantity {
+			return ErrTradeChanged
+		}
+
+		from.ReleaseItem(item.Item)
+		err = from.DropItem(location.BagID, location.Slot)
+		if err != nil {
+			return err
+		}
+		_, err = to.PickupItem(item.Item)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// executeTrade exchanges both offers, either everything is exchanged or
+// nothing is
+func (s *swService) executeTrade(trade *Trade) error {
+	first, second := trade.Offers[0], trade.Offers[1]
+
+	err := first.User.Transaction(func() error {
+		return second.User.Transaction(func() error {
+			err := giveItems(first, second.User)
+			if err != nil {
+				return err
+			}
+			return giveItems(second, first.User)
+		})
+	})
+	if err != nil {
+		// Whatever was released is back on its place
+		trade.reserveItems()
+	}
+	return err
+}
+
+func (s *swService) ConfirmTrade(user *sworld.User, tradeID string) (*Trade, error) {
+	trade, unlock, err := s.lockTrade(user, tradeID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if trade.Status != TradeOpen {
+		return nil, ErrTradeClosed
+	}
+	offer, other, _ := trade.offer(user)
+	offer.Confirmed = true
+	trade.record(user, "confirmed")
+
+	if !other.Confirmed {
+		return trade, nil
+	}
+
+	err = s.executeTrade(trade)
+	if err != nil {
+		offer.Confirmed = false
+		other.Confirmed = false
+		trade.record(user, "failed: "+err.Error())
+		return nil, err
+	}
+	s.closeTrade(trade, TradeCompleted, user)
+
+	return trade, nil
+}
+
+func (s *swService) CancelTrade(user *sworld.User, tradeID string) (*Trade, error) {
+	trade, unlock, err := s.lockTrade(user, tradeID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if trade.Status != TradeOpen {
+		return nil, ErrTradeClosed
+	}
+	s.closeTrade(trade, TradeCancelled, user)
+
+	return trade, nil
+}
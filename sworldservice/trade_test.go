@@ -0,0 +1,36 @@
+package sworldservice
+
+import (
+	"testing"
+
+	"github.com/grilix/sworld/sworld"
+)
+
+func TestClosedTradesAreRemoved(t *testing.T) {
+	s := NewService().(*swService)
+	user := newTestUser(t, s)
+	partner := newTestUser(t, s)
+
+	trade, err := s.OpenTrade(user, partner.Username)
+	if err != nil {
+		t.Fatal(err)
+	}
+	location := sworld.ItemLocation{CharacterID: user.Characters[0].ID}
+	_, err = s.SetTradeOffer(user, trade.ID, []sworld.ItemLocation{location}, 0)
+	if err != ErrTradeCharacterItem {
+		t.Error("Expected character items to not be offered, got", err)
+	}
+
+	if _, err := s.CancelTrade(partner, trade.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.ViewTrade(user, trade.ID); err != ErrTradeNotFound {
+		t.Error("Expected the trade to be removed, got", err)
+	}
+	if trades, _ := s.ListTrades(user); len(trades) != 0 {
+		t.Error("Expected no trades, got", len(trades))
+	}
+	if len(s.trades.audit) != 1 || s.trades.audit[0].Status != TradeCancelled {
+		t.Error("Expected the cancelled trade to be audited, got", s.trades.audit)
+	}
+}
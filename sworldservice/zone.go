@@ -1,11 +1,15 @@
 package sworldservice
 
 import (
+	"errors"
 	"time"
 
 	"github.com/grilix/sworld/sworld"
 )
 
+// ErrZoneNotFound is when the zone does not exist
+var ErrZoneNotFound = errors.New("The zone was not found")
+
 // TODO: This is me testing it
 func randomPowerStone(portal *sworld.Portal) sworld.Item {
 	return &sworld.PortalStone{
@@ -16,9 +20,11 @@ func randomPowerStone(portal *sworld.Portal) sworld.Item {
 }
 
 func randomWeapon(portal *sworld.Portal) sworld.Item {
-	return &sworld.Weapon{
-		Damage: 10,
-	}
+	return portal.RandomWeapon()
+}
+
+func randomConsumable(portal *sworld.Portal) sworld.Item {
+	return portal.RandomConsumable()
 }
 
 func randomItemEvent(portal *sworld.Portal) *sworld.PortalEvent {
@@ -27,6 +33,21 @@ func randomItemEvent(portal *sworld.Portal) *sworld.PortalEvent {
 	return &sworld.PortalEvent{Item: item}
 }
 
+// ambushEvent spawns a group of enemies, bigger on higher levels
+// Only the last spawn is returned as the room event, RandomEnemyEvent already
+// adds every enemy to the portal and starts it, so the others don't need to
+// be handled by the explorer
+func ambushEvent(portal *sworld.Portal, position int) *sworld.PortalEvent {
+	var event *sworld.PortalEvent
+
+	enemies := 1 + (portal.PortalStone.Level / 2)
+	for i := 0; i < enemies; i++ {
+		event = portal.RandomEnemyEvent(position)
+	}
+
+	return event
+}
+
 func createDefaultZone() *sworld.Zone {
 	zone := sworld.NewZone("Forest")
 
@@ -37,21 +58,33 @@ func createDefaultZone() *sworld.Zone {
 	zone.AddItemDrop(2, 2, randomPowerStone)
 	zone.AddItemDrop(3, 6, randomPowerStone)
 	zone.AddItemDrop(1, 10, randomWeapon)
+	zone.AddItemDrop(0, 8, randomConsumable)
+	zone.AddItemDrop(1, 2, randomBagItem)
+	zone.AddItemDrop(0, 15, randomMaterial)
 
-	zone.AddEventDrop(0, 10, func(portal *sworld.Portal, position int) *sworld.PortalEvent {
-		return randomItemEvent(portal)
-	})
-	zone.AddEventDrop(0, 50, func(portal *sworld.Portal, position int) *sworld.PortalEvent {
-		return nil
-	})
-	zone.AddEventDrop(1, 15, func(portal *sworld.Portal, position int) *sworld.PortalEvent {
+	zone.Layout = sworld.NewLayoutGenerator(30, 10, 0.3, 0.05)
+	zone.Layout.MaxDensity = 0.8
+	zone.Layout.AddRoom(0, 30, sworld.TreasureRoom)
+	zone.Layout.AddRoom(0, 10, sworld.ShrineRoom)
+	zone.Layout.AddRoom(0, 10, sworld.RestRoom)
+	zone.Layout.AddRoom(1, 40, sworld.AmbushRoom)
+
+	zone.AddRoomEvent(sworld.TreasureRoom, func(portal *sworld.Portal, position int) *sworld.PortalEvent {
 		return randomItemEvent(portal)
 	})
-	zone.AddEventDrop(1, 30, func(portal *sworld.Portal, position int) *sworld.PortalEvent {
-		return portal.RandomEnemyEvent(position)
+	zone.AddRoomEvent(sworld.AmbushRoom, ambushEvent)
+	zone.AddRoomEvent(sworld.ShrineRoom, func(portal *sworld.Portal, position int) *sworld.PortalEvent {
+		return &sworld.PortalEvent{Gold: (portal.PortalStone.Level + 1) * 10}
 	})
-	zone.AddEventDrop(2, 40, func(portal *sworld.Portal, position int) *sworld.PortalEvent {
-		return portal.RandomEnemyEvent(position)
+	zone.AddRoomEvent(sworld.RestRoom, func(portal *sworld.Portal, position int) *sworld.PortalEvent {
+		return &sworld.PortalEvent{Heal: 25}
 	})
 	return zone
 }
+
+func (s *swService) findZone(zoneID string) (*sworld.Zone, error) {
+	if zoneID == "" || zoneID == s.defaultZone.ID {
+		return s.defaultZone, nil
+	}
+	return nil, ErrZoneNotFound
+}